@@ -0,0 +1,449 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	aclOwner  = "OWNER"
+	aclWriter = "WRITER"
+	aclReader = "READER"
+
+	// emulatorProject names the project in project team entities. Project
+	// membership is not modelled, so those entities are recorded for
+	// fidelity but grant nothing on their own.
+	emulatorProject = "olympus"
+
+	defaultPredefinedAcl = "projectPrivate"
+)
+
+// bucketAclPermissions and objectAclPermissions are the IAM permissions an
+// ACL role stands in for, following the GCS legacy roles. Deleting objects
+// needs WRITER on the bucket; object ACLs only govern reads and updates.
+var bucketAclPermissions = map[string][]string{
+	aclReader: {permBucketsGet, permObjectsList},
+	aclWriter: {permBucketsGet, permObjectsList, permObjectsCreate, permObjectsDelete},
+	aclOwner: {
+		permBucketsGet, permBucketsUpdate, permBucketsGetIamPolicy, permBucketsSetIamPolicy,
+		permObjectsList, permObjectsCreate, permObjectsDelete,
+	},
+}
+
+var objectAclPermissions = map[string][]string{
+	aclReader: {permObjectsGet},
+	aclOwner:  {permObjectsGet, permObjectsUpdate, permObjectsGetIamPolicy, permObjectsSetIamPolicy},
+}
+
+// aclEntry is one stored ACL grant.
+type aclEntry struct {
+	Entity string `json:"entity"`
+	Role   string `json:"role"`
+}
+
+func aclToProto(acl []aclEntry) []*storagev1.AclEntry {
+	var out []*storagev1.AclEntry
+	for _, e := range acl {
+		out = append(out, &storagev1.AclEntry{Entity: e.Entity, Role: e.Role})
+	}
+	return out
+}
+
+// memberEntity converts an IAM member into the ACL entity naming the same
+// caller. Service accounts appear as users in ACLs, as in GCS.
+func memberEntity(member string) string {
+	if member == memberAllUsers || member == memberAllAuthenticatedUsers {
+		return member
+	}
+	kind, id, _ := strings.Cut(member, ":")
+	switch kind {
+	case "user", "serviceAccount":
+		return "user-" + id
+	case "group":
+		return "group-" + id
+	case "domain":
+		return "domain-" + id
+	}
+	return ""
+}
+
+// matchesEntity reports whether entity covers member.
+func matchesEntity(entity, member string) bool {
+	switch entity {
+	case memberAllUsers:
+		return true
+	case memberAllAuthenticatedUsers:
+		return member != ""
+	}
+	if member == "" {
+		return false
+	}
+	if entity == memberEntity(member) {
+		return true
+	}
+	if domain, ok := strings.CutPrefix(entity, "domain-"); ok {
+		_, id, _ := strings.Cut(member, ":")
+		return strings.HasSuffix(id, "@"+domain)
+	}
+	return false
+}
+
+// aclGrants reports whether any entry matching member holds a role that
+// stands in for perm.
+func aclGrants(acl []aclEntry, roles map[string][]string, member, perm string) bool {
+	for _, e := range acl {
+		if matchesEntity(e.Entity, member) && containsString(roles[e.Role], perm) {
+			return true
+		}
+	}
+	return false
+}
+
+func validEntity(entity string) bool {
+	if entity == memberAllUsers || entity == memberAllAuthenticatedUsers {
+		return true
+	}
+	for _, prefix := range []string{"user-", "group-", "domain-", "project-owners-", "project-editors-", "project-viewers-"} {
+		if rest, ok := strings.CutPrefix(entity, prefix); ok && rest != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func aclEntryFromProto(e *storagev1.AclEntry, object bool) (aclEntry, error) {
+	if e == nil {
+		return aclEntry{}, errors.New("entry is required")
+	}
+	if !validEntity(e.Entity) {
+		return aclEntry{}, fmt.Errorf("invalid entity: %q", e.Entity)
+	}
+	role := strings.ToUpper(e.Role)
+	roles := bucketAclPermissions
+	if object {
+		roles = objectAclPermissions
+	}
+	if _, ok := roles[role]; !ok {
+		return aclEntry{}, fmt.Errorf("invalid role: %q", e.Role)
+	}
+	return aclEntry{Entity: e.Entity, Role: role}, nil
+}
+
+// setAclEntry inserts e, replacing the role of an entry for the same entity.
+func setAclEntry(acl []aclEntry, e aclEntry) []aclEntry {
+	for i := range acl {
+		if acl[i].Entity == e.Entity {
+			acl[i].Role = e.Role
+			return acl
+		}
+	}
+	return append(acl, e)
+}
+
+func projectTeams() []aclEntry {
+	return []aclEntry{
+		{Entity: "project-owners-" + emulatorProject, Role: aclOwner},
+		{Entity: "project-editors-" + emulatorProject, Role: aclOwner},
+		{Entity: "project-viewers-" + emulatorProject, Role: aclReader},
+	}
+}
+
+// predefinedBucketAcl expands a GCS predefined bucket ACL for a bucket
+// created by owner.
+func predefinedBucketAcl(name, owner string) ([]aclEntry, error) {
+	var acl []aclEntry
+	if entity := memberEntity(owner); entity != "" {
+		acl = append(acl, aclEntry{Entity: entity, Role: aclOwner})
+	}
+	switch name {
+	case "private":
+	case "", "projectPrivate":
+		acl = append(acl, projectTeams()...)
+	case "publicRead":
+		acl = append(acl, aclEntry{Entity: memberAllUsers, Role: aclReader})
+	case "publicReadWrite":
+		acl = append(acl, aclEntry{Entity: memberAllUsers, Role: aclWriter})
+	case "authenticatedRead":
+		acl = append(acl, aclEntry{Entity: memberAllAuthenticatedUsers, Role: aclReader})
+	default:
+		return nil, fmt.Errorf("unsupported predefined bucket ACL: %q", name)
+	}
+	return acl, nil
+}
+
+// predefinedObjectAcl expands a GCS predefined object ACL for an object
+// written by owner into bucket. Without owner the object ACL only carries the
+// predefined grants.
+func predefinedObjectAcl(name, owner string, bucket *bucketRecord) ([]aclEntry, error) {
+	var acl []aclEntry
+	if entity := memberEntity(owner); entity != "" {
+		acl = append(acl, aclEntry{Entity: entity, Role: aclOwner})
+	}
+	bucketOwners := func(role string) {
+		for _, e := range bucket.Acl {
+			if e.Role == aclOwner {
+				acl = setAclEntry(acl, aclEntry{Entity: e.Entity, Role: role})
+			}
+		}
+	}
+	switch name {
+	case "private":
+	case "projectPrivate":
+		acl = append(acl, projectTeams()...)
+	case "publicRead":
+		acl = append(acl, aclEntry{Entity: memberAllUsers, Role: aclReader})
+	case "authenticatedRead":
+		acl = append(acl, aclEntry{Entity: memberAllAuthenticatedUsers, Role: aclReader})
+	case "bucketOwnerRead":
+		bucketOwners(aclReader)
+	case "bucketOwnerFullControl":
+		bucketOwners(aclOwner)
+	default:
+		return nil, fmt.Errorf("unsupported predefined object ACL: %q", name)
+	}
+	return acl, nil
+}
+
+// newObjectAcl returns the ACL for an object written by owner: the
+// predefined ACL when one is named, otherwise a copy of the bucket's default
+// object ACL. Only predefined ACLs make the writer an owner, so a bare
+// objectCreator cannot read back what it wrote. Buckets with uniform access
+// reject predefined ACLs and give objects none.
+func newObjectAcl(bucket *bucketRecord, predefined, owner string) ([]aclEntry, error) {
+	if bucket.UniformBucketLevelAccess {
+		if predefined != "" {
+			return nil, uniformAccessError(bucket.Name)
+		}
+		return nil, nil
+	}
+	if predefined != "" {
+		acl, err := predefinedObjectAcl(predefined, owner, bucket)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return acl, nil
+	}
+	return append([]aclEntry(nil), bucket.DefaultObjectAcl...), nil
+}
+
+func uniformAccessError(bucket string) error {
+	return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("cannot use ACLs on bucket %s: uniform bucket-level access is enabled", bucket))
+}
+
+// canAccessTx reports whether member holds perm on bucket, or on object in
+// bucket when object is non-empty, through the bucket IAM policy or, unless
+// uniform bucket-level access is on, through the bucket or object ACL.
+func (s *StorageServer) canAccessTx(tx *bbolt.Tx, member, bucket, object, perm string) (bool, error) {
+	policy, err := s.getPolicyRecord(tx, bucket)
+	if err != nil {
+		return false, err
+	}
+	if policy.grants(member, perm) {
+		return true, nil
+	}
+	rec, err := s.getBucketRecord(tx, bucket)
+	if errors.Is(err, errBucketNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if rec.UniformBucketLevelAccess {
+		return false, nil
+	}
+	if aclGrants(rec.Acl, bucketAclPermissions, member, perm) {
+		return true, nil
+	}
+	if object == "" {
+		return false, nil
+	}
+	obj, found, err := s.getObjectRecord(tx, bucket, object)
+	if err != nil || !found {
+		return false, err
+	}
+	return aclGrants(obj.Acl, objectAclPermissions, member, perm), nil
+}
+
+// aclTarget identifies the ACL an ACL RPC addresses.
+type aclTarget struct {
+	bucket        string
+	object        string
+	defaultObject bool
+}
+
+func (t aclTarget) objectRoles() bool {
+	return t.object != "" || t.defaultObject
+}
+
+// readPerm and writePerm are the IAM permissions needed to read and change
+// the target ACL, mirroring GCS, which treats ACLs as part of the policy.
+func (t aclTarget) readPerm() string {
+	if t.object != "" {
+		return permObjectsGetIamPolicy
+	}
+	return permBucketsGetIamPolicy
+}
+
+func (t aclTarget) writePerm() string {
+	if t.object != "" {
+		return permObjectsSetIamPolicy
+	}
+	return permBucketsSetIamPolicy
+}
+
+// withAcl authorizes the caller for perm on the target and runs fn on its
+// ACL inside one transaction. When write is set, the modified ACL returned by
+// fn is stored and the owning resource's metageneration bumped.
+func (s *StorageServer) withAcl(ctx context.Context, member string, t aclTarget, perm string, write bool, fn func([]aclEntry) ([]aclEntry, error)) error {
+	var updated *objectRecord
+	txFn := func(tx *bbolt.Tx) error {
+		bucket, err := s.getBucketRecord(tx, t.bucket)
+		if err != nil {
+			return err
+		}
+		if bucket.UniformBucketLevelAccess {
+			return uniformAccessError(t.bucket)
+		}
+		ok, err := s.canAccessTx(tx, member, t.bucket, t.object, perm)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			slog.Warn("Permission denied", "principal", member, "bucket", t.bucket, "object", t.object, "permission", perm)
+			return permissionError(member, perm, t.bucket)
+		}
+
+		now := time.Now().UTC()
+		switch {
+		case t.object != "":
+			obj, found, err := s.getObjectRecord(tx, t.bucket, t.object)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("%w: %s/%s", errObjectNotFound, t.bucket, t.object)
+			}
+			acl, err := fn(obj.Acl)
+			if err != nil || !write {
+				return err
+			}
+			obj.Acl = acl
+			obj.Metageneration++
+			obj.Updated = now
+			updated = obj
+			return s.putObjectRecord(tx, obj)
+		case t.defaultObject:
+			acl, err := fn(bucket.DefaultObjectAcl)
+			if err != nil || !write {
+				return err
+			}
+			bucket.DefaultObjectAcl = acl
+		default:
+			acl, err := fn(bucket.Acl)
+			if err != nil || !write {
+				return err
+			}
+			bucket.Acl = acl
+		}
+		bucket.Metageneration++
+		bucket.Updated = now
+		return s.putBucketRecord(tx, bucket)
+	}
+
+	var err error
+	if write {
+		err = s.update(ctx, txFn)
+	} else {
+		err = s.view(ctx, txFn)
+	}
+	if err != nil {
+		return objectError(err)
+	}
+	if updated != nil {
+		s.emit(objectEvent{eventType: EventObjectMetadataUpdate, object: updated})
+	}
+	return nil
+}
+
+func (s *StorageServer) ListAcl(ctx context.Context, req *connect.Request[storagev1.ListAclRequest]) (*connect.Response[storagev1.ListAclResponse], error) {
+	slog.Info("ListAcl", "bucket", req.Msg.Bucket, "object", req.Msg.Object, "defaultObjectAcl", req.Msg.DefaultObjectAcl)
+	t := aclTarget{bucket: req.Msg.Bucket, object: req.Msg.Object, defaultObject: req.Msg.DefaultObjectAcl}
+
+	var entries []*storagev1.AclEntry
+	err := s.withAcl(ctx, principalOf(ctx, req.Header()), t, t.readPerm(), false, func(acl []aclEntry) ([]aclEntry, error) {
+		entries = aclToProto(acl)
+		return acl, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&storagev1.ListAclResponse{Entries: entries}), nil
+}
+
+func (s *StorageServer) InsertAcl(ctx context.Context, req *connect.Request[storagev1.InsertAclRequest]) (*connect.Response[storagev1.InsertAclResponse], error) {
+	slog.Info("InsertAcl", "bucket", req.Msg.Bucket, "object", req.Msg.Object, "defaultObjectAcl", req.Msg.DefaultObjectAcl)
+	t := aclTarget{bucket: req.Msg.Bucket, object: req.Msg.Object, defaultObject: req.Msg.DefaultObjectAcl}
+	entry, err := aclEntryFromProto(req.Msg.Entry, t.objectRoles())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	err = s.withAcl(ctx, principalOf(ctx, req.Header()), t, t.writePerm(), true, func(acl []aclEntry) ([]aclEntry, error) {
+		return setAclEntry(acl, entry), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&storagev1.InsertAclResponse{Entry: &storagev1.AclEntry{Entity: entry.Entity, Role: entry.Role}}), nil
+}
+
+func (s *StorageServer) PatchAcl(ctx context.Context, req *connect.Request[storagev1.PatchAclRequest]) (*connect.Response[storagev1.PatchAclResponse], error) {
+	slog.Info("PatchAcl", "bucket", req.Msg.Bucket, "object", req.Msg.Object, "defaultObjectAcl", req.Msg.DefaultObjectAcl)
+	t := aclTarget{bucket: req.Msg.Bucket, object: req.Msg.Object, defaultObject: req.Msg.DefaultObjectAcl}
+	entry, err := aclEntryFromProto(req.Msg.Entry, t.objectRoles())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	err = s.withAcl(ctx, principalOf(ctx, req.Header()), t, t.writePerm(), true, func(acl []aclEntry) ([]aclEntry, error) {
+		for i := range acl {
+			if acl[i].Entity == entry.Entity {
+				acl[i].Role = entry.Role
+				return acl, nil
+			}
+		}
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no ACL entry for entity %s", entry.Entity))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&storagev1.PatchAclResponse{Entry: &storagev1.AclEntry{Entity: entry.Entity, Role: entry.Role}}), nil
+}
+
+func (s *StorageServer) DeleteAcl(ctx context.Context, req *connect.Request[storagev1.DeleteAclRequest]) (*connect.Response[storagev1.DeleteAclResponse], error) {
+	slog.Info("DeleteAcl", "bucket", req.Msg.Bucket, "object", req.Msg.Object, "defaultObjectAcl", req.Msg.DefaultObjectAcl, "entity", req.Msg.Entity)
+	t := aclTarget{bucket: req.Msg.Bucket, object: req.Msg.Object, defaultObject: req.Msg.DefaultObjectAcl}
+
+	err := s.withAcl(ctx, principalOf(ctx, req.Header()), t, t.writePerm(), true, func(acl []aclEntry) ([]aclEntry, error) {
+		for i := range acl {
+			if acl[i].Entity == req.Msg.Entity {
+				return append(acl[:i], acl[i+1:]...), nil
+			}
+		}
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no ACL entry for entity %s", req.Msg.Entity))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&storagev1.DeleteAclResponse{}), nil
+}
@@ -0,0 +1,110 @@
+package inference
+
+import (
+	"context"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestAcls(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	ctx := context.Background()
+
+	alice, bob := "user:alice@example.com", "user:bob@example.com"
+	created, err := server.CreateBucket(ctx, as(alice, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "shared"})))
+	if err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	if acl := created.Msg.Bucket.Acl; len(acl) != 4 || acl[0].Entity != "user-alice@example.com" || acl[0].Role != "OWNER" {
+		t.Errorf("unexpected projectPrivate bucket ACL: %v", acl)
+	}
+
+	upload := func(member, name, predefined string) error {
+		_, err := server.UploadObject(ctx, as(member, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "shared", Name: name, Data: []byte("x"), PredefinedAcl: predefined})))
+		return err
+	}
+	read := func(member, name string) error {
+		_, err := server.GetObjectMetadata(ctx, as(member, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "shared", Name: name})))
+		return err
+	}
+	if err := upload(alice, "public", "publicRead"); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	upload(alice, "hidden", "")
+	if err := read(bob, "public"); err != nil {
+		t.Errorf("publicRead object should be readable, got %v", err)
+	}
+	if err := read("", "public"); err != nil {
+		t.Errorf("publicRead object should be readable anonymously, got %v", err)
+	}
+	if err := read(bob, "hidden"); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied on hidden object, got %v", err)
+	}
+	if err := upload(alice, "bad", "publicReadWrite"); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("publicReadWrite is a bucket-only ACL, got %v", err)
+	}
+
+	// Bucket WRITER lets bob upload; patching down to READER takes it away.
+	_, err = server.InsertAcl(ctx, as(alice, connect.NewRequest(&storagev1.InsertAclRequest{Bucket: "shared", Entry: &storagev1.AclEntry{Entity: "user-bob@example.com", Role: "WRITER"}})))
+	if err != nil {
+		t.Fatalf("InsertAcl failed: %v", err)
+	}
+	if err := upload(bob, "from-bob", "private"); err != nil {
+		t.Errorf("bucket WRITER should upload, got %v", err)
+	}
+	if err := read(bob, "from-bob"); err != nil {
+		t.Errorf("private ACL should make the writer owner, got %v", err)
+	}
+	_, err = server.PatchAcl(ctx, as(alice, connect.NewRequest(&storagev1.PatchAclRequest{Bucket: "shared", Entry: &storagev1.AclEntry{Entity: "user-bob@example.com", Role: "READER"}})))
+	if err != nil {
+		t.Fatalf("PatchAcl failed: %v", err)
+	}
+	if err := upload(bob, "again", ""); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("bucket READER must not upload, got %v", err)
+	}
+	if _, err := server.ListObjects(ctx, as(bob, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "shared"}))); err != nil {
+		t.Errorf("bucket READER should list, got %v", err)
+	}
+
+	// Object ACL edits by the object owner.
+	_, err = server.InsertAcl(ctx, as(bob, connect.NewRequest(&storagev1.InsertAclRequest{Bucket: "shared", Object: "from-bob", Entry: &storagev1.AclEntry{Entity: "domain-example.org", Role: "READER"}})))
+	if err != nil {
+		t.Fatalf("object InsertAcl failed: %v", err)
+	}
+	if err := read("user:carol@example.org", "from-bob"); err != nil {
+		t.Errorf("domain READER should read, got %v", err)
+	}
+	_, err = server.DeleteAcl(ctx, as(bob, connect.NewRequest(&storagev1.DeleteAclRequest{Bucket: "shared", Object: "from-bob", Entity: "domain-example.org"})))
+	if err != nil {
+		t.Fatalf("DeleteAcl failed: %v", err)
+	}
+	if err := read("user:carol@example.org", "from-bob"); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied after DeleteAcl, got %v", err)
+	}
+	if _, err := server.InsertAcl(ctx, as(bob, connect.NewRequest(&storagev1.InsertAclRequest{Bucket: "shared", Object: "public", Entry: &storagev1.AclEntry{Entity: "allUsers", Role: "OWNER"}}))); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("only owners may edit an object ACL, got %v", err)
+	}
+
+	// Uniform bucket-level access switches ACLs off.
+	_, err = server.UpdateBucket(ctx, as(alice, connect.NewRequest(&storagev1.UpdateBucketRequest{
+		Bucket:     &storagev1.Bucket{Name: "shared", UniformBucketLevelAccess: true},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"uniform_bucket_level_access"}},
+	})))
+	if err != nil {
+		t.Fatalf("UpdateBucket failed: %v", err)
+	}
+	if err := read(bob, "public"); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("ACLs must not apply under uniform access, got %v", err)
+	}
+	if _, err := server.ListAcl(ctx, as(alice, connect.NewRequest(&storagev1.ListAclRequest{Bucket: "shared"}))); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("expected FailedPrecondition from ListAcl, got %v", err)
+	}
+	if err := upload(alice, "public2", "publicRead"); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("expected FailedPrecondition for predefined ACL, got %v", err)
+	}
+}
@@ -0,0 +1,744 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+// A state archive is a tar file holding, in order:
+//
+//	manifest.json          archiveManifest
+//	buckets/{bucket}.json  archiveBucket, one per bucket
+//	early_deletions.json   the early deletion charges
+//	objects/{bucket}/{name} and data/{bucket}/{name}
+//	                       each object record, then its stored bytes
+//
+// Readers reject archives of a newer version than archiveVersion.
+const (
+	archiveFormat  = "olympus-storage-state"
+	archiveVersion = 1
+
+	archiveManifestName       = "manifest.json"
+	archiveEarlyDeletionsName = "early_deletions.json"
+)
+
+// Import modes.
+const (
+	// ImportMerge replaces the buckets and objects an archive holds and
+	// keeps all others.
+	ImportMerge = "merge"
+	// ImportReplace discards all state before importing.
+	ImportReplace = "replace"
+)
+
+var errInvalidArchive = errors.New("invalid state archive")
+
+type archiveManifest struct {
+	Format  string    `json:"format"`
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Buckets int       `json:"buckets"`
+	Objects int       `json:"objects"`
+}
+
+// archiveBucket is a bucket record with the configuration kept beside it.
+type archiveBucket struct {
+	Bucket        *bucketRecord         `json:"bucket"`
+	Policy        *policyRecord         `json:"policy"`
+	Notifications []*notificationRecord `json:"notifications,omitempty"`
+}
+
+// ArchiveSummary counts what a state archive holds.
+type ArchiveSummary struct {
+	Buckets int `json:"buckets"`
+	Objects int `json:"objects"`
+}
+
+// bucketCheck authorizes access to an existing bucket during an export or
+// import.
+type bucketCheck func(tx *bbolt.Tx, bucket string) error
+
+// ExportArchive writes the whole storage state to w as a tar archive for
+// ImportArchive. Records are written decrypted, so no metadata key is needed
+// to read them back; object data is written as stored, so encrypted objects
+// still need their KMS or customer keys. The archive is a snapshot of the
+// records when the export starts, and writes go on while it streams. Blobs
+// are pinned until their data is written; an object stored by name that is
+// replaced or deleted before its data is read fails the export with
+// errObjectChanged.
+func (s *StorageServer) ExportArchive(ctx context.Context, w io.Writer) (*ArchiveSummary, error) {
+	return s.exportArchive(ctx, w, nil)
+}
+
+func (s *StorageServer) exportArchive(ctx context.Context, w io.Writer, check bucketCheck) (*ArchiveSummary, error) {
+	now := time.Now().UTC()
+	var buckets []*archiveBucket
+	deletions := []*earlyDeletionRecord{}
+	var objects []*objectRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucketBuckets))
+		err := b.ForEach(func(k, v []byte) error {
+			var rec bucketRecord
+			if err := s.decodeRecord(bucketBuckets, string(k), v, &rec); err != nil {
+				return err
+			}
+			buckets = append(buckets, &archiveBucket{Bucket: &rec})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, ab := range buckets {
+			name := ab.Bucket.Name
+			if check != nil {
+				if err := check(tx, name); err != nil {
+					return err
+				}
+			}
+			if ab.Policy, err = s.getPolicyRecord(tx, name); err != nil {
+				return err
+			}
+			if ab.Notifications, err = s.listNotificationRecords(tx, name); err != nil {
+				return err
+			}
+			err := s.scanObjects(tx, name, "", "", func(rec *objectRecord) (bool, error) {
+				objects = append(objects, rec)
+				return true, nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		eb := tx.Bucket([]byte(bucketEarlyDeletions))
+		return eb.ForEach(func(k, v []byte) error {
+			var rec earlyDeletionRecord
+			if err := s.decodeRecord(bucketEarlyDeletions, string(k), v, &rec); err != nil {
+				return err
+			}
+			deletions = append(deletions, &rec)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	unpin, err := s.pinObjectBlobs(ctx, objects)
+	if err != nil {
+		return nil, err
+	}
+	defer unpin()
+
+	sum := &ArchiveSummary{Buckets: len(buckets), Objects: len(objects)}
+	tw := tar.NewWriter(w)
+	manifest := &archiveManifest{Format: archiveFormat, Version: archiveVersion, Created: now, Buckets: sum.Buckets, Objects: sum.Objects}
+	if err := writeArchiveJSON(tw, archiveManifestName, now, manifest); err != nil {
+		return nil, err
+	}
+	for _, ab := range buckets {
+		if err := writeArchiveJSON(tw, "buckets/"+ab.Bucket.Name+".json", ab.Bucket.Updated, ab); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeArchiveJSON(tw, archiveEarlyDeletionsName, now, deletions); err != nil {
+		return nil, err
+	}
+	for _, rec := range objects {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		key := objectKey(rec.Bucket, rec.Name)
+		data, err := s.readSnapshotData(ctx, rec)
+		if errors.Is(err, errObjectNotFound) || errors.Is(err, errObjectChanged) {
+			return nil, fmt.Errorf("%w: %s changed during the export", errObjectChanged, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", key, err)
+		}
+		// Storage details are the importing server's to choose.
+		rec.Blobs = nil
+		rec.StoredCRC32C = nil
+		if err := writeArchiveJSON(tw, "objects/"+key, rec.Updated, rec); err != nil {
+			return nil, err
+		}
+		if err := writeArchiveFile(tw, "data/"+key, rec.Updated, data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return sum, nil
+}
+
+// pinObjectBlobs pins the blobs of objects, records read in an earlier
+// transaction, and returns the function unpinning them. It fails with
+// errObjectChanged if a collection removed any of them in between.
+func (s *StorageServer) pinObjectBlobs(ctx context.Context, objects []*objectRecord) (func(), error) {
+	var pinned []string
+	unpin := func() {
+		for _, hash := range pinned {
+			s.unpinBlob(hash)
+		}
+	}
+	for _, rec := range objects {
+		for _, ref := range rec.Blobs {
+			s.pinBlob(ref.Hash)
+			pinned = append(pinned, ref.Hash)
+		}
+	}
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucketBlobs))
+		for _, hash := range pinned {
+			if b.Get([]byte(hash)) == nil {
+				return fmt.Errorf("%w: blob %s was collected", errObjectChanged, hash)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		unpin()
+		return nil, err
+	}
+	return unpin, nil
+}
+
+// readSnapshotData returns the stored bytes of rec, a record read in an
+// earlier transaction. Data stored by name is read under its data lock,
+// once rec is found to still be the object's live generation; pinned blobs
+// need no lock.
+func (s *StorageServer) readSnapshotData(ctx context.Context, rec *objectRecord) ([]byte, error) {
+	if rec.contentAddressed() {
+		return s.readStoredData(ctx, rec)
+	}
+	unlock := s.lockObjectData(rec.Bucket, rec.Name)
+	defer unlock()
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		_, err := s.currentObject(tx, rec)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.readStoredData(ctx, rec)
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, modified time.Time, data []byte) error {
+	hdr := &tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modified}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func writeArchiveJSON(tw *tar.Writer, name string, modified time.Time, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeArchiveFile(tw, name, modified, data)
+}
+
+// ImportArchive restores an archive written by ExportArchive in mode
+// ImportMerge or ImportReplace. Imported objects keep their generations and
+// timestamps; no notifications are sent and quotas do not apply. The
+// archive is spooled to a temporary directory and checked in full before
+// anything changes. Object data is then staged, the records applied in one
+// transaction and data stored by name placed once it commits, so a bad
+// archive or failed transaction leaves the state as it was.
+func (s *StorageServer) ImportArchive(ctx context.Context, r io.Reader, mode string) (*ArchiveSummary, error) {
+	return s.importArchive(ctx, r, mode, nil)
+}
+
+// spooledArchive is an archive read in full and checked, with the data of
+// each object spooled to a file.
+type spooledArchive struct {
+	buckets []*archiveBucket
+	// deletions is nil when the archive holds no early deletion charges.
+	deletions []*earlyDeletionRecord
+	objects   []*objectRecord
+	data      []string
+}
+
+// spoolArchive reads the archive from r, spooling object data into dir.
+func spoolArchive(ctx context.Context, r io.Reader, dir string) (*spooledArchive, error) {
+	tr := tar.NewReader(r)
+	next := func() (*tar.Header, error) {
+		hdr, err := tr.Next()
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("%w: %w", errInvalidArchive, err)
+		}
+		return hdr, err
+	}
+	hdr, err := next()
+	if err == io.EOF || (err == nil && hdr.Name != archiveManifestName) {
+		return nil, fmt.Errorf("%w: %s must come first", errInvalidArchive, archiveManifestName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifest archiveManifest
+	if err := readArchiveJSON(tr, hdr, &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Format != archiveFormat {
+		return nil, fmt.Errorf("%w: unknown format %q", errInvalidArchive, manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > archiveVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", errInvalidArchive, manifest.Version)
+	}
+
+	a := &spooledArchive{}
+	var pending *objectRecord
+	for {
+		hdr, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		kind, path, _ := strings.Cut(hdr.Name, "/")
+		if pending != nil && hdr.Name != "data/"+objectKey(pending.Bucket, pending.Name) {
+			return nil, fmt.Errorf("%w: object %s has no data", errInvalidArchive, objectKey(pending.Bucket, pending.Name))
+		}
+		switch {
+		case hdr.Name == archiveEarlyDeletionsName:
+			a.deletions = []*earlyDeletionRecord{}
+			if err := readArchiveJSON(tr, hdr, &a.deletions); err != nil {
+				return nil, err
+			}
+
+		case kind == "buckets" && strings.HasSuffix(path, ".json"):
+			var ab archiveBucket
+			if err := readArchiveJSON(tr, hdr, &ab); err != nil {
+				return nil, err
+			}
+			if ab.Bucket == nil || ab.Bucket.Name+".json" != path {
+				return nil, fmt.Errorf("%w: %s does not hold its bucket", errInvalidArchive, hdr.Name)
+			}
+			if err := validateBucketName(ab.Bucket.Name); err != nil {
+				return nil, fmt.Errorf("%w: %v", errInvalidArchive, err)
+			}
+			a.buckets = append(a.buckets, &ab)
+
+		case kind == "objects":
+			pending = &objectRecord{}
+			if err := readArchiveJSON(tr, hdr, pending); err != nil {
+				return nil, err
+			}
+			if objectKey(pending.Bucket, pending.Name) != path {
+				return nil, fmt.Errorf("%w: %s does not hold its object", errInvalidArchive, hdr.Name)
+			}
+			if err := validateObjectName(pending.Name); err != nil {
+				return nil, fmt.Errorf("%w: %v", errInvalidArchive, err)
+			}
+
+		case kind == "data" && pending != nil:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", errInvalidArchive, err)
+			}
+			name := filepath.Join(dir, strconv.Itoa(len(a.objects)))
+			if err := os.WriteFile(name, data, 0600); err != nil {
+				return nil, err
+			}
+			a.objects = append(a.objects, pending)
+			a.data = append(a.data, name)
+			pending = nil
+
+		default:
+			return nil, fmt.Errorf("%w: unexpected entry %s", errInvalidArchive, hdr.Name)
+		}
+	}
+	if pending != nil {
+		return nil, fmt.Errorf("%w: object %s has no data", errInvalidArchive, objectKey(pending.Bucket, pending.Name))
+	}
+	if len(a.buckets) != manifest.Buckets || len(a.objects) != manifest.Objects {
+		return nil, fmt.Errorf("%w: manifest lists %d buckets and %d objects, archive holds %d and %d",
+			errInvalidArchive, manifest.Buckets, manifest.Objects, len(a.buckets), len(a.objects))
+	}
+	return a, nil
+}
+
+func (s *StorageServer) importArchive(ctx context.Context, r io.Reader, mode string, check bucketCheck) (*ArchiveSummary, error) {
+	if mode != ImportMerge && mode != ImportReplace {
+		return nil, fmt.Errorf("%w: unknown import mode %q: use %q or %q", errInvalidArchive, mode, ImportMerge, ImportReplace)
+	}
+	dir, err := os.MkdirTemp("", "olympus-import-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	a, err := spoolArchive(ctx, r, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	writes := make([]*objectWrite, 0, len(a.objects))
+	defer func() {
+		for _, w := range writes {
+			w.close()
+		}
+	}()
+	for i, rec := range a.objects {
+		data, err := os.ReadFile(a.data[i])
+		if err != nil {
+			return nil, err
+		}
+		w := &objectWrite{s: s, bucket: rec.Bucket, name: rec.Name, placeLater: true}
+		writes = append(writes, w)
+		if err := w.stage(ctx, data); err != nil {
+			return nil, err
+		}
+	}
+
+	// The data locks of every imported object are held, as writers hold
+	// theirs, until the data stored by name is placed.
+	unlock := s.lockObjectDataAll(a.objects)
+	defer func() {
+		if unlock != nil {
+			unlock()
+		}
+	}()
+	// Data stored by name is only deleted once the import has committed.
+	var cleared []*objectRecord
+	var clearedBuckets []string
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		// authorized holds the buckets the caller may overwrite: those
+		// checked, and those the import created.
+		authorized := map[string]bool{}
+		authorize := func(bucket string) error {
+			if check == nil || authorized[bucket] {
+				return nil
+			}
+			if _, err := s.getBucketRecord(tx, bucket); errors.Is(err, errBucketNotFound) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			if err := check(tx, bucket); err != nil {
+				return err
+			}
+			authorized[bucket] = true
+			return nil
+		}
+		var err error
+		if mode == ImportReplace {
+			if cleared, clearedBuckets, err = s.clearState(tx, authorize); err != nil {
+				return err
+			}
+		}
+
+		archived := map[string]bool{}
+		for _, ab := range a.buckets {
+			if err := authorize(ab.Bucket.Name); err != nil {
+				return err
+			}
+			authorized[ab.Bucket.Name] = true
+			archived[ab.Bucket.Name] = true
+			if err := s.importBucket(tx, ab, s.admins); err != nil {
+				return err
+			}
+		}
+		if a.deletions != nil {
+			// Charges accrued in the archived buckets replace theirs.
+			if err := s.deleteEarlyDeletions(tx, archived); err != nil {
+				return err
+			}
+			for _, rec := range a.deletions {
+				if err := s.putEarlyDeletion(tx, rec); err != nil {
+					return err
+				}
+			}
+		}
+		for i, rec := range a.objects {
+			if err := authorize(rec.Bucket); err != nil {
+				return err
+			}
+			if _, err := s.getBucketRecord(tx, rec.Bucket); err != nil {
+				return fmt.Errorf("%w: object %s: %w", errInvalidArchive, objectKey(rec.Bucket, rec.Name), err)
+			}
+			if err := writes[i].put(ctx, tx, rec); err != nil {
+				return err
+			}
+			s.observeGeneration(rec.Generation)
+		}
+
+		// Bucket projects may have changed under existing objects.
+		if err := tx.DeleteBucket([]byte(bucketUsage)); err != nil {
+			return err
+		}
+		return s.computeUsage(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var placeErr error
+	imported := map[string]bool{}
+	for i, rec := range a.objects {
+		imported[objectKey(rec.Bucket, rec.Name)] = true
+		if s.contentAddressed {
+			continue
+		}
+		if err := writes[i].place(ctx); err != nil && placeErr == nil {
+			placeErr = fmt.Errorf("failed to place %s: %w", objectKey(rec.Bucket, rec.Name), err)
+		}
+	}
+	unlock()
+	unlock = nil
+	for _, w := range writes {
+		w.finish(ctx)
+	}
+	for _, rec := range cleared {
+		if !imported[objectKey(rec.Bucket, rec.Name)] {
+			s.removeObjectData(ctx, rec.Bucket, rec.Name)
+		}
+	}
+	if backend, ok := s.backend.(legacyBucketBackend); ok {
+		for _, bucket := range clearedBuckets {
+			if s.bucketExists(bucket) {
+				continue
+			}
+			if err := backend.RemoveBucket(ctx, bucket); err != nil {
+				slog.Warn("Failed to remove replaced bucket", "bucket", bucket, "error", err)
+			}
+		}
+	}
+	if placeErr != nil {
+		return nil, placeErr
+	}
+	return &ArchiveSummary{Buckets: len(a.buckets), Objects: len(a.objects)}, nil
+}
+
+func readArchiveJSON(tr *tar.Reader, hdr *tar.Header, v any) error {
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArchive, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s: %v", errInvalidArchive, hdr.Name, err)
+	}
+	return nil
+}
+
+// clearState deletes every bucket and object record after authorizing each
+// bucket. It returns the records of objects stored by name, whose data the
+// caller deletes once the import commits, and the bucket names.
+func (s *StorageServer) clearState(tx *bbolt.Tx, authorize func(string) error) ([]*objectRecord, []string, error) {
+	var buckets []string
+	err := tx.Bucket([]byte(bucketBuckets)).ForEach(func(k, _ []byte) error {
+		buckets = append(buckets, string(k))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var byName []*objectRecord
+	for _, bucket := range buckets {
+		if err := authorize(bucket); err != nil {
+			return nil, nil, err
+		}
+		var records []*objectRecord
+		err := s.scanObjects(tx, bucket, "", "", func(rec *objectRecord) (bool, error) {
+			records = append(records, rec)
+			return true, nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, rec := range records {
+			// Deleting through the index drops blob references.
+			if err := s.deleteObjectRecord(tx, rec.Bucket, rec.Name); err != nil {
+				return nil, nil, err
+			}
+			if !rec.contentAddressed() {
+				byName = append(byName, rec)
+			}
+		}
+	}
+	for _, name := range []string{bucketBuckets, bucketIAM, bucketNotifications, bucketEarlyDeletions, bucketObjects} {
+		if err := tx.DeleteBucket([]byte(name)); err != nil {
+			return nil, nil, err
+		}
+		if _, err := tx.CreateBucket([]byte(name)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return byName, buckets, nil
+}
+
+// importBucket stores the bucket record, policy and notification configs of
+// ab in place of any the bucket had. Buckets archived without a policy go
+// to owners, if any.
+func (s *StorageServer) importBucket(tx *bbolt.Tx, ab *archiveBucket, owners []string) error {
+	name := ab.Bucket.Name
+	if err := s.putBucketRecord(tx, ab.Bucket); err != nil {
+		return err
+	}
+	if ab.Policy == nil && len(owners) > 0 {
+		ab.Policy = defaultPolicy("", owners)
+	}
+	if ab.Policy != nil {
+		if err := s.putPolicyRecord(tx, name, ab.Policy); err != nil {
+			return err
+		}
+	} else if err := tx.Bucket([]byte(bucketIAM)).Delete([]byte(name)); err != nil {
+		return err
+	}
+	existing, err := s.listNotificationRecords(tx, name)
+	if err != nil {
+		return err
+	}
+	b := tx.Bucket([]byte(bucketNotifications))
+	for _, rec := range existing {
+		if err := b.Delete([]byte(name + "/" + rec.ID)); err != nil {
+			return err
+		}
+	}
+	for _, rec := range ab.Notifications {
+		rec.Bucket = name
+		if err := s.putRecord(b, bucketNotifications, name+"/"+rec.ID, rec); err != nil {
+			return err
+		}
+		// Keep new IDs from colliding with imported ones.
+		if id, err := strconv.ParseUint(rec.ID, 10, 64); err == nil && id > b.Sequence() {
+			if err := b.SetSequence(id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *StorageServer) bucketExists(name string) bool {
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		_, err := s.getBucketRecord(tx, name)
+		return err
+	})
+	return err == nil
+}
+
+// archiveError maps export and import failures onto Connect codes.
+func archiveError(err error) error {
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) && errors.Is(err, errInvalidArchive) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if errors.Is(err, context.Canceled) {
+		return connect.NewError(connect.CodeCanceled, err)
+	}
+	return objectError(err)
+}
+
+// sendWriter splits what is written to it into messages of at most
+// readChunkSize bytes.
+type sendWriter func([]byte) error
+
+func (f sendWriter) Write(p []byte) (int, error) {
+	for n := 0; n < len(p); n += readChunkSize {
+		if err := f(p[n:min(n+readChunkSize, len(p))]); err != nil {
+			return n, err
+		}
+	}
+	return len(p), nil
+}
+
+// ExportState streams ExportArchive. The caller must be a server
+// administrator with getIamPolicy and objects.get on every bucket.
+func (s *StorageServer) ExportState(ctx context.Context, req *connect.Request[storagev1.ExportStateRequest], stream *connect.ServerStream[storagev1.ExportStateResponse]) error {
+	member := principalOf(ctx, req.Header())
+	slog.Info("ExportState", "principal", member)
+	if err := s.authorizeAdmin(ctx, req.Header(), "export server state"); err != nil {
+		return err
+	}
+
+	w := bufio.NewWriterSize(sendWriter(func(p []byte) error {
+		return stream.Send(&storagev1.ExportStateResponse{Data: p})
+	}), readChunkSize)
+	sum, err := s.exportArchive(ctx, w, func(tx *bbolt.Tx, bucket string) error {
+		return s.authorizeTx(tx, member, bucket, permBucketsGetIamPolicy, permObjectsGet)
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		return archiveError(err)
+	}
+	slog.Info("Exported state", "buckets", sum.Buckets, "objects", sum.Objects)
+	return nil
+}
+
+// importStream reads the data of an ImportState stream.
+type importStream struct {
+	stream *connect.ClientStream[storagev1.ImportStateRequest]
+	buf    []byte
+}
+
+func (r *importStream) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		r.buf = r.stream.Msg().Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// ImportState streams an archive into ImportArchive. The caller must be a
+// server administrator with setIamPolicy, objects.create and objects.delete
+// on every existing bucket the import overwrites, which in replace mode is
+// every bucket.
+func (s *StorageServer) ImportState(ctx context.Context, stream *connect.ClientStream[storagev1.ImportStateRequest]) (*connect.Response[storagev1.ImportStateResponse], error) {
+	member := principalOf(ctx, stream.RequestHeader())
+	if err := s.authorizeAdmin(ctx, stream.RequestHeader(), "import server state"); err != nil {
+		return nil, err
+	}
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("empty import stream"))
+	}
+	mode := stream.Msg().Mode
+	if mode == "" {
+		mode = ImportMerge
+	}
+	slog.Info("ImportState", "mode", mode, "principal", member)
+
+	r := &importStream{stream: stream, buf: stream.Msg().Data}
+	sum, err := s.importArchive(ctx, r, mode, func(tx *bbolt.Tx, bucket string) error {
+		return s.authorizeTx(tx, member, bucket, permBucketsSetIamPolicy, permObjectsCreate, permObjectsDelete)
+	})
+	if err != nil {
+		return nil, archiveError(err)
+	}
+	slog.Info("Imported state", "mode", mode, "buckets", sum.Buckets, "objects", sum.Objects)
+	return connect.NewResponse(&storagev1.ImportStateResponse{Buckets: int64(sum.Buckets), Objects: int64(sum.Objects)}), nil
+}
@@ -0,0 +1,300 @@
+package inference
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+const alice = "user:alice@example.com"
+
+// archiveSource returns a content-addressed server holding two buckets: one
+// private to alice, and one open with a notification config.
+func archiveSource(t *testing.T) *StorageServer {
+	t.Helper()
+	server := NewStorageServer("", WithInMemory(), WithContentAddressing())
+	ctx := context.Background()
+	server.CreateBucket(ctx, as(alice, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "alpha", Labels: map[string]string{"team": "qa"}})))
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "beta"}))
+	server.CreateNotificationConfig(ctx, connect.NewRequest(&storagev1.CreateNotificationConfigRequest{
+		Bucket:             "beta",
+		NotificationConfig: &storagev1.NotificationConfig{Topic: "projects/demo/topics/uploads"},
+	}))
+	for _, o := range []struct{ bucket, name, data, member string }{
+		{"alpha", "a", "apple", alice},
+		{"alpha", "dir/b", "banana", alice},
+		{"beta", "c", "apple", ""},
+	} {
+		_, err := server.UploadObject(ctx, as(o.member, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: o.bucket, Name: o.name, Data: []byte(o.data), Metadata: map[string]string{"k": o.name}})))
+		if err != nil {
+			t.Fatalf("UploadObject %s failed: %v", o.name, err)
+		}
+	}
+	return server
+}
+
+func TestStateArchive(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	src := archiveSource(t)
+	defer src.Close()
+	var archive bytes.Buffer
+	sum, err := src.ExportArchive(ctx, &archive)
+	if err != nil || sum.Buckets != 2 || sum.Objects != 3 {
+		t.Fatalf("ExportArchive: %+v (%v)", sum, err)
+	}
+	srcMeta, _ := src.GetObjectMetadata(ctx, as(alice, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "alpha", Name: "a"})))
+
+	dst := NewStorageServer("", WithInMemory())
+	defer dst.Close()
+	dst.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "beta"}))
+	dst.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "gamma"}))
+	for _, key := range [][2]string{{"beta", "old"}, {"beta", "c"}, {"gamma", "g"}} {
+		dst.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: key[0], Name: key[1], Data: []byte("local")}))
+	}
+	listing := func(bucket string) []string {
+		t.Helper()
+		resp, err := dst.ListObjects(ctx, as(alice, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: bucket})))
+		if err != nil {
+			return nil
+		}
+		return resp.Msg.ObjectNames
+	}
+	read := func(bucket, name string) string {
+		t.Helper()
+		obj, err := dst.lookupObject(ctx, bucket, name)
+		if err != nil {
+			t.Fatalf("lookup %s/%s: %v", bucket, name, err)
+		}
+		data, err := dst.readStoredData(ctx, obj)
+		if err != nil {
+			t.Fatalf("read %s/%s: %v", bucket, name, err)
+		}
+		return string(data)
+	}
+
+	if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), ImportMerge); err != nil {
+		t.Fatalf("merge import failed: %v", err)
+	}
+	if got := listing("alpha"); !slices.Equal(got, []string{"a", "dir/b"}) {
+		t.Errorf("unexpected alpha listing %v", got)
+	}
+	if got := listing("beta"); !slices.Equal(got, []string{"c", "old"}) {
+		t.Errorf("merge must keep objects the archive does not hold: %v", got)
+	}
+	if got := listing("gamma"); !slices.Equal(got, []string{"g"}) {
+		t.Errorf("merge must keep buckets the archive does not hold: %v", got)
+	}
+	if got := read("beta", "c"); got != "apple" {
+		t.Errorf("beta/c not replaced: %q", got)
+	}
+	meta, err := dst.GetObjectMetadata(ctx, as(alice, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "alpha", Name: "a"})))
+	if err != nil || meta.Msg.Generation != srcMeta.Msg.Generation || meta.Msg.Metadata["k"] != "a" {
+		t.Errorf("record not imported intact: %v (%v)", meta, err)
+	}
+	if _, err := dst.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "alpha", Name: "a"})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("policy not imported: %v", err)
+	}
+	bucket, err := dst.GetBucket(ctx, as(alice, connect.NewRequest(&storagev1.GetBucketRequest{Name: "alpha"})))
+	if err != nil || bucket.Msg.Bucket.Labels["team"] != "qa" {
+		t.Errorf("bucket config not imported: %v (%v)", bucket, err)
+	}
+	configs, err := dst.ListNotificationConfigs(ctx, connect.NewRequest(&storagev1.ListNotificationConfigsRequest{Bucket: "beta"}))
+	if err != nil || len(configs.Msg.NotificationConfigs) != 1 {
+		t.Errorf("notification configs not imported: %v (%v)", configs, err)
+	}
+	usage, err := dst.GetStorageUsage(ctx, connect.NewRequest(&storagev1.GetStorageUsageRequest{Bucket: "beta"}))
+	if err != nil || usage.Msg.Objects != 2 || usage.Msg.Bytes != 10 {
+		t.Errorf("usage not recomputed: %v (%v)", usage, err)
+	}
+	up, err := dst.UploadObject(ctx, as(alice, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "alpha", Name: "a", Data: []byte("apricot")})))
+	if err != nil || up.Msg.Generation <= srcMeta.Msg.Generation {
+		t.Errorf("generation after import must exceed the imported one: %v (%v)", up, err)
+	}
+
+	if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), ImportReplace); err != nil {
+		t.Fatalf("replace import failed: %v", err)
+	}
+	if got := listing("beta"); !slices.Equal(got, []string{"c"}) {
+		t.Errorf("replace must drop objects the archive does not hold: %v", got)
+	}
+	if _, err := dst.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: "gamma"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("replace must drop buckets the archive does not hold: %v", err)
+	}
+	if got := read("alpha", "a"); got != "apple" {
+		t.Errorf("alpha/a not restored: %q", got)
+	}
+	usage, err = dst.GetStorageUsage(ctx, connect.NewRequest(&storagev1.GetStorageUsageRequest{Bucket: "beta"}))
+	if err != nil || usage.Msg.Objects != 1 || usage.Msg.Bytes != 5 {
+		t.Errorf("usage not recomputed after replace: %v (%v)", usage, err)
+	}
+
+	// A cut archive must not be applied, nor any of its data placed.
+	if _, err := dst.UploadObject(ctx, as(alice, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "alpha", Name: "a", Data: []byte("apricot")}))); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	truncated := archive.Bytes()[:archive.Len()-2048]
+	if _, err := dst.ImportArchive(ctx, bytes.NewReader(truncated), ImportReplace); !errors.Is(err, errInvalidArchive) {
+		t.Errorf("expected a truncated archive to be rejected, got %v", err)
+	}
+	if got := listing("alpha"); len(got) != 2 {
+		t.Errorf("failed import changed records: %v", got)
+	}
+	if got := read("alpha", "a"); got != "apricot" {
+		t.Errorf("failed import changed stored data: %q", got)
+	}
+	if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), "overwrite"); !errors.Is(err, errInvalidArchive) {
+		t.Errorf("expected an unknown mode to be rejected, got %v", err)
+	}
+}
+
+func TestStateArchiveSnapshot(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	// startExport begins exporting server into a pipe and returns once the
+	// snapshot is taken, with the export blocked on its first write.
+	startExport := func(server *StorageServer) (io.Reader, chan error) {
+		r, w := io.Pipe()
+		done := make(chan error, 1)
+		go func() {
+			_, err := server.ExportArchive(ctx, w)
+			w.CloseWithError(err)
+			done <- err
+		}()
+		first := make([]byte, 1)
+		if _, err := io.ReadFull(r, first); err != nil {
+			t.Fatalf("export failed to start: %v", err)
+		}
+		return io.MultiReader(bytes.NewReader(first), r), done
+	}
+
+	// Writes and collections go on during an export, which still holds the
+	// data of its snapshot.
+	src := archiveSource(t)
+	defer src.Close()
+	r, done := startExport(src)
+	if _, err := src.UploadObject(ctx, as(alice, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "alpha", Name: "a", Data: []byte("avocado")}))); err != nil {
+		t.Fatalf("UploadObject during export failed: %v", err)
+	}
+	if _, err := src.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "beta", Name: "c"})); err != nil {
+		t.Fatalf("DeleteObject during export failed: %v", err)
+	}
+	if _, err := src.CollectGarbage(ctx); err != nil {
+		t.Fatalf("CollectGarbage during export failed: %v", err)
+	}
+	archive, err := io.ReadAll(r)
+	if err != nil || <-done != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	dst := NewStorageServer("", WithInMemory())
+	defer dst.Close()
+	if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive), ImportReplace); err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	for _, key := range [][2]string{{"alpha", "a"}, {"beta", "c"}} {
+		obj, err := dst.lookupObject(ctx, key[0], key[1])
+		if err != nil {
+			t.Fatalf("lookup %s/%s: %v", key[0], key[1], err)
+		}
+		if data, err := dst.readStoredData(ctx, obj); err != nil || string(data) != "apple" {
+			t.Errorf("expected %s/%s as of the snapshot, got %q (%v)", key[0], key[1], data, err)
+		}
+	}
+
+	// Data stored by name is not kept, so replacing it fails the export.
+	named := NewStorageServer("", WithInMemory())
+	defer named.Close()
+	named.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	named.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("old")}))
+	r, done = startExport(named)
+	if _, err := named.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("new")})); err != nil {
+		t.Fatalf("UploadObject during export failed: %v", err)
+	}
+	io.Copy(io.Discard, r)
+	if err := <-done; !errors.Is(err, errObjectChanged) {
+		t.Errorf("expected the replaced object to fail the export, got %v", err)
+	}
+}
+
+func TestStateArchiveRPC(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	src := archiveSource(t)
+	defer src.Close()
+	// Named after the buckets exist, so the open bucket stays open.
+	src.admins = []string{alice}
+	dst := NewStorageServer("", WithInMemory(), WithAdmins(alice, "user:bob@example.com"))
+	defer dst.Close()
+	dst.CreateBucket(ctx, as(alice, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "alpha"})))
+
+	auth, err := NewAuthInterceptor(AuthConfig{Dev: true})
+	if err != nil {
+		t.Fatalf("NewAuthInterceptor failed: %v", err)
+	}
+	auth.tokens = map[string]string{"alice": alice, "bob": "user:bob@example.com"}
+	client := func(server *StorageServer) storagev1connect.StorageServiceClient {
+		mux := http.NewServeMux()
+		mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(auth)))
+		httpServer := httptest.NewServer(mux)
+		t.Cleanup(httpServer.Close)
+		return storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	}
+	srcClient, dstClient := client(src), client(dst)
+
+	export := func(req *connect.Request[storagev1.ExportStateRequest]) ([]byte, error) {
+		stream, err := srcClient.ExportState(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return receiveAll(stream)
+	}
+	if _, err := export(as(alice, connect.NewRequest(&storagev1.ExportStateRequest{}))); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated for an administrator named only by header, got %v", err)
+	}
+	if _, err := export(bearer("bob", connect.NewRequest(&storagev1.ExportStateRequest{}))); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied for a caller who is not an administrator, got %v", err)
+	}
+	archive, err := export(bearer("alice", connect.NewRequest(&storagev1.ExportStateRequest{})))
+	if err != nil {
+		t.Fatalf("ExportState failed: %v", err)
+	}
+
+	importState := func(token, mode string, data []byte) (*storagev1.ImportStateResponse, error) {
+		stream := dstClient.ImportState(ctx)
+		stream.RequestHeader().Set("Authorization", "Bearer "+token)
+		stream.Send(&storagev1.ImportStateRequest{Mode: mode})
+		for len(data) > 0 {
+			n := min(len(data), 1000)
+			stream.Send(&storagev1.ImportStateRequest{Data: data[:n]})
+			data = data[n:]
+		}
+		resp, err := stream.CloseAndReceive()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+	if _, err := importState("bob", ImportMerge, archive); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied importing over alice's bucket, got %v", err)
+	}
+	if _, err := importState("alice", ImportMerge, []byte("not a tar file")); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected InvalidArgument for a bad archive, got %v", err)
+	}
+	resp, err := importState("alice", "", archive)
+	if err != nil || resp.Buckets != 2 || resp.Objects != 3 {
+		t.Fatalf("ImportState: %v (%v)", resp, err)
+	}
+	list, err := dst.ListObjects(ctx, as(alice, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "alpha"})))
+	if err != nil || !slices.Equal(list.Msg.ObjectNames, []string{"a", "dir/b"}) {
+		t.Errorf("unexpected listing after ImportState: %v (%v)", list, err)
+	}
+}
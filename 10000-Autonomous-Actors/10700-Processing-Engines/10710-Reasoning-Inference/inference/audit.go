@@ -0,0 +1,400 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// bucketAudit is the append-only audit log, keyed by big-endian sequence
+// numbers so entries iterate in the order they were made. Nothing updates
+// or deletes its records.
+const bucketAudit = "audit"
+
+// RequestIDHeader carries the caller's request ID. Audited calls without
+// one are assigned one, which is returned in the same header.
+const RequestIDHeader = "X-Request-Id"
+
+// auditedProcedures are the StorageService procedures that change state.
+var auditedProcedures = map[string]bool{
+	storagev1connect.StorageServiceCreateBucketProcedure:             true,
+	storagev1connect.StorageServiceUploadObjectProcedure:             true,
+	storagev1connect.StorageServiceUpdateBucketProcedure:             true,
+	storagev1connect.StorageServiceDeleteObjectProcedure:             true,
+	storagev1connect.StorageServiceRewriteObjectProcedure:            true,
+	storagev1connect.StorageServiceUpdateObjectProcedure:             true,
+	storagev1connect.StorageServiceCreateNotificationConfigProcedure: true,
+	storagev1connect.StorageServiceDeleteNotificationConfigProcedure: true,
+	storagev1connect.StorageServiceSetIamPolicyProcedure:             true,
+	storagev1connect.StorageServiceInsertAclProcedure:                true,
+	storagev1connect.StorageServicePatchAclProcedure:                 true,
+	storagev1connect.StorageServiceDeleteAclProcedure:                true,
+	storagev1connect.StorageServiceRotateKmsKeyProcedure:             true,
+	storagev1connect.StorageServiceComposeObjectProcedure:            true,
+	storagev1connect.StorageServiceWriteObjectProcedure:              true,
+	storagev1connect.StorageServiceImportStateProcedure:              true,
+	storagev1connect.StorageServiceSeedStorageProcedure:              true,
+	storagev1connect.StorageServiceSetFaultRulesProcedure:            true,
+}
+
+// AuditEntry records one call of a mutating RPC. It is stored, and exported
+// as a JSON line, in this form.
+type AuditEntry struct {
+	Sequence   uint64    `json:"sequence"`
+	Time       time.Time `json:"time"`
+	Principal  string    `json:"principal,omitempty"`
+	Procedure  string    `json:"procedure"`
+	Bucket     string    `json:"bucket,omitempty"`
+	Object     string    `json:"object,omitempty"`
+	Generation int64     `json:"generation,omitempty"`
+	RequestID  string    `json:"requestId"`
+	Code       string    `json:"code"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func (e *AuditEntry) toProto() *storagev1.AuditEntry {
+	return &storagev1.AuditEntry{
+		Sequence:     int64(e.Sequence),
+		Time:         timestamppb.New(e.Time),
+		Principal:    e.Principal,
+		Procedure:    e.Procedure,
+		Bucket:       e.Bucket,
+		Object:       e.Object,
+		Generation:   e.Generation,
+		RequestId:    e.RequestID,
+		Code:         e.Code,
+		ErrorMessage: e.Error,
+	}
+}
+
+// AuditEntryFromProto converts an entry returned by QueryAuditLog, so remote
+// and local exports share one form.
+func AuditEntryFromProto(p *storagev1.AuditEntry) AuditEntry {
+	return AuditEntry{
+		Sequence:   uint64(p.Sequence),
+		Time:       p.Time.AsTime(),
+		Principal:  p.Principal,
+		Procedure:  p.Procedure,
+		Bucket:     p.Bucket,
+		Object:     p.Object,
+		Generation: p.Generation,
+		RequestID:  p.RequestId,
+		Code:       p.Code,
+		Error:      p.ErrorMessage,
+	}
+}
+
+// resource fills in what msg, a request or response of an audited
+// procedure, says about the bucket, object and generation affected.
+func (e *AuditEntry) resource(msg any) {
+	switch m := msg.(type) {
+	case *storagev1.CreateBucketRequest:
+		e.Bucket = m.Name
+	case *storagev1.UpdateBucketRequest:
+		e.Bucket = m.GetBucket().GetName()
+	case *storagev1.UploadObjectRequest:
+		e.Bucket, e.Object = m.Bucket, m.Name
+	case *storagev1.UploadObjectResponse:
+		e.Generation = m.Generation
+	case *storagev1.WriteObjectRequest:
+		// Only the first message carries the spec.
+		if m.Spec != nil {
+			e.Bucket, e.Object = m.Spec.Bucket, m.Spec.Name
+		}
+	case *storagev1.WriteObjectResponse:
+		e.Generation = m.Generation
+	case *storagev1.DeleteObjectRequest:
+		e.Bucket, e.Object = m.Bucket, m.Name
+	case *storagev1.RewriteObjectRequest:
+		e.Bucket, e.Object = m.DestinationBucket, m.DestinationObject
+	case *storagev1.RewriteObjectResponse:
+		e.Generation = m.GetResource().GetGeneration()
+	case *storagev1.UpdateObjectRequest:
+		e.Bucket, e.Object = m.Bucket, m.Name
+	case *storagev1.UpdateObjectResponse:
+		e.Generation = m.GetResource().GetGeneration()
+	case *storagev1.ComposeObjectRequest:
+		e.Bucket, e.Object = m.Bucket, m.DestinationObject
+	case *storagev1.ComposeObjectResponse:
+		e.Generation = m.GetResource().GetGeneration()
+	case *storagev1.CreateNotificationConfigRequest:
+		e.Bucket = m.Bucket
+	case *storagev1.DeleteNotificationConfigRequest:
+		e.Bucket = m.Bucket
+	case *storagev1.SetIamPolicyRequest:
+		e.Bucket = m.Bucket
+	case *storagev1.InsertAclRequest:
+		e.Bucket, e.Object = m.Bucket, m.Object
+	case *storagev1.PatchAclRequest:
+		e.Bucket, e.Object = m.Bucket, m.Object
+	case *storagev1.DeleteAclRequest:
+		e.Bucket, e.Object = m.Bucket, m.Object
+	}
+}
+
+// finish records the outcome of the call.
+func (e *AuditEntry) finish(err error) {
+	e.Time = time.Now().UTC()
+	e.Code = "ok"
+	if err != nil {
+		e.Code = connect.CodeOf(err).String()
+		e.Error = err.Error()
+		var cerr *connect.Error
+		if errors.As(err, &cerr) {
+			e.Error = cerr.Message()
+		}
+	}
+}
+
+// AuditFilter selects audit entries; zero fields match everything.
+type AuditFilter struct {
+	// Start and End bound the entry time, Start inclusive.
+	Start, End time.Time
+	Principal  string
+	Bucket     string
+	Object     string
+}
+
+func (f *AuditFilter) matches(e *AuditEntry) bool {
+	switch {
+	case !f.Start.IsZero() && e.Time.Before(f.Start):
+	case !f.End.IsZero() && !e.Time.Before(f.End):
+	case f.Principal != "" && e.Principal != f.Principal:
+	case f.Bucket != "" && e.Bucket != f.Bucket:
+	case f.Object != "" && e.Object != f.Object:
+	default:
+		return true
+	}
+	return false
+}
+
+func auditKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+// appendAudit numbers e and adds it to the log. The call it records has
+// already taken effect, so a failure is logged rather than returned.
+func (s *StorageServer) appendAudit(ctx context.Context, e *AuditEntry) {
+	err := s.update(ctx, func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucketAudit))
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		e.Sequence = seq
+		return s.putRecord(b, bucketAudit, string(auditKey(seq)), e)
+	})
+	if err != nil {
+		slog.Error("Failed to write audit entry", "procedure", e.Procedure, "requestId", e.RequestID, "error", err)
+	}
+}
+
+// scanAudit calls fn with the entries after sequence number after that
+// match f, oldest first, until fn returns false.
+func (s *StorageServer) scanAudit(tx *bbolt.Tx, after uint64, f AuditFilter, fn func(*AuditEntry) (bool, error)) error {
+	b := tx.Bucket([]byte(bucketAudit))
+	c := b.Cursor()
+	for k, v := c.Seek(auditKey(after + 1)); k != nil; k, v = c.Next() {
+		var e AuditEntry
+		if err := s.decodeRecord(bucketAudit, string(k), v, &e); err != nil {
+			return fmt.Errorf("audit entry %d: %v", binary.BigEndian.Uint64(k), err)
+		}
+		if !f.matches(&e) {
+			continue
+		}
+		if more, err := fn(&e); err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportAuditLog writes the entries matching f to w as JSON lines, oldest
+// first, and returns how many it wrote. It bypasses IAM, for storage-admin
+// working on the data directory.
+func (s *StorageServer) ExportAuditLog(ctx context.Context, w io.Writer, f AuditFilter) (int, error) {
+	enc := json.NewEncoder(w)
+	n := 0
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		return s.scanAudit(tx, 0, f, func(e *AuditEntry) (bool, error) {
+			n++
+			return true, enc.Encode(e)
+		})
+	})
+	return n, err
+}
+
+func (s *StorageServer) QueryAuditLog(ctx context.Context, req *connect.Request[storagev1.QueryAuditLogRequest]) (*connect.Response[storagev1.QueryAuditLogResponse], error) {
+	member := principalOf(ctx, req.Header())
+	slog.Info("QueryAuditLog", "principal", member, "bucket", req.Msg.Bucket, "object", req.Msg.Object, "filterPrincipal", req.Msg.Principal)
+	if req.Msg.Object != "" && req.Msg.Bucket == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("an object filter needs a bucket"))
+	}
+	pageSize := int(req.Msg.PageSize)
+	if pageSize < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page size: %d", pageSize))
+	}
+	if pageSize == 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	var after uint64
+	if req.Msg.PageToken != "" {
+		var err error
+		if after, err = strconv.ParseUint(req.Msg.PageToken, 10, 64); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page token: %q", req.Msg.PageToken))
+		}
+	}
+	filter := AuditFilter{Principal: req.Msg.Principal, Bucket: req.Msg.Bucket, Object: req.Msg.Object}
+	if req.Msg.StartTime != nil {
+		filter.Start = req.Msg.StartTime.AsTime()
+	}
+	if req.Msg.EndTime != nil {
+		filter.End = req.Msg.EndTime.AsTime()
+	}
+
+	// Callers see their own entries and those of buckets they administer,
+	// as with ExportState. Entries of deleted buckets are only their
+	// callers'.
+	admin := map[string]bool{}
+	resp := &storagev1.QueryAuditLogResponse{}
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		return s.scanAudit(tx, after, filter, func(e *AuditEntry) (bool, error) {
+			if e.Principal != member {
+				if e.Bucket == "" {
+					return true, nil
+				}
+				allowed, ok := admin[e.Bucket]
+				if !ok {
+					var err error
+					if allowed, err = s.canAccessTx(tx, member, e.Bucket, "", permBucketsGetIamPolicy); err != nil {
+						return false, err
+					}
+					admin[e.Bucket] = allowed
+				}
+				if !allowed {
+					return true, nil
+				}
+			}
+			if len(resp.Entries) == pageSize {
+				resp.NextPageToken = strconv.FormatInt(resp.Entries[pageSize-1].Sequence, 10)
+				return false, nil
+			}
+			resp.Entries = append(resp.Entries, e.toProto())
+			return true, nil
+		})
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read audit log: %v", err))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// AuditInterceptor records every call of a mutating StorageService
+// procedure, with its outcome, in the audit log. It belongs after the
+// authentication interceptor, whose principal it records; calls that fail
+// authentication change nothing and are not recorded.
+type AuditInterceptor struct {
+	server *StorageServer
+}
+
+func NewAuditInterceptor(server *StorageServer) *AuditInterceptor {
+	return &AuditInterceptor{server: server}
+}
+
+// requestID returns the caller's request ID or a new random one.
+func requestID(header http.Header) string {
+	if id := header.Get(RequestIDHeader); id != "" {
+		return id
+	}
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (a *AuditInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient || !auditedProcedures[req.Spec().Procedure] {
+			return next(ctx, req)
+		}
+		entry := &AuditEntry{
+			Principal: principalOf(ctx, req.Header()),
+			Procedure: req.Spec().Procedure,
+			RequestID: requestID(req.Header()),
+		}
+		entry.resource(req.Any())
+		resp, err := next(ctx, req)
+		var cerr *connect.Error
+		switch {
+		case err == nil:
+			entry.resource(resp.Any())
+			resp.Header().Set(RequestIDHeader, entry.RequestID)
+		case errors.As(err, &cerr):
+			cerr.Meta().Set(RequestIDHeader, entry.RequestID)
+		}
+		entry.finish(err)
+		a.server.appendAudit(ctx, entry)
+		return resp, err
+	}
+}
+
+func (a *AuditInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler records audited streams once they end, with the
+// resource their messages named.
+func (a *AuditInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if !auditedProcedures[conn.Spec().Procedure] {
+			return next(ctx, conn)
+		}
+		entry := &AuditEntry{
+			Principal: principalOf(ctx, conn.RequestHeader()),
+			Procedure: conn.Spec().Procedure,
+			RequestID: requestID(conn.RequestHeader()),
+		}
+		conn.ResponseHeader().Set(RequestIDHeader, entry.RequestID)
+		err := next(ctx, &auditedConn{StreamingHandlerConn: conn, entry: entry})
+		entry.finish(err)
+		a.server.appendAudit(ctx, entry)
+		return err
+	}
+}
+
+type auditedConn struct {
+	connect.StreamingHandlerConn
+	entry *AuditEntry
+}
+
+func (c *auditedConn) Receive(msg any) error {
+	err := c.StreamingHandlerConn.Receive(msg)
+	if err == nil {
+		c.entry.resource(msg)
+	}
+	return err
+}
+
+func (c *auditedConn) Send(msg any) error {
+	err := c.StreamingHandlerConn.Send(msg)
+	if err == nil {
+		c.entry.resource(msg)
+	}
+	return err
+}
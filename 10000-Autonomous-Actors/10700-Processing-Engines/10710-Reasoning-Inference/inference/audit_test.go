@@ -0,0 +1,140 @@
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAuditLog(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(NewAuditInterceptor(server))))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	ctx := context.Background()
+	const bob = "user:bob@example.com"
+	start := time.Now()
+
+	create := as(alice, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "audited"}))
+	create.Header().Set(RequestIDHeader, "req-1")
+	created, err := client.CreateBucket(ctx, create)
+	if err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	if id := created.Header().Get(RequestIDHeader); id != "req-1" {
+		t.Errorf("expected the request ID echoed, got %q", id)
+	}
+	uploaded, err := client.UploadObject(ctx, as(alice, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "audited", Name: "a", Data: []byte("hello")})))
+	if err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	if uploaded.Header().Get(RequestIDHeader) == "" {
+		t.Error("expected a request ID to be assigned")
+	}
+	_, err = client.UploadObject(ctx, as(bob, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "audited", Name: "b", Data: []byte("x")})))
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) || cerr.Meta().Get(RequestIDHeader) == "" {
+		t.Errorf("expected a refused upload carrying a request ID, got %v", err)
+	}
+	write := client.WriteObject(ctx)
+	write.RequestHeader().Set(PrincipalHeader, alice)
+	write.Send(&storagev1.WriteObjectRequest{Spec: &storagev1.UploadObjectRequest{Bucket: "audited", Name: "streamed"}, Data: []byte("abc")})
+	written, err := write.CloseAndReceive()
+	if err != nil {
+		t.Fatalf("WriteObject failed: %v", err)
+	}
+	client.GetObjectMetadata(ctx, as(alice, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "audited", Name: "a"})))
+	client.CreateBucket(ctx, as(bob, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "bobs"})))
+
+	query := func(member string, msg *storagev1.QueryAuditLogRequest) []*storagev1.AuditEntry {
+		t.Helper()
+		resp, err := client.QueryAuditLog(ctx, as(member, connect.NewRequest(msg)))
+		if err != nil {
+			t.Fatalf("QueryAuditLog failed: %v", err)
+		}
+		return resp.Msg.Entries
+	}
+	// Alice administers "audited", so she sees bob's refused upload there
+	// but not the bucket he made.
+	entries := query(alice, &storagev1.QueryAuditLogRequest{})
+	want := []struct {
+		principal, procedure, object, code string
+		generation                         int64
+	}{
+		{alice, storagev1connect.StorageServiceCreateBucketProcedure, "", "ok", 0},
+		{alice, storagev1connect.StorageServiceUploadObjectProcedure, "a", "ok", uploaded.Msg.Generation},
+		{bob, storagev1connect.StorageServiceUploadObjectProcedure, "b", "permission_denied", 0},
+		{alice, storagev1connect.StorageServiceWriteObjectProcedure, "streamed", "ok", written.Msg.Generation},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries for alice, got %v", len(want), entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Principal != w.principal || e.Procedure != w.procedure || e.Bucket != "audited" || e.Object != w.object || e.Code != w.code || e.Generation != w.generation {
+			t.Errorf("entry %d = %v, want %+v", i, e, w)
+		}
+		if e.RequestId == "" || e.Sequence != int64(i+1) || e.Time.AsTime().Before(start) {
+			t.Errorf("entry %d lacks its request ID, sequence or time: %v", i, e)
+		}
+	}
+	if entries[0].RequestId != "req-1" || entries[2].ErrorMessage == "" {
+		t.Errorf("unexpected request ID or error: %v", entries)
+	}
+
+	if got := query(bob, &storagev1.QueryAuditLogRequest{}); len(got) != 2 || got[0].Object != "b" || got[1].Bucket != "bobs" {
+		t.Errorf("expected only bob's own entries, got %v", got)
+	}
+	if got := query(alice, &storagev1.QueryAuditLogRequest{Principal: bob}); len(got) != 1 || got[0].Code != "permission_denied" {
+		t.Errorf("unexpected principal filter result %v", got)
+	}
+	if got := query(alice, &storagev1.QueryAuditLogRequest{Bucket: "audited", Object: "streamed"}); len(got) != 1 {
+		t.Errorf("unexpected resource filter result %v", got)
+	}
+	future := timestamppb.New(time.Now().Add(time.Hour))
+	if got := query(alice, &storagev1.QueryAuditLogRequest{StartTime: future}); len(got) != 0 {
+		t.Errorf("expected no entries from the future, got %v", got)
+	}
+	if got := query(alice, &storagev1.QueryAuditLogRequest{EndTime: future}); len(got) != 4 {
+		t.Errorf("expected all entries before the end time, got %v", got)
+	}
+
+	page, err := client.QueryAuditLog(ctx, as(alice, connect.NewRequest(&storagev1.QueryAuditLogRequest{PageSize: 3})))
+	if err != nil || len(page.Msg.Entries) != 3 || page.Msg.NextPageToken == "" {
+		t.Fatalf("unexpected first page %v (%v)", page, err)
+	}
+	if got := query(alice, &storagev1.QueryAuditLogRequest{PageSize: 3, PageToken: page.Msg.NextPageToken}); len(got) != 1 || got[0].Object != "streamed" {
+		t.Errorf("unexpected second page %v", got)
+	}
+	for _, bad := range []*storagev1.QueryAuditLogRequest{{Object: "a"}, {PageToken: "x"}, {PageSize: -1}} {
+		if _, err := client.QueryAuditLog(ctx, as(alice, connect.NewRequest(bad))); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%v: expected InvalidArgument, got %v", bad, err)
+		}
+	}
+
+	var out bytes.Buffer
+	n, err := server.ExportAuditLog(ctx, &out, AuditFilter{})
+	if err != nil || n != 5 || strings.Count(out.String(), "\n") != 5 {
+		t.Fatalf("unexpected export of %d entries (%v):\n%s", n, err, out.String())
+	}
+	var last AuditEntry
+	json.Unmarshal(bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))[4], &last)
+	if last.Sequence != 5 || last.Principal != bob || last.Bucket != "bobs" || last.Code != "ok" {
+		t.Errorf("unexpected exported entry %+v", last)
+	}
+}
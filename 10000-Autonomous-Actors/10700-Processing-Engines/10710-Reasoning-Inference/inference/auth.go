@@ -0,0 +1,283 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// jwtClockSkew tolerates small clock differences between signer and server.
+const jwtClockSkew = time.Minute
+
+// AuthConfig selects how StorageManager authenticates callers.
+type AuthConfig struct {
+	// TokensFile is a JSON object mapping static bearer tokens to IAM
+	// members, e.g. {"ci-token": "serviceAccount:ci@olympus.iam"}.
+	TokensFile string
+	// KeysDir holds service account key files (GCP JSON key format). JWTs
+	// signed with one of these keys authenticate as that service account.
+	KeysDir string
+	// Audience, when set, must match the "aud" claim of presented JWTs.
+	Audience string
+	// Dev admits requests without credentials, identifying the caller by
+	// PrincipalHeader as the handlers do when no interceptor is installed.
+	Dev bool
+}
+
+// serviceAccountKey is the subset of a GCP service account key file used to
+// verify self-signed JWTs.
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+}
+
+type verificationKey struct {
+	email string
+	key   *rsa.PublicKey
+}
+
+// AuthInterceptor authenticates every StorageService call and places the
+// resulting principal in the request context for authorization checks.
+type AuthInterceptor struct {
+	cfg    AuthConfig
+	tokens map[string]string
+	keys   map[string]verificationKey
+	now    func() time.Time
+}
+
+// NewAuthInterceptor loads the credentials named by cfg.
+func NewAuthInterceptor(cfg AuthConfig) (*AuthInterceptor, error) {
+	a := &AuthInterceptor{cfg: cfg, tokens: map[string]string{}, keys: map[string]verificationKey{}, now: time.Now}
+	if cfg.TokensFile != "" {
+		data, err := os.ReadFile(cfg.TokensFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tokens file: %v", err)
+		}
+		if err := json.Unmarshal(data, &a.tokens); err != nil {
+			return nil, fmt.Errorf("failed to parse tokens file: %v", err)
+		}
+		for token, member := range a.tokens {
+			if !validMember(member) {
+				return nil, fmt.Errorf("token %q maps to invalid member %q", token[:min(4, len(token))]+"…", member)
+			}
+		}
+	}
+	if cfg.KeysDir != "" {
+		paths, err := filepath.Glob(filepath.Join(cfg.KeysDir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			k, err := loadServiceAccountKey(p)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load key %s: %v", p, err)
+			}
+			a.keys[k.PrivateKeyID] = verificationKey{email: k.ClientEmail, key: k.public()}
+		}
+	}
+	slog.Info("Authentication configured", "tokens", len(a.tokens), "keys", len(a.keys), "dev", cfg.Dev)
+	return a, nil
+}
+
+func loadServiceAccountKey(path string) (*serviceAccountKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var k serviceAccountKey
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, err
+	}
+	if k.Type != "service_account" || k.ClientEmail == "" || k.PrivateKeyID == "" {
+		return nil, errors.New("not a service account key")
+	}
+	if k.public() == nil {
+		return nil, errors.New("private_key is not an RSA key")
+	}
+	return &k, nil
+}
+
+func (k *serviceAccountKey) private() *rsa.PrivateKey {
+	block, _ := pem.Decode([]byte(k.PrivateKey))
+	if block == nil {
+		return nil
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		rsaKey, _ := key.(*rsa.PrivateKey)
+		return rsaKey
+	}
+	key, _ := x509.ParsePKCS1PrivateKey(block.Bytes)
+	return key
+}
+
+func (k *serviceAccountKey) public() *rsa.PublicKey {
+	if key := k.private(); key != nil {
+		return &key.PublicKey
+	}
+	return nil
+}
+
+// authenticate resolves the principal for a request. A bearer token takes
+// precedence over a client certificate. It reports false when the request
+// carries no credentials and dev mode admits it as is.
+func (a *AuthInterceptor) authenticate(ctx context.Context, header http.Header) (string, bool, error) {
+	auth := header.Get("Authorization")
+	if auth == "" {
+		if member, ok := ctx.Value(certPrincipalKey{}).(string); ok {
+			return member, true, nil
+		}
+		if a.cfg.Dev {
+			return "", false, nil
+		}
+		return "", false, errors.New("missing bearer token or client certificate")
+	}
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok {
+		return "", false, errors.New("authorization must be a bearer token")
+	}
+	if member, ok := a.tokens[token]; ok {
+		return member, true, nil
+	}
+	if strings.Count(token, ".") == 2 && len(a.keys) > 0 {
+		email, err := a.verifyJWT(token)
+		if err != nil {
+			return "", false, err
+		}
+		return "serviceAccount:" + email, true, nil
+	}
+	return "", false, errors.New("unrecognized bearer token")
+}
+
+// verifyJWT checks an RS256 JWT self-signed by a service account key, as
+// produced by the Google auth libraries' JWT access credentials.
+func (a *AuthInterceptor) verifyJWT(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	var claims struct {
+		Iss string          `json:"iss"`
+		Sub string          `json:"sub"`
+		Aud json.RawMessage `json:"aud"`
+		Iat int64           `json:"iat"`
+		Exp int64           `json:"exp"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return "", fmt.Errorf("malformed JWT header: %v", err)
+	}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return "", fmt.Errorf("malformed JWT claims: %v", err)
+	}
+	if header.Alg != "RS256" {
+		return "", fmt.Errorf("unsupported JWT algorithm: %q", header.Alg)
+	}
+	key, ok := a.keys[header.Kid]
+	if !ok {
+		return "", fmt.Errorf("unknown JWT key id: %q", header.Kid)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed JWT signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key.key, crypto.SHA256, digest[:], sig); err != nil {
+		return "", errors.New("invalid JWT signature")
+	}
+
+	now := a.now()
+	if claims.Iss != key.email || (claims.Sub != "" && claims.Sub != key.email) {
+		return "", fmt.Errorf("JWT issuer %q does not match key owner", claims.Iss)
+	}
+	if claims.Exp == 0 || now.After(time.Unix(claims.Exp, 0).Add(jwtClockSkew)) {
+		return "", errors.New("JWT expired")
+	}
+	if now.Add(jwtClockSkew).Before(time.Unix(claims.Iat, 0)) {
+		return "", errors.New("JWT issued in the future")
+	}
+	if a.cfg.Audience != "" && !audienceMatches(claims.Aud, a.cfg.Audience) {
+		return "", errors.New("JWT audience mismatch")
+	}
+	return key.email, nil
+}
+
+func decodeJWTPart(part string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// audienceMatches accepts both the string and array forms of "aud".
+func audienceMatches(raw json.RawMessage, want string) bool {
+	var one string
+	if json.Unmarshal(raw, &one) == nil {
+		return one == want
+	}
+	var many []string
+	if json.Unmarshal(raw, &many) == nil {
+		return containsString(many, want)
+	}
+	return false
+}
+
+func (a *AuthInterceptor) authContext(ctx context.Context, procedure string, header http.Header) (context.Context, error) {
+	member, ok, err := a.authenticate(ctx, header)
+	if err != nil {
+		slog.Warn("Authentication failed", "procedure", procedure, "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if !ok {
+		slog.Info("Unauthenticated request admitted", "procedure", procedure, "principal", principalOf(ctx, header))
+		return ctx, nil
+	}
+	slog.Info("Authenticated request", "procedure", procedure, "principal", member)
+	return WithPrincipal(ctx, member), nil
+}
+
+func (a *AuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		ctx, err := a.authContext(ctx, req.Spec().Procedure, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (a *AuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (a *AuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := a.authContext(ctx, conn.Spec().Procedure, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
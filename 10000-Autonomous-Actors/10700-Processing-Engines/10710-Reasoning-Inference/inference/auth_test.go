@@ -0,0 +1,131 @@
+package inference
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+// signJWT builds a service-account style self-signed JWT.
+func signJWT(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	enc := func(v any) string {
+		b, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	unsigned := enc(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid}) + "." + enc(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	dir := t.TempDir()
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	email := "uploader@demo.iam.gserviceaccount.com"
+	keyFile, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   email,
+		"private_key_id": "k1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	})
+	os.MkdirAll(filepath.Join(dir, "keys"), 0755)
+	os.WriteFile(filepath.Join(dir, "keys", "uploader.json"), keyFile, 0600)
+	os.WriteFile(filepath.Join(dir, "tokens.json"), []byte(`{"ci-secret":"user:ci@example.com"}`), 0600)
+
+	auth, err := NewAuthInterceptor(AuthConfig{
+		TokensFile: filepath.Join(dir, "tokens.json"),
+		KeysDir:    filepath.Join(dir, "keys"),
+		Audience:   "https://storage.olympus",
+	})
+	if err != nil {
+		t.Fatalf("NewAuthInterceptor failed: %v", err)
+	}
+
+	server := NewStorageServer(filepath.Join(dir, "data"))
+	defer server.Close()
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(auth)))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := storagev1connect.NewStorageServiceClient(ts.Client(), ts.URL)
+	ctx := context.Background()
+
+	bearer := func(token string, req *connect.Request[storagev1.CreateBucketRequest]) *connect.Request[storagev1.CreateBucketRequest] {
+		req.Header().Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	now := time.Now()
+	valid := signJWT(t, key, "k1", map[string]any{"iss": email, "sub": email, "aud": "https://storage.olympus", "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()})
+	if _, err := client.CreateBucket(ctx, bearer(valid, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "jwt"}))); err != nil {
+		t.Fatalf("CreateBucket with JWT failed: %v", err)
+	}
+	policy, _ := server.GetIamPolicy(WithPrincipal(ctx, "serviceAccount:"+email), connect.NewRequest(&storagev1.GetIamPolicyRequest{Bucket: "jwt"}))
+	if members := policy.Msg.Policy.Bindings[0].Members; members[0] != "serviceAccount:"+email {
+		t.Errorf("expected bucket to be owned by the service account, got %v", members)
+	}
+
+	if _, err := client.CreateBucket(ctx, bearer("ci-secret", connect.NewRequest(&storagev1.CreateBucketRequest{Name: "static"}))); err != nil {
+		t.Errorf("CreateBucket with static token failed: %v", err)
+	}
+
+	rejected := map[string]string{
+		"expired":        signJWT(t, key, "k1", map[string]any{"iss": email, "aud": "https://storage.olympus", "iat": now.Add(-2 * time.Hour).Unix(), "exp": now.Add(-time.Hour).Unix()}),
+		"wrong audience": signJWT(t, key, "k1", map[string]any{"iss": email, "aud": "https://elsewhere", "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()}),
+		"unknown key":    signJWT(t, key, "k2", map[string]any{"iss": email, "aud": "https://storage.olympus", "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()}),
+		"unknown token":  "guess",
+	}
+	for name, token := range rejected {
+		if _, err := client.CreateBucket(ctx, bearer(token, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "nope"}))); connect.CodeOf(err) != connect.CodeUnauthenticated {
+			t.Errorf("%s: expected Unauthenticated, got %v", name, err)
+		}
+	}
+
+	// Without dev mode the principal header is not trusted.
+	if _, err := client.CreateBucket(ctx, as("user:alice@example.com", connect.NewRequest(&storagev1.CreateBucketRequest{Name: "nope"}))); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated without credentials, got %v", err)
+	}
+}
+
+func TestAuthInterceptorDevMode(t *testing.T) {
+	auth, err := NewAuthInterceptor(AuthConfig{Dev: true})
+	if err != nil {
+		t.Fatalf("NewAuthInterceptor failed: %v", err)
+	}
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(auth)))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := storagev1connect.NewStorageServiceClient(ts.Client(), ts.URL)
+	ctx := context.Background()
+
+	if _, err := client.CreateBucket(ctx, as("alice@example.com", connect.NewRequest(&storagev1.CreateBucketRequest{Name: "dev"}))); err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	if _, err := client.UploadObject(ctx, as("bob@example.com", connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "dev", Name: "o", Data: []byte("x")}))); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected dev mode to honour the principal header, got %v", err)
+	}
+}
@@ -0,0 +1,321 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Backend stores object data. Metadata stays in BoltDB; a backend holds only
+// bytes, addressed by bucket and object name, both validated before they
+// reach it. Absent objects are reported with errors matching fs.ErrNotExist.
+type Backend interface {
+	// Put stores data under bucket/name, replacing any previous data
+	// atomically: readers see either the old bytes or the new.
+	Put(ctx context.Context, bucket, name string, data []byte) error
+	// Get reads length bytes from offset; a negative length reads to the
+	// end.
+	Get(ctx context.Context, bucket, name string, offset, length int64) (io.ReadCloser, error)
+	Stat(ctx context.Context, bucket, name string) (ObjectInfo, error)
+	// Delete removes bucket/name. Deleting an absent object is not an error.
+	Delete(ctx context.Context, bucket, name string) error
+	// List returns the sorted names in bucket that start with prefix.
+	List(ctx context.Context, bucket, prefix string) ([]string, error)
+}
+
+// ObjectInfo describes stored object data.
+type ObjectInfo struct {
+	Size     int64
+	Modified time.Time
+}
+
+// urlBackend is implemented by backends whose data callers can fetch
+// directly, bypassing ReadObject.
+type urlBackend interface {
+	URL(bucket, name string) string
+}
+
+// legacyBucketBackend is implemented by backends that can find buckets
+// created before bucket records were kept in BoltDB.
+type legacyBucketBackend interface {
+	Buckets(ctx context.Context) (map[string]time.Time, error)
+	// RemoveBucket removes an empty bucket, so that a bucket whose record
+	// is gone is not adopted again. It fails if any file remains.
+	RemoveBucket(ctx context.Context, bucket string) error
+}
+
+// tempFileBackend is implemented by backends that stage writes in temporary
+// files, which a crash can leave behind.
+type tempFileBackend interface {
+	// TempFiles lists the leftover files by path relative to the backend
+	// root, slash-separated.
+	TempFiles(ctx context.Context) ([]string, error)
+	RemoveTempFile(ctx context.Context, path string) error
+}
+
+// stagingBackend is implemented by backends that can write data ahead of
+// placing it, so that a BoltDB transaction recording the data waits only
+// for the placing.
+type stagingBackend interface {
+	// Stage writes data for bucket/name without placing it there.
+	Stage(ctx context.Context, bucket, name string, data []byte) (stagedPut, error)
+}
+
+// stagedPut is data written by Stage. Place replaces the data at its
+// destination atomically, as Put does; Discard removes it unless placed.
+type stagedPut interface {
+	Place() error
+	Discard()
+}
+
+// WithBackend stores object data in b instead of the filesystem under the
+// storage directory.
+func WithBackend(b Backend) Option {
+	return func(s *StorageServer) {
+		s.backend = b
+	}
+}
+
+// readStoredData returns the stored bytes of obj in full.
+func (s *StorageServer) readStoredData(ctx context.Context, obj *objectRecord) ([]byte, error) {
+	r, err := s.openStoredData(ctx, obj, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// stagedPrefix names the temporary files fsBackend renames into place.
+const stagedPrefix = ".staged-"
+
+// fsBackend stores each object as the file root/bucket/name.
+type fsBackend struct {
+	root string
+}
+
+// NewFSBackend returns the filesystem backend StorageManager has always
+// used: one directory per bucket under root, one file per object.
+func NewFSBackend(root string) Backend {
+	return &fsBackend{root: root}
+}
+
+func (b *fsBackend) path(bucket, name string) string {
+	return filepath.Join(b.root, bucket, name)
+}
+
+func fileAttrs(bucket, name string, extra ...attribute.KeyValue) []attribute.KeyValue {
+	return append([]attribute.KeyValue{attribute.String("storage.bucket", bucket), attribute.String("storage.object", name)}, extra...)
+}
+
+// Put writes through a temporary file in the destination directory so the
+// final rename is atomic.
+func (b *fsBackend) Put(ctx context.Context, bucket, name string, data []byte) (err error) {
+	_, span := startSpan(ctx, "fs.Put", fileAttrs(bucket, name, attribute.Int("storage.bytes", len(data)))...)
+	defer func() { endSpan(span, err) }()
+	staged, err := b.stage(bucket, name, data)
+	if err != nil {
+		return err
+	}
+	defer staged.Discard()
+	return staged.Place()
+}
+
+// Stage writes the temporary file Put renames into place.
+func (b *fsBackend) Stage(ctx context.Context, bucket, name string, data []byte) (_ stagedPut, err error) {
+	_, span := startSpan(ctx, "fs.Stage", fileAttrs(bucket, name, attribute.Int("storage.bytes", len(data)))...)
+	defer func() { endSpan(span, err) }()
+	return b.stage(bucket, name, data)
+}
+
+func (b *fsBackend) stage(bucket, name string, data []byte) (*fsStaged, error) {
+	path := b.path(bucket, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create object path: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), stagedPrefix+"*")
+	if err != nil {
+		return nil, err
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return &fsStaged{tmp: tmp.Name(), path: path}, nil
+}
+
+// fsStaged is a temporary file awaiting its rename to path.
+type fsStaged struct {
+	tmp, path string
+	placed    bool
+}
+
+func (f *fsStaged) Place() error {
+	err := os.Rename(f.tmp, f.path)
+	f.placed = err == nil
+	return err
+}
+
+func (f *fsStaged) Discard() {
+	if !f.placed {
+		os.Remove(f.tmp)
+	}
+}
+
+// Get's span covers opening the file; reading is left to the caller.
+func (b *fsBackend) Get(ctx context.Context, bucket, name string, offset, length int64) (_ io.ReadCloser, err error) {
+	_, span := startSpan(ctx, "fs.Get", fileAttrs(bucket, name)...)
+	defer func() { endSpan(span, err) }()
+	f, err := os.Open(b.path(bucket, name))
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err == nil && info.IsDir() {
+		err = fmt.Errorf("%s/%s: %w", bucket, name, fs.ErrNotExist)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if length < 0 {
+		length = max(0, info.Size()-offset)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.NewSectionReader(f, offset, length), f}, nil
+}
+
+func (b *fsBackend) Stat(ctx context.Context, bucket, name string) (_ ObjectInfo, err error) {
+	_, span := startSpan(ctx, "fs.Stat", fileAttrs(bucket, name)...)
+	defer func() { endSpan(span, err) }()
+	info, err := os.Stat(b.path(bucket, name))
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if info.IsDir() {
+		return ObjectInfo{}, fmt.Errorf("%s/%s: %w", bucket, name, fs.ErrNotExist)
+	}
+	return ObjectInfo{Size: info.Size(), Modified: info.ModTime().UTC()}, nil
+}
+
+func (b *fsBackend) Delete(ctx context.Context, bucket, name string) (err error) {
+	_, span := startSpan(ctx, "fs.Delete", fileAttrs(bucket, name)...)
+	defer func() { endSpan(span, err) }()
+	if err := os.Remove(b.path(bucket, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (b *fsBackend) List(ctx context.Context, bucket, prefix string) (_ []string, err error) {
+	_, span := startSpan(ctx, "fs.List", attribute.String("storage.bucket", bucket), attribute.String("storage.prefix", prefix))
+	defer func() { endSpan(span, err) }()
+	dir := filepath.Join(b.root, bucket)
+	var names []string
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), stagedPrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel = filepath.ToSlash(rel); strings.HasPrefix(rel, prefix) {
+			names = append(names, rel)
+		}
+		return nil
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+func (b *fsBackend) URL(bucket, name string) string {
+	return "file://" + b.path(bucket, name)
+}
+
+// Buckets reports the bucket directories under root.
+func (b *fsBackend) Buckets(_ context.Context) (map[string]time.Time, error) {
+	entries, err := os.ReadDir(b.root)
+	if err != nil {
+		return nil, err
+	}
+	buckets := map[string]time.Time{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		buckets[e.Name()] = info.ModTime().UTC()
+	}
+	return buckets, nil
+}
+
+func (b *fsBackend) RemoveBucket(_ context.Context, bucket string) error {
+	dir := filepath.Join(b.root, bucket)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return fmt.Errorf("bucket %s still holds %s", bucket, path)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (b *fsBackend) TempFiles(_ context.Context) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(b.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasPrefix(d.Name(), stagedPrefix) {
+			return err
+		}
+		rel, err := filepath.Rel(b.root, path)
+		if err == nil {
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return paths, err
+}
+
+func (b *fsBackend) RemoveTempFile(_ context.Context, path string) error {
+	if !strings.HasPrefix(filepath.Base(path), stagedPrefix) {
+		return fmt.Errorf("not a temporary file: %s", path)
+	}
+	return os.Remove(filepath.Join(b.root, filepath.FromSlash(path)))
+}
@@ -0,0 +1,130 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"slices"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+)
+
+// testBackend checks the Backend contract every implementation must meet.
+func testBackend(t *testing.T, b Backend) {
+	t.Helper()
+	ctx := context.Background()
+	get := func(name string, offset, length int64) string {
+		t.Helper()
+		r, err := b.Get(ctx, "b", name, offset, length)
+		if err != nil {
+			t.Fatalf("Get %s failed: %v", name, err)
+		}
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("Get %s failed: %v", name, err)
+		}
+		return string(data)
+	}
+
+	for name, data := range map[string]string{"a.txt": "alpha", "dir/b.txt": "bravo", "dir/c.txt": "charlie"} {
+		if err := b.Put(ctx, "b", name, []byte(data)); err != nil {
+			t.Fatalf("Put %s failed: %v", name, err)
+		}
+	}
+	if err := b.Put(ctx, "b", "a.txt", []byte("alpha two")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if got := get("a.txt", 0, -1); got != "alpha two" {
+		t.Errorf("unexpected data %q", got)
+	}
+	if got := get("a.txt", 6, 2); got != "tw" {
+		t.Errorf("unexpected range %q", got)
+	}
+	if got := get("a.txt", 9, -1); got != "" {
+		t.Errorf("unexpected range at end %q", got)
+	}
+	if info, err := b.Stat(ctx, "b", "dir/c.txt"); err != nil || info.Size != 7 || info.Modified.IsZero() {
+		t.Errorf("unexpected Stat: %+v (%v)", info, err)
+	}
+	if _, err := b.Stat(ctx, "b", "dir"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat of a prefix must not find an object, got %v", err)
+	}
+	if _, err := b.Get(ctx, "b", "missing", 0, -1); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+
+	if names, err := b.List(ctx, "b", ""); err != nil || !slices.Equal(names, []string{"a.txt", "dir/b.txt", "dir/c.txt"}) {
+		t.Errorf("unexpected List: %v (%v)", names, err)
+	}
+	if names, err := b.List(ctx, "b", "dir/c"); err != nil || !slices.Equal(names, []string{"dir/c.txt"}) {
+		t.Errorf("unexpected prefix List: %v (%v)", names, err)
+	}
+	if names, err := b.List(ctx, "empty", ""); err != nil || len(names) != 0 {
+		t.Errorf("unexpected List of an empty bucket: %v (%v)", names, err)
+	}
+
+	if err := b.Delete(ctx, "b", "dir/b.txt"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := b.Delete(ctx, "b", "dir/b.txt"); err != nil {
+		t.Errorf("deleting an absent object must succeed, got %v", err)
+	}
+	if _, err := b.Stat(ctx, "b", "dir/b.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist after Delete, got %v", err)
+	}
+}
+
+func TestFSBackend(t *testing.T) {
+	testBackend(t, NewFSBackend(t.TempDir()))
+
+	ctx := context.Background()
+	b := NewFSBackend(t.TempDir())
+	b.Put(ctx, "b", "dir/o", []byte("x"))
+	legacy := b.(legacyBucketBackend)
+	if err := legacy.RemoveBucket(ctx, "b"); err == nil {
+		t.Error("expected RemoveBucket to refuse a bucket holding data")
+	}
+	b.Delete(ctx, "b", "dir/o")
+	if err := legacy.RemoveBucket(ctx, "b"); err != nil {
+		t.Errorf("RemoveBucket failed: %v", err)
+	}
+	if buckets, _ := legacy.Buckets(ctx); len(buckets) != 0 {
+		t.Errorf("removed bucket still found: %v", buckets)
+	}
+}
+
+// plainBackend hides every optional capability of the backend it wraps.
+type plainBackend struct{ Backend }
+
+func TestServerUsesBackend(t *testing.T) {
+	dir := t.TempDir()
+	backend := NewFSBackend(t.TempDir())
+	server := NewStorageServer(dir, WithBackend(plainBackend{backend}))
+	defer server.Close()
+	ctx := context.Background()
+
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "remote"}))
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "remote", Name: "x/y", Data: []byte("payload")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	if info, err := backend.Stat(ctx, "remote", "x/y"); err != nil || info.Size != 7 {
+		t.Errorf("object not stored in the backend: %+v (%v)", info, err)
+	}
+	list, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "remote"}))
+	if err != nil || !slices.Equal(list.Msg.ObjectNames, []string{"x/y"}) {
+		t.Errorf("unexpected ListObjects: %v (%v)", list, err)
+	}
+	if _, err := server.GetDownloadURL(ctx, connect.NewRequest(&storagev1.GetDownloadURLRequest{Bucket: "remote", Name: "x/y"})); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("expected FailedPrecondition without URL support, got %v", err)
+	}
+	if _, err := server.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "remote", Name: "x/y"})); err != nil {
+		t.Fatalf("DeleteObject failed: %v", err)
+	}
+	if _, err := backend.Stat(ctx, "remote", "x/y"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("object still stored after DeleteObject: %v", err)
+	}
+}
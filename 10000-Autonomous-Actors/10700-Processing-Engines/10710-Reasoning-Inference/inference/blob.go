@@ -0,0 +1,476 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// bucketBlobs maps each blob hash to its blobRecord.
+	bucketBlobs = "blobs"
+	// blobBucket is the backend bucket holding blob data.
+	blobBucket = ".blobs"
+	// maxBlobParts bounds the blobs one object may reference, as GCS
+	// bounds the components of a composite object.
+	maxBlobParts = 1024
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// blobRef is one part of a content-addressed object: the object's data is
+// the concatenation of its parts.
+type blobRef struct {
+	Hash string `json:"sha256"`
+	Size int64  `json:"size"`
+}
+
+// blobRecord counts the object parts referencing a blob. Blobs whose count
+// drops to zero are kept until CollectGarbage removes them, so a
+// transaction that rolls back never leaves a record pointing at deleted
+// data.
+type blobRecord struct {
+	Size int64 `json:"size"`
+	Refs int64 `json:"refs"`
+}
+
+// WithContentAddressing stores new object data once per distinct SHA-256
+// in the backend's reserved ".blobs" bucket, with object records referencing
+// it. Copies and compositions of unencrypted objects then only add
+// references. Objects stored by name before it was enabled stay readable,
+// and move to blobs when next written.
+func WithContentAddressing() Option {
+	return func(s *StorageServer) {
+		s.contentAddressed = true
+	}
+}
+
+func (r *objectRecord) contentAddressed() bool {
+	return len(r.Blobs) > 0
+}
+
+// blobName shards blobs by the first byte of their hash.
+func blobName(hash string) string {
+	return hash[:2] + "/" + hash
+}
+
+// pinBlob keeps CollectGarbage from removing a blob written ahead of the
+// transaction referencing it.
+func (s *StorageServer) pinBlob(hash string) {
+	s.blobsMu.Lock()
+	defer s.blobsMu.Unlock()
+	if s.blobPins == nil {
+		s.blobPins = map[string]int{}
+	}
+	s.blobPins[hash]++
+}
+
+func (s *StorageServer) unpinBlob(hash string) {
+	s.blobsMu.Lock()
+	defer s.blobsMu.Unlock()
+	if s.blobPins[hash]--; s.blobPins[hash] <= 0 {
+		delete(s.blobPins, hash)
+	}
+}
+
+// lockObjectData serializes placing and removing the data stored under an
+// object name. Writers hold it until the transaction recording the data
+// commits, so removeObjectData sees the record of any data it would remove.
+func (s *StorageServer) lockObjectData(bucket, name string) func() {
+	mu := &s.dataLocks[s.dataLockStripe(bucket, name)]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// lockObjectDataAll takes the data locks of all the objects named in recs.
+// Each stripe is locked once and in order, so it cannot deadlock with
+// another caller.
+func (s *StorageServer) lockObjectDataAll(recs []*objectRecord) func() {
+	var stripes []int
+	for _, rec := range recs {
+		stripes = append(stripes, s.dataLockStripe(rec.Bucket, rec.Name))
+	}
+	slices.Sort(stripes)
+	stripes = slices.Compact(stripes)
+	for _, i := range stripes {
+		s.dataLocks[i].Lock()
+	}
+	return func() {
+		for _, i := range stripes {
+			s.dataLocks[i].Unlock()
+		}
+	}
+}
+
+func (s *StorageServer) dataLockStripe(bucket, name string) int {
+	h := fnv.New32a()
+	h.Write([]byte(objectKey(bucket, name)))
+	return int(h.Sum32() % uint32(len(s.dataLocks)))
+}
+
+// removeObjectData deletes the data stored under bucket/name once the
+// transaction dropping its record has committed, unless an object stored
+// by name has taken its place since. Data it fails to delete is left for
+// Fsck to report as orphaned.
+func (s *StorageServer) removeObjectData(ctx context.Context, bucket, name string) {
+	unlock := s.lockObjectData(bucket, name)
+	defer unlock()
+	var replaced bool
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		rec, found, err := s.getObjectRecord(tx, bucket, name)
+		replaced = found && !rec.contentAddressed()
+		return err
+	})
+	if err == nil && !replaced {
+		err = s.backend.Delete(ctx, bucket, name)
+	}
+	if err != nil {
+		slog.Warn("Failed to remove object data", "bucket", bucket, "name", name, "error", err)
+	}
+}
+
+// objectWrite stores the data of one object around the BoltDB transaction
+// recording it, so that the transaction does not wait on the backend:
+// stage writes the data ahead, put or putBlobs records the object within
+// the transaction and finish removes the data it replaced once the
+// transaction commits. Close releases the write whatever the outcome;
+// what a failure leaves behind is for CollectGarbage or Fsck to remove.
+type objectWrite struct {
+	s            *StorageServer
+	bucket, name string
+	unlock       func()
+	// placeLater is set by imports, which place data stored by name only
+	// once their transaction commits; see place.
+	placeLater bool
+
+	// Staged content-addressed data.
+	ref    blobRef
+	pinned bool
+	// Staged data stored by name; data is put within the transaction
+	// when the backend cannot stage it.
+	staged stagedPut
+	data   []byte
+	crc    uint32
+
+	removeNamed bool
+}
+
+// newObjectWrite starts writing the object bucket/name.
+func (s *StorageServer) newObjectWrite(bucket, name string) *objectWrite {
+	return &objectWrite{s: s, bucket: bucket, name: name, unlock: s.lockObjectData(bucket, name)}
+}
+
+// stage writes data ahead of put. Blobs are written unless an identical
+// one is referenced already.
+func (w *objectWrite) stage(ctx context.Context, data []byte) error {
+	s := w.s
+	if !s.contentAddressed {
+		w.crc = crc32.Checksum(data, crc32c)
+		sb, ok := s.backend.(stagingBackend)
+		if !ok {
+			w.data = data
+			return nil
+		}
+		staged, err := sb.Stage(ctx, w.bucket, w.name, data)
+		w.staged = staged
+		return err
+	}
+	sum := sha256.Sum256(data)
+	w.ref = blobRef{Hash: hex.EncodeToString(sum[:]), Size: int64(len(data))}
+	s.pinBlob(w.ref.Hash)
+	w.pinned = true
+	var rec blobRecord
+	var found bool
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		found, err = s.getRecord(tx.Bucket([]byte(bucketBlobs)), bucketBlobs, w.ref.Hash, &rec)
+		return err
+	})
+	if err != nil || (found && rec.Refs > 0) {
+		return err
+	}
+	// An unreferenced blob may have lost its data to an interrupted
+	// collection, so it is written again.
+	return s.backend.Put(ctx, blobBucket, blobName(w.ref.Hash), data)
+}
+
+// put stores rec with the staged data as its content.
+func (w *objectWrite) put(ctx context.Context, tx *bbolt.Tx, rec *objectRecord) error {
+	if !w.s.contentAddressed {
+		rec.Blobs = nil
+		sum := w.crc
+		rec.StoredCRC32C = &sum
+		if err := w.s.putObjectRecord(tx, rec); err != nil {
+			return err
+		}
+		if w.placeLater {
+			return nil
+		}
+		return w.place(ctx)
+	}
+	b := tx.Bucket([]byte(bucketBlobs))
+	found, err := w.s.getRecord(b, bucketBlobs, w.ref.Hash, &blobRecord{})
+	if err != nil {
+		return err
+	}
+	if !found {
+		if err := w.s.putRecord(b, bucketBlobs, w.ref.Hash, &blobRecord{Size: w.ref.Size}); err != nil {
+			return err
+		}
+	}
+	rec.Blobs = []blobRef{w.ref}
+	rec.StoredCRC32C = nil
+	return w.putBlobs(tx, rec)
+}
+
+// place puts the staged data stored by name under the object's name.
+func (w *objectWrite) place(ctx context.Context) error {
+	if w.staged != nil {
+		return w.staged.Place()
+	}
+	return w.s.backend.Put(ctx, w.bucket, w.name, w.data)
+}
+
+// putBlobs stores rec, whose parts are already stored. Any data stored
+// under its name is removed by finish.
+func (w *objectWrite) putBlobs(tx *bbolt.Tx, rec *objectRecord) error {
+	w.removeNamed = true
+	return w.s.putObjectRecord(tx, rec)
+}
+
+// finish removes the data the committed write replaced.
+func (w *objectWrite) finish(ctx context.Context) {
+	if !w.removeNamed {
+		return
+	}
+	if w.unlock != nil {
+		w.unlock()
+		w.unlock = nil
+	}
+	w.s.removeObjectData(ctx, w.bucket, w.name)
+}
+
+func (w *objectWrite) close() {
+	if w.staged != nil {
+		w.staged.Discard()
+	}
+	if w.pinned {
+		w.s.unpinBlob(w.ref.Hash)
+	}
+	if w.unlock != nil {
+		w.unlock()
+	}
+}
+
+// addBlobRefs moves the blob reference counts from old's parts to rec's.
+// Either may be nil.
+func (s *StorageServer) addBlobRefs(tx *bbolt.Tx, old, rec *objectRecord) error {
+	b := tx.Bucket([]byte(bucketBlobs))
+	adjust := func(refs []blobRef, delta int64) error {
+		for _, ref := range refs {
+			var blob blobRecord
+			found, err := s.getRecord(b, bucketBlobs, ref.Hash, &blob)
+			if err != nil {
+				return err
+			}
+			if !found {
+				// Releasing a lost blob must not block deleting the
+				// object referencing it.
+				if delta < 0 {
+					continue
+				}
+				return fmt.Errorf("blob %s is missing", ref.Hash)
+			}
+			blob.Refs += delta
+			if err := s.putRecord(b, bucketBlobs, ref.Hash, &blob); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if rec != nil {
+		if err := adjust(rec.Blobs, 1); err != nil {
+			return err
+		}
+	}
+	if old != nil {
+		return adjust(old.Blobs, -1)
+	}
+	return nil
+}
+
+// currentBlobs returns the parts of obj as committed in tx, failing if the
+// object was replaced or deleted since obj was read. Callers adding
+// references to another object's parts use it so those parts cannot be
+// released in between.
+func (s *StorageServer) currentBlobs(tx *bbolt.Tx, obj *objectRecord) ([]blobRef, error) {
+	current, err := s.currentObject(tx, obj)
+	if err != nil {
+		return nil, err
+	}
+	return current.Blobs, nil
+}
+
+// openStoredData reads length bytes of obj's stored data from offset; a
+// negative length reads to the end.
+func (s *StorageServer) openStoredData(ctx context.Context, obj *objectRecord, offset, length int64) (io.ReadCloser, error) {
+	if !obj.contentAddressed() {
+		return s.backend.Get(ctx, obj.Bucket, obj.Name, offset, length)
+	}
+	r := &blobReader{ctx: ctx, backend: s.backend}
+	for _, ref := range obj.Blobs {
+		if length == 0 {
+			break
+		}
+		if offset >= ref.Size {
+			offset -= ref.Size
+			continue
+		}
+		n := ref.Size - offset
+		if length > 0 {
+			n = min(n, length)
+			length -= n
+		}
+		r.parts = append(r.parts, blobPart{name: blobName(ref.Hash), offset: offset, length: n})
+		offset = 0
+	}
+	return r, nil
+}
+
+type blobPart struct {
+	name           string
+	offset, length int64
+}
+
+// blobReader reads a range spanning the parts of an object, opening each
+// blob only when the previous one is exhausted.
+type blobReader struct {
+	ctx     context.Context
+	backend Backend
+	parts   []blobPart
+	current io.ReadCloser
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.parts) == 0 {
+				return 0, io.EOF
+			}
+			part := r.parts[0]
+			r.parts = r.parts[1:]
+			rc, err := r.backend.Get(r.ctx, blobBucket, part.name, part.offset, part.length)
+			if err != nil {
+				return 0, err
+			}
+			r.current = rc
+		}
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *blobReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	return r.current.Close()
+}
+
+// CollectGarbage deletes the data of blobs no object references, along with
+// blob data left without a record by uploads that failed. It returns the
+// number of blobs removed.
+//
+// The records of unreferenced blobs are dropped in one transaction, and
+// their data deleted once it commits, so a failed delete leaves orphaned
+// data for the next collection rather than a record without data. Uploads
+// write blobs ahead of the transaction referencing them, pinning them until
+// it ends. The collector skips pinned blobs and, holding blobsMu, deletes
+// only data whose record is still gone, so a blob is never removed between
+// being written and being referenced.
+func (s *StorageServer) CollectGarbage(ctx context.Context) (int, error) {
+	stored, err := s.backend.List(ctx, blobBucket, "")
+	if err != nil {
+		return 0, err
+	}
+	// candidates maps the hash of each blob whose data may go to its name.
+	candidates := map[string]string{}
+	for _, name := range stored {
+		candidates[path.Base(name)] = name
+	}
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		s.blobsMu.Lock()
+		defer s.blobsMu.Unlock()
+		b := tx.Bucket([]byte(bucketBlobs))
+		var released []string
+		err := b.ForEach(func(k, v []byte) error {
+			var rec blobRecord
+			if err := s.decodeRecord(bucketBlobs, string(k), v, &rec); err != nil {
+				return err
+			}
+			if rec.Refs <= 0 && s.blobPins[string(k)] == 0 {
+				released = append(released, string(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, hash := range released {
+			if err := b.Delete([]byte(hash)); err != nil {
+				return err
+			}
+			candidates[hash] = blobName(hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.blobsMu.Lock()
+	defer s.blobsMu.Unlock()
+	var doomed []string
+	err = s.view(ctx, func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucketBlobs))
+		for hash, name := range candidates {
+			if b.Get([]byte(hash)) == nil && s.blobPins[hash] == 0 {
+				doomed = append(doomed, name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, name := range doomed {
+		if err := s.backend.Delete(ctx, blobBucket, name); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	if removed > 0 {
+		slog.Info("Collected unreferenced blobs", "count", removed)
+	}
+	return removed, nil
+}
@@ -0,0 +1,317 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"slices"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+func TestContentAddressing(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory(), WithContentAddressing())
+	defer server.Close()
+	ctx := context.Background()
+	upload := func(name, data string) {
+		t.Helper()
+		if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "weights", Name: name, Data: []byte(data)})); err != nil {
+			t.Fatalf("UploadObject %s failed: %v", name, err)
+		}
+	}
+	read := func(name string, offset, length int64) string {
+		t.Helper()
+		obj, err := server.lookupObject(ctx, "weights", name)
+		if err != nil {
+			t.Fatalf("lookupObject %s failed: %v", name, err)
+		}
+		r, err := server.openStoredData(ctx, obj, offset, length)
+		if err != nil {
+			t.Fatalf("openStoredData %s failed: %v", name, err)
+		}
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("reading %s failed: %v", name, err)
+		}
+		return string(data)
+	}
+	blobs := func() []string {
+		t.Helper()
+		names, err := server.backend.List(ctx, blobBucket, "")
+		if err != nil {
+			t.Fatalf("listing blobs failed: %v", err)
+		}
+		return names
+	}
+	refs := func(hash string) int64 {
+		t.Helper()
+		var rec blobRecord
+		server.db.View(func(tx *bbolt.Tx) error {
+			_, err := server.getRecord(tx.Bucket([]byte(bucketBlobs)), bucketBlobs, hash, &rec)
+			return err
+		})
+		return rec.Refs
+	}
+	collect := func() int {
+		t.Helper()
+		n, err := server.CollectGarbage(ctx)
+		if err != nil {
+			t.Fatalf("CollectGarbage failed: %v", err)
+		}
+		return n
+	}
+
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "weights"}))
+	upload("model-a.bin", "0123456789")
+	upload("model-b.bin", "0123456789")
+	upload("tail.bin", "abcdef")
+	if got := blobs(); len(got) != 2 {
+		t.Fatalf("expected identical uploads to share a blob, got %v", got)
+	}
+	obj, _ := server.lookupObject(ctx, "weights", "model-a.bin")
+	shared := obj.Blobs[0].Hash
+	if n := refs(shared); n != 2 {
+		t.Errorf("expected 2 references, got %d", n)
+	}
+
+	if _, err := server.RewriteObject(ctx, connect.NewRequest(&storagev1.RewriteObjectRequest{
+		SourceBucket: "weights", SourceObject: "model-a.bin", DestinationBucket: "weights", DestinationObject: "copy.bin",
+	})); err != nil {
+		t.Fatalf("RewriteObject failed: %v", err)
+	}
+	composed, err := server.ComposeObject(ctx, connect.NewRequest(&storagev1.ComposeObjectRequest{
+		Bucket: "weights", DestinationObject: "joined.bin", SourceObjects: []string{"copy.bin", "tail.bin", "model-b.bin"},
+	}))
+	if err != nil {
+		t.Fatalf("ComposeObject failed: %v", err)
+	}
+	if composed.Msg.Resource.Size != 26 {
+		t.Errorf("unexpected composite size %d", composed.Msg.Resource.Size)
+	}
+	if got := blobs(); len(got) != 2 {
+		t.Errorf("copy and compose must not store data, got blobs %v", got)
+	}
+	if got := read("copy.bin", 0, -1); got != "0123456789" {
+		t.Errorf("unexpected copy %q", got)
+	}
+	if got := read("joined.bin", 0, -1); got != "0123456789abcdef0123456789" {
+		t.Errorf("unexpected composite %q", got)
+	}
+	if got := read("joined.bin", 8, 10); got != "89abcdef01" {
+		t.Errorf("unexpected range across parts %q", got)
+	}
+	list, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "weights"}))
+	if err != nil || !slices.Equal(list.Msg.ObjectNames, []string{"copy.bin", "joined.bin", "model-a.bin", "model-b.bin", "tail.bin"}) {
+		t.Errorf("unexpected ListObjects: %v (%v)", list, err)
+	}
+
+	// A blob written by an upload that never committed has no record.
+	if err := server.backend.Put(ctx, blobBucket, blobName("ff00"), []byte("orphan")); err != nil {
+		t.Fatal(err)
+	}
+	if n := collect(); n != 1 {
+		t.Errorf("expected only the orphan to be collected, got %d", n)
+	}
+	for _, name := range []string{"model-a.bin", "model-b.bin", "copy.bin", "tail.bin"} {
+		if _, err := server.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "weights", Name: name})); err != nil {
+			t.Fatalf("DeleteObject %s failed: %v", name, err)
+		}
+	}
+	if n := collect(); n != 0 {
+		t.Errorf("blobs still referenced by the composite were collected: %d", n)
+	}
+	if n := refs(shared); n != 2 {
+		t.Errorf("expected the composite's 2 references, got %d", n)
+	}
+	if got := read("joined.bin", 0, -1); got != "0123456789abcdef0123456789" {
+		t.Errorf("composite changed after deleting its sources: %q", got)
+	}
+	server.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "weights", Name: "joined.bin"}))
+	if n := collect(); n != 2 {
+		t.Errorf("expected both blobs to be collected, got %d", n)
+	}
+	if got := blobs(); len(got) != 0 {
+		t.Errorf("blobs left after collection: %v", got)
+	}
+}
+
+func TestContentAddressingAdoptsNamedData(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	server := NewStorageServer(dir)
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "mixed"}))
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "mixed", Name: "old", Data: []byte("by name")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	server.Close()
+
+	server = NewStorageServer(dir, WithContentAddressing())
+	defer server.Close()
+	obj, err := server.lookupObject(ctx, "mixed", "old")
+	if err != nil || obj.contentAddressed() {
+		t.Fatalf("object stored by name not found as such: %+v (%v)", obj, err)
+	}
+	if data, err := server.readStoredData(ctx, obj); err != nil || string(data) != "by name" {
+		t.Errorf("unexpected data %q (%v)", data, err)
+	}
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "mixed", Name: "old", Data: []byte("by content")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	if obj, err = server.lookupObject(ctx, "mixed", "old"); err != nil || !obj.contentAddressed() {
+		t.Fatalf("overwritten object not stored by content: %+v (%v)", obj, err)
+	}
+	if _, err := server.backend.Stat(ctx, "mixed", "old"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("data stored by name was not removed on overwrite: %v", err)
+	}
+	list, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "mixed"}))
+	if err != nil || !slices.Equal(list.Msg.ObjectNames, []string{"old"}) {
+		t.Errorf("unexpected ListObjects: %v (%v)", list, err)
+	}
+	if _, err := server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: blobBucket})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected the blob bucket name to be reserved, got %v", err)
+	}
+	url, err := server.GetDownloadURL(ctx, connect.NewRequest(&storagev1.GetDownloadURLRequest{Bucket: "mixed", Name: "old"}))
+	if err != nil || url.Msg.Url != "file://"+server.backend.(*fsBackend).path(blobBucket, blobName(obj.Blobs[0].Hash)) {
+		t.Errorf("unexpected download URL: %v (%v)", url, err)
+	}
+}
+
+func TestObjectWriteStaging(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// A blob written ahead of its transaction survives a collection.
+	server := NewStorageServer("", WithInMemory(), WithContentAddressing())
+	defer server.Close()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	w := server.newObjectWrite("b", "o")
+	if err := w.stage(ctx, []byte("staged")); err != nil {
+		t.Fatalf("stage failed: %v", err)
+	}
+	if removed, err := server.CollectGarbage(ctx); err != nil || removed != 0 {
+		t.Fatalf("collected a staged blob: %d (%v)", removed, err)
+	}
+	w.close()
+	if removed, err := server.CollectGarbage(ctx); err != nil || removed != 1 {
+		t.Errorf("expected the abandoned blob to be collected, got %d (%v)", removed, err)
+	}
+
+	// An overwrite failing in its transaction leaves the data it staged
+	// unplaced.
+	dir := t.TempDir()
+	named := NewStorageServer(dir, WithQuotas(Quotas{DefaultBucket: Quota{MaxObjectSize: 4}}))
+	defer named.Close()
+	named.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	if _, err := named.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("old")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	if _, err := named.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("too big")})); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	obj, err := named.lookupObject(ctx, "b", "o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := named.readStoredData(ctx, obj); err != nil || string(data) != "old" {
+		t.Errorf("unexpected data %q (%v)", data, err)
+	}
+	if res, err := named.Fsck(ctx, FsckOptions{VerifyChecksums: true}); err != nil || len(res.Issues) != 0 {
+		t.Errorf("unexpected fsck result %+v (%v)", res, err)
+	}
+
+	// Deleted data goes once the record has.
+	if _, err := named.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "b", Name: "o"})); err != nil {
+		t.Fatalf("DeleteObject failed: %v", err)
+	}
+	if _, err := named.backend.Stat(ctx, "b", "o"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("deleted object data remains: %v", err)
+	}
+}
+
+// failingDeletes fails every Delete while fail is set.
+type failingDeletes struct {
+	Backend
+	fail bool
+}
+
+func (b *failingDeletes) Delete(ctx context.Context, bucket, name string) error {
+	if b.fail {
+		return errors.New("delete refused")
+	}
+	return b.Backend.Delete(ctx, bucket, name)
+}
+
+func TestCollectGarbageDeletesAfterCommit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	backend := &failingDeletes{Backend: NewFSBackend(t.TempDir())}
+	server := NewStorageServer(t.TempDir(), WithBackend(backend), WithContentAddressing())
+	defer server.Close()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("data")}))
+	obj, err := server.lookupObject(ctx, "b", "o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := obj.Blobs[0].Hash
+	if _, err := server.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "b", Name: "o"})); err != nil {
+		t.Fatalf("DeleteObject failed: %v", err)
+	}
+
+	// The record goes even when the data cannot, leaving an orphan.
+	backend.fail = true
+	if _, err := server.CollectGarbage(ctx); err == nil {
+		t.Fatal("expected the failed delete to be reported")
+	}
+	var found bool
+	server.db.View(func(tx *bbolt.Tx) error {
+		found = tx.Bucket([]byte(bucketBlobs)).Get([]byte(hash)) != nil
+		return nil
+	})
+	if found {
+		t.Error("blob record kept after its collection committed")
+	}
+	if _, err := backend.Stat(ctx, blobBucket, blobName(hash)); err != nil {
+		t.Errorf("blob data should survive the failed delete: %v", err)
+	}
+	backend.fail = false
+	if removed, err := server.CollectGarbage(ctx); err != nil || removed != 1 {
+		t.Errorf("expected the orphaned data to be collected, got %d (%v)", removed, err)
+	}
+}
+
+func TestDeleteObjectGenerationMatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	server := NewStorageServer("", WithInMemory(), WithContentAddressing())
+	defer server.Close()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	first, _ := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("one")}))
+	second, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("two")}))
+	if err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+
+	del := func(generation int64) error {
+		_, err := server.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "b", Name: "o", IfGenerationMatch: generation}))
+		return err
+	}
+	if err := del(first.Msg.Generation); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("expected FailedPrecondition deleting a replaced generation, got %v", err)
+	}
+	if _, err := server.lookupObject(ctx, "b", "o"); err != nil {
+		t.Errorf("a failed precondition deleted the object: %v", err)
+	}
+	if err := del(second.Msg.Generation); err != nil {
+		t.Errorf("DeleteObject of the live generation failed: %v", err)
+	}
+	if err := del(0); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("expected NotFound after the delete, got %v", err)
+	}
+}
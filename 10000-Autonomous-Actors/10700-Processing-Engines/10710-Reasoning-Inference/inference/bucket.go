@@ -0,0 +1,351 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	bucketBuckets = "buckets"
+
+	defaultLocation     = "US"
+	defaultStorageClass = StorageClassStandard
+)
+
+var errBucketNotFound = errors.New("bucket not found")
+
+// bucketRecord is the BoltDB representation of a bucket resource, keyed by
+// bucket name in the "buckets" bucket.
+type bucketRecord struct {
+	Name                  string            `json:"name"`
+	Location              string            `json:"location"`
+	StorageClass          string            `json:"storageClass"`
+	Labels                map[string]string `json:"labels,omitempty"`
+	DefaultObjectMetadata map[string]string `json:"defaultObjectMetadata,omitempty"`
+	Lifecycle             []lifecycleRule   `json:"lifecycle,omitempty"`
+	Acl                   []aclEntry        `json:"acl,omitempty"`
+	DefaultObjectAcl      []aclEntry        `json:"defaultObjectAcl,omitempty"`
+	DefaultKMSKeyName     string            `json:"defaultKmsKeyName,omitempty"`
+	Project               string            `json:"project,omitempty"`
+	Metageneration        int64             `json:"metageneration"`
+	TimeCreated           time.Time         `json:"timeCreated"`
+	Updated               time.Time         `json:"updated"`
+
+	// UniformBucketLevelAccess turns ACL enforcement off. Stored ACLs are
+	// kept so that switching it back restores them.
+	UniformBucketLevelAccess bool `json:"uniformBucketLevelAccess,omitempty"`
+}
+
+func newBucketRecord(name string, now time.Time) *bucketRecord {
+	return &bucketRecord{
+		Name:           name,
+		Location:       defaultLocation,
+		StorageClass:   defaultStorageClass,
+		Metageneration: 1,
+		TimeCreated:    now,
+		Updated:        now,
+	}
+}
+
+func (r *bucketRecord) toProto() *storagev1.Bucket {
+	return &storagev1.Bucket{
+		Name:                  r.Name,
+		Location:              r.Location,
+		StorageClass:          r.StorageClass,
+		Labels:                r.Labels,
+		DefaultObjectMetadata: r.DefaultObjectMetadata,
+		CreateTime:            timestamppb.New(r.TimeCreated),
+		UpdateTime:            timestamppb.New(r.Updated),
+		Metageneration:        r.Metageneration,
+		LifecycleRules:        lifecycleRulesToProto(r.Lifecycle),
+		Acl:                   aclToProto(r.Acl),
+		DefaultObjectAcl:      aclToProto(r.DefaultObjectAcl),
+		DefaultKmsKeyName:     r.DefaultKMSKeyName,
+		Project:               r.project(),
+
+		UniformBucketLevelAccess: r.UniformBucketLevelAccess,
+	}
+}
+
+// matchesLabels reports whether the bucket carries every label in filter. An
+// empty filter value only requires the key to be present.
+func (r *bucketRecord) matchesLabels(filter map[string]string) bool {
+	for k, v := range filter {
+		got, ok := r.Labels[k]
+		if !ok || (v != "" && got != v) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateBucketName(name string) error {
+	if name == "" {
+		return errors.New("bucket name is required")
+	}
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid bucket name: %q", name)
+	}
+	if reservedBucket(name) {
+		return fmt.Errorf("bucket name is reserved: %q", name)
+	}
+	return nil
+}
+
+// reservedBucket reports whether name is a backend bucket the server keeps
+// for itself.
+func reservedBucket(name string) bool {
+	return name == blobBucket || name == quarantineBucket
+}
+
+func (s *StorageServer) getBucketRecord(tx *bbolt.Tx, name string) (*bucketRecord, error) {
+	var rec bucketRecord
+	found, err := s.getRecord(tx.Bucket([]byte(bucketBuckets)), bucketBuckets, name, &rec)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", errBucketNotFound, name)
+	}
+	return &rec, nil
+}
+
+func (s *StorageServer) putBucketRecord(tx *bbolt.Tx, rec *bucketRecord) error {
+	return s.putRecord(tx.Bucket([]byte(bucketBuckets)), bucketBuckets, rec.Name, rec)
+}
+
+// bucketError maps metadata transaction failures onto Connect codes, passing
+// through errors that already carry one.
+func bucketError(err error) error {
+	var cerr *connect.Error
+	if errors.As(err, &cerr) {
+		return cerr
+	}
+	if errors.Is(err, errBucketNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// adoptLegacyBuckets creates default records for bucket directories written
+// before buckets were stored in BoltDB.
+func (s *StorageServer) adoptLegacyBuckets() error {
+	backend, ok := s.backend.(legacyBucketBackend)
+	if !ok {
+		return nil
+	}
+	buckets, err := backend.Buckets(context.Background())
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucketBuckets))
+		for name, created := range buckets {
+			if b.Get([]byte(name)) != nil || reservedBucket(name) {
+				continue
+			}
+			slog.Info("Adopting legacy bucket", "name", name)
+			if err := s.putBucketRecord(tx, newBucketRecord(name, created)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *StorageServer) GetBucket(ctx context.Context, req *connect.Request[storagev1.GetBucketRequest]) (*connect.Response[storagev1.GetBucketResponse], error) {
+	slog.Info("GetBucket", "name", req.Msg.Name)
+	if err := s.authorize(ctx, req.Header(), req.Msg.Name, permBucketsGet); err != nil {
+		return nil, err
+	}
+
+	var rec *bucketRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		rec, err = s.getBucketRecord(tx, req.Msg.Name)
+		return err
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.GetBucketResponse{Bucket: rec.toProto()}), nil
+}
+
+func (s *StorageServer) ListBuckets(ctx context.Context, req *connect.Request[storagev1.ListBucketsRequest]) (*connect.Response[storagev1.ListBucketsResponse], error) {
+	slog.Info("ListBuckets", "prefix", req.Msg.Prefix, "labels", req.Msg.Labels)
+
+	// Only buckets the caller may read are listed.
+	member := principalOf(ctx, req.Header())
+	var buckets []*storagev1.Bucket
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		c := tx.Bucket([]byte(bucketBuckets)).Cursor()
+		for k, _ := c.Seek([]byte(req.Msg.Prefix)); k != nil && strings.HasPrefix(string(k), req.Msg.Prefix); k, _ = c.Next() {
+			rec, err := s.getBucketRecord(tx, string(k))
+			if err != nil {
+				return err
+			}
+			ok, err := s.canAccessTx(tx, member, rec.Name, "", permBucketsGet)
+			if err != nil {
+				return err
+			}
+			if ok && rec.matchesLabels(req.Msg.Labels) {
+				buckets = append(buckets, rec.toProto())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&storagev1.ListBucketsResponse{Buckets: buckets}), nil
+}
+
+func (s *StorageServer) UpdateBucket(ctx context.Context, req *connect.Request[storagev1.UpdateBucketRequest]) (*connect.Response[storagev1.UpdateBucketResponse], error) {
+	patch := req.Msg.GetBucket()
+	if patch == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("bucket is required"))
+	}
+	paths := req.Msg.GetUpdateMask().GetPaths()
+	slog.Info("UpdateBucket", "name", patch.Name, "paths", paths)
+	if err := s.authorize(ctx, req.Header(), patch.Name, permBucketsUpdate); err != nil {
+		return nil, err
+	}
+
+	var rec *bucketRecord
+	err := s.update(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		rec, err = s.getBucketRecord(tx, patch.Name)
+		if err != nil {
+			return err
+		}
+		previousKey := rec.DefaultKMSKeyName
+		if len(paths) == 0 {
+			err = applyBucketMerge(rec, patch)
+		} else {
+			err = applyBucketMask(rec, patch, paths)
+		}
+		if err != nil {
+			return err
+		}
+		if rec.DefaultKMSKeyName != "" && rec.DefaultKMSKeyName != previousKey {
+			if err := s.checkKMSKeyName(rec.DefaultKMSKeyName); err != nil {
+				return err
+			}
+		}
+		rec.Metageneration++
+		rec.Updated = time.Now().UTC()
+		return s.putBucketRecord(tx, rec)
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.UpdateBucketResponse{Bucket: rec.toProto()}), nil
+}
+
+// applyBucketMerge applies every non-empty field of patch, merging maps.
+func applyBucketMerge(rec *bucketRecord, patch *storagev1.Bucket) error {
+	if patch.Location != "" {
+		rec.Location = patch.Location
+	}
+	if patch.StorageClass != "" {
+		class, err := normalizeStorageClass(patch.StorageClass, rec.StorageClass)
+		if err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		rec.StorageClass = class
+	}
+	if len(patch.LifecycleRules) > 0 {
+		rules, err := lifecycleRulesFromProto(patch.LifecycleRules)
+		if err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		rec.Lifecycle = rules
+	}
+	if patch.UniformBucketLevelAccess {
+		rec.UniformBucketLevelAccess = true
+	}
+	if patch.DefaultKmsKeyName != "" {
+		rec.DefaultKMSKeyName = patch.DefaultKmsKeyName
+	}
+	rec.Labels = mergeStrings(rec.Labels, patch.Labels)
+	rec.DefaultObjectMetadata = mergeStrings(rec.DefaultObjectMetadata, patch.DefaultObjectMetadata)
+	return nil
+}
+
+// applyBucketMask replaces exactly the fields named by paths.
+func applyBucketMask(rec *bucketRecord, patch *storagev1.Bucket, paths []string) error {
+	for _, p := range paths {
+		switch {
+		case p == "location":
+			rec.Location = patch.Location
+		case p == "storage_class":
+			class, err := normalizeStorageClass(patch.StorageClass, defaultStorageClass)
+			if err != nil {
+				return connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			rec.StorageClass = class
+		case p == "lifecycle_rules":
+			rules, err := lifecycleRulesFromProto(patch.LifecycleRules)
+			if err != nil {
+				return connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			rec.Lifecycle = rules
+		case p == "uniform_bucket_level_access":
+			rec.UniformBucketLevelAccess = patch.UniformBucketLevelAccess
+		case p == "default_kms_key_name":
+			rec.DefaultKMSKeyName = patch.DefaultKmsKeyName
+		case p == "labels":
+			rec.Labels = copyStrings(patch.Labels)
+		case p == "default_object_metadata":
+			rec.DefaultObjectMetadata = copyStrings(patch.DefaultObjectMetadata)
+		case strings.HasPrefix(p, "labels."):
+			key := strings.TrimPrefix(p, "labels.")
+			if v, ok := patch.Labels[key]; ok {
+				rec.Labels = mergeStrings(rec.Labels, map[string]string{key: v})
+			} else {
+				delete(rec.Labels, key)
+			}
+		default:
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update_mask path: %q", p))
+		}
+	}
+	if rec.Location == "" {
+		rec.Location = defaultLocation
+	}
+	return nil
+}
+
+func copyStrings(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func mergeStrings(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
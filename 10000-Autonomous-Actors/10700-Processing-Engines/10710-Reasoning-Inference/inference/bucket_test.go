@@ -0,0 +1,70 @@
+package inference
+
+import (
+	"context"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestBucketMetadata(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	ctx := context.Background()
+
+	created, err := server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{
+		Name:                  "finops",
+		Labels:                map[string]string{"team": "finops", "env": "dev"},
+		DefaultObjectMetadata: map[string]string{"owner": "finops"},
+	}))
+	if err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	if b := created.Msg.Bucket; b.Location != "US" || b.StorageClass != "STANDARD" || b.Metageneration != 1 {
+		t.Errorf("unexpected defaults: %v", b)
+	}
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "other", Labels: map[string]string{"team": "ml"}}))
+
+	_, err = server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "finops"}))
+	if connect.CodeOf(err) != connect.CodeAlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+
+	// Patch one label away and change the location, leaving the rest alone.
+	updated, err := server.UpdateBucket(ctx, connect.NewRequest(&storagev1.UpdateBucketRequest{
+		Bucket:     &storagev1.Bucket{Name: "finops", Location: "EU"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"location", "labels.env"}},
+	}))
+	if err != nil {
+		t.Fatalf("UpdateBucket failed: %v", err)
+	}
+	b := updated.Msg.Bucket
+	if b.Location != "EU" || b.Labels["team"] != "finops" || b.Labels["env"] != "" || b.Metageneration != 2 {
+		t.Errorf("unexpected patched bucket: %v", b)
+	}
+
+	list, err := server.ListBuckets(ctx, connect.NewRequest(&storagev1.ListBucketsRequest{
+		Labels: map[string]string{"team": "finops"},
+	}))
+	if err != nil {
+		t.Fatalf("ListBuckets failed: %v", err)
+	}
+	if len(list.Msg.Buckets) != 1 || list.Msg.Buckets[0].Name != "finops" {
+		t.Errorf("expected only finops bucket, got %v", list.Msg.Buckets)
+	}
+
+	// Default object metadata fills in keys the upload omits.
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "finops", Name: "report.csv", Data: []byte("a,b")}))
+	meta, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "finops", Name: "report.csv"}))
+	if err != nil || meta.Msg.Metadata["owner"] != "finops" {
+		t.Errorf("expected default object metadata, got %v (%v)", meta, err)
+	}
+
+	_, err = server.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: "missing"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+// maxComposeSources is the GCS limit on source objects per composition.
+const maxComposeSources = 32
+
+func (s *StorageServer) ComposeObject(ctx context.Context, req *connect.Request[storagev1.ComposeObjectRequest]) (*connect.Response[storagev1.ComposeObjectResponse], error) {
+	m := req.Msg
+	slog.Info("ComposeObject", "bucket", m.Bucket, "destination", m.DestinationObject, "sources", len(m.SourceObjects))
+	if err := validateObjectName(m.DestinationObject); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if len(m.SourceObjects) == 0 || len(m.SourceObjects) > maxComposeSources {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("between 1 and %d source objects are required, got %d", maxComposeSources, len(m.SourceObjects)))
+	}
+	member := principalOf(ctx, req.Header())
+	if err := s.authorize(ctx, req.Header(), m.Bucket, permObjectsCreate); err != nil {
+		return nil, err
+	}
+	for _, name := range m.SourceObjects {
+		if err := s.authorizeObject(ctx, req.Header(), m.Bucket, name, permObjectsGet); err != nil {
+			return nil, err
+		}
+	}
+	key, err := customerKeyFromProto(m.CommonObjectRequestParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var bucket *bucketRecord
+	err = s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		bucket, err = s.getBucketRecord(tx, m.Bucket)
+		return err
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	acl, err := newObjectAcl(bucket, m.DestinationPredefinedAcl, member)
+	if err != nil {
+		return nil, err
+	}
+	kmsKey, err := s.objectKMSKey(bucket, m.KmsKeyName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// An unencrypted composite of unencrypted content-addressed sources
+	// references their blobs instead of copying them.
+	sources := make([]*objectRecord, len(m.SourceObjects))
+	byRef := key == nil && kmsKey == ""
+	parts := 0
+	var size int64
+	for i, name := range m.SourceObjects {
+		if sources[i], err = s.lookupObject(ctx, m.Bucket, name); err != nil {
+			return nil, objectError(err)
+		}
+		byRef = byRef && sources[i].contentAddressed() && !sources[i].encrypted()
+		parts += len(sources[i].Blobs)
+		size += sources[i].Size
+	}
+	byRef = byRef && parts <= maxBlobParts
+
+	// Sources encrypted with a customer-supplied key must all use the key
+	// that encrypts the composite; unencrypted sources are accepted too.
+	var sealed []byte
+	var env *kmsEnvelope
+	if !byRef {
+		var data []byte
+		for _, src := range sources {
+			srcKey := key
+			if src.CustomerEncryption == nil {
+				srcKey = nil
+			}
+			part, err := s.readObjectData(ctx, src, srcKey)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, part...)
+		}
+		if sealed, env, err = s.sealObjectData(ctx, data, key, kmsKey); err != nil {
+			return nil, err
+		}
+		size = int64(len(data))
+	}
+
+	now := time.Now().UTC()
+	dst := &objectRecord{
+		Bucket:                  m.Bucket,
+		Name:                    m.DestinationObject,
+		Size:                    size,
+		Metadata:                mergeStrings(copyStrings(bucket.DefaultObjectMetadata), m.Metadata),
+		StorageClass:            bucket.StorageClass,
+		Generation:              s.nextGeneration(now),
+		Metageneration:          1,
+		TimeCreated:             now,
+		Updated:                 now,
+		TimeStorageClassUpdated: now,
+		Acl:                     acl,
+		CustomerEncryption:      key.encryption(),
+		KMS:                     env,
+	}
+	w := s.newObjectWrite(dst.Bucket, dst.Name)
+	defer w.close()
+	if !byRef {
+		if err := s.precheckQuota(ctx, dst); err != nil {
+			return nil, objectError(err)
+		}
+		if err := w.stage(ctx, sealed); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to store object data: %v", err))
+		}
+	}
+	var old *objectRecord
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		var found bool
+		var err error
+		old, found, err = s.getObjectRecord(tx, dst.Bucket, dst.Name)
+		if err != nil {
+			return err
+		}
+		if found {
+			if err := s.authorizeTx(tx, member, dst.Bucket, permObjectsDelete); err != nil {
+				return err
+			}
+			if err := s.recordEarlyDeletion(tx, old, now, "compose"); err != nil {
+				return err
+			}
+		}
+		if err := s.checkQuota(tx, old, dst); err != nil {
+			return err
+		}
+		if !byRef {
+			return w.put(ctx, tx, dst)
+		}
+		for _, src := range sources {
+			blobs, err := s.currentBlobs(tx, src)
+			if err != nil {
+				return err
+			}
+			dst.Blobs = append(dst.Blobs, blobs...)
+		}
+		return w.putBlobs(tx, dst)
+	})
+	if err != nil {
+		return nil, objectError(err)
+	}
+	w.finish(ctx)
+	s.emit(overwriteEvents(old, dst)...)
+	return connect.NewResponse(&storagev1.ComposeObjectResponse{Resource: dst.toProto()}), nil
+}
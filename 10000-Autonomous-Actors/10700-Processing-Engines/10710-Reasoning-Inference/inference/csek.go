@@ -0,0 +1,153 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+)
+
+const encryptionAlgorithmAES256 = "AES256"
+
+// customerEncryption is stored on objects encrypted with a customer-supplied
+// key. Only the key's hash is kept; the key itself never touches disk.
+type customerEncryption struct {
+	Algorithm string `json:"encryptionAlgorithm"`
+	KeySHA256 []byte `json:"keySha256"`
+}
+
+func (c *customerEncryption) toProto() *storagev1.CustomerEncryption {
+	if c == nil {
+		return nil
+	}
+	return &storagev1.CustomerEncryption{EncryptionAlgorithm: c.Algorithm, KeySha256Bytes: c.KeySHA256}
+}
+
+// customerKey is a validated customer-supplied AES-256 key.
+type customerKey struct {
+	key    []byte
+	sha256 []byte
+}
+
+// newCustomerKey validates a key as GCS does. It returns nil when no key is
+// supplied.
+func newCustomerKey(algorithm string, key, keySHA256 []byte) (*customerKey, error) {
+	if algorithm == "" && len(key) == 0 && len(keySHA256) == 0 {
+		return nil, nil
+	}
+	if algorithm != encryptionAlgorithmAES256 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported encryption algorithm: %q", algorithm))
+	}
+	if len(key) != 32 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("encryption key must be 32 bytes for AES256"))
+	}
+	sum := sha256.Sum256(key)
+	if len(keySHA256) > 0 && !bytes.Equal(keySHA256, sum[:]) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("the provided encryption key SHA-256 does not match the key"))
+	}
+	return &customerKey{key: key, sha256: sum[:]}, nil
+}
+
+func customerKeyFromProto(p *storagev1.CommonObjectRequestParams) (*customerKey, error) {
+	return newCustomerKey(p.GetEncryptionAlgorithm(), p.GetEncryptionKeyBytes(), p.GetEncryptionKeySha256Bytes())
+}
+
+func (k *customerKey) encryption() *customerEncryption {
+	if k == nil {
+		return nil
+	}
+	return &customerEncryption{Algorithm: encryptionAlgorithmAES256, KeySHA256: k.sha256}
+}
+
+func (k *customerKey) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with AES-256-GCM, prefixing the random nonce.
+func (k *customerKey) seal(plaintext []byte) ([]byte, error) {
+	if k == nil {
+		return plaintext, nil
+	}
+	aead, err := k.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (k *customerKey) open(sealed []byte) ([]byte, error) {
+	aead, err := k.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("encrypted object is truncated")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+}
+
+// checkCustomerKey verifies that key is the one obj was encrypted with,
+// returning the errors GCS gives for a missing, wrong or unexpected key.
+func checkCustomerKey(obj *objectRecord, key *customerKey) error {
+	switch {
+	case obj.CustomerEncryption == nil && key != nil:
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("object %s is not encrypted by a customer-supplied encryption key", objectKey(obj.Bucket, obj.Name)))
+	case obj.CustomerEncryption != nil && key == nil:
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("object %s is encrypted by a customer-supplied encryption key", objectKey(obj.Bucket, obj.Name)))
+	case obj.CustomerEncryption != nil && !bytes.Equal(obj.CustomerEncryption.KeySHA256, key.sha256):
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("the provided encryption key is incorrect for object %s", objectKey(obj.Bucket, obj.Name)))
+	}
+	return nil
+}
+
+// readObjectData returns the plaintext of obj, decrypting it with the
+// customer-supplied key or the data key wrapped in its KMS envelope.
+func (s *StorageServer) readObjectData(ctx context.Context, obj *objectRecord, key *customerKey) ([]byte, error) {
+	if err := checkCustomerKey(obj, key); err != nil {
+		return nil, err
+	}
+	data, err := s.readStoredData(ctx, obj)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read object: %v", err))
+	}
+	if obj.KMS != nil {
+		if key, err = s.dataKey(ctx, obj.KMS); err != nil {
+			return nil, err
+		}
+	}
+	if key == nil {
+		return data, nil
+	}
+	plaintext, err := key.open(data)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to decrypt object: %v", err))
+	}
+	return plaintext, nil
+}
+
+// customerEncryptionResource renders the customerEncryption field of a GCS
+// JSON object resource.
+func customerEncryptionResource(c *customerEncryption) map[string]any {
+	return map[string]any{
+		"encryptionAlgorithm": c.Algorithm,
+		"keySha256":           base64.StdEncoding.EncodeToString(c.KeySHA256),
+	}
+}
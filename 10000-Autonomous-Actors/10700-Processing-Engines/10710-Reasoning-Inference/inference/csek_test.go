@@ -0,0 +1,95 @@
+package inference
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+func csek(fill byte) *storagev1.CommonObjectRequestParams {
+	return &storagev1.CommonObjectRequestParams{EncryptionAlgorithm: "AES256", EncryptionKeyBytes: bytes.Repeat([]byte{fill}, 32)}
+}
+
+func TestCustomerSuppliedKeys(t *testing.T) {
+	dir := t.TempDir()
+	server := NewStorageServer(dir)
+	defer server.Close()
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+
+	read := func(name string, params *storagev1.CommonObjectRequestParams) (string, error) {
+		stream, err := client.ReadObject(ctx, connect.NewRequest(&storagev1.ReadObjectRequest{Bucket: "vault", Name: name, ReadOffset: 10, CommonObjectRequestParams: params}))
+		if err != nil {
+			return "", err
+		}
+		data, err := receiveAll(stream)
+		return string(data), err
+	}
+
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "vault"}))
+	_, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "vault", Name: "secret", Data: []byte("plaintext secret"), CommonObjectRequestParams: csek(1)}))
+	if err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "vault", Name: "plain", Data: []byte("plaintext open")}))
+
+	onDisk, _ := os.ReadFile(server.backend.(*fsBackend).path("vault", "secret"))
+	if bytes.Contains(onDisk, []byte("plaintext")) {
+		t.Errorf("object stored unencrypted: %q", onDisk)
+	}
+	meta, _ := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "vault", Name: "secret"}))
+	sum := sha256.Sum256(bytes.Repeat([]byte{1}, 32))
+	if enc := meta.Msg.CustomerEncryption; meta.Msg.Size != 16 || enc == nil || !bytes.Equal(enc.KeySha256Bytes, sum[:]) {
+		t.Errorf("unexpected metadata: %v", meta.Msg)
+	}
+
+	if data, err := read("secret", csek(1)); err != nil || data != "secret" {
+		t.Errorf("unexpected read %q (%v)", data, err)
+	}
+	for name, params := range map[string]*storagev1.CommonObjectRequestParams{"missing": nil, "wrong": csek(2)} {
+		if _, err := read("secret", params); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s key: expected InvalidArgument, got %v", name, err)
+		}
+	}
+	if _, err := read("plain", csek(1)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("key for unencrypted object: expected InvalidArgument, got %v", err)
+	}
+	bad := csek(1)
+	bad.EncryptionKeySha256Bytes = make([]byte, 32)
+	if _, err := read("secret", bad); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("mismatched key hash: expected InvalidArgument, got %v", err)
+	}
+	if _, err := server.GetDownloadURL(ctx, connect.NewRequest(&storagev1.GetDownloadURLRequest{Bucket: "vault", Name: "secret"})); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("expected FailedPrecondition for download URL, got %v", err)
+	}
+
+	// Rotate the key by rewriting the object onto itself.
+	old := csek(1)
+	_, err = server.RewriteObject(ctx, connect.NewRequest(&storagev1.RewriteObjectRequest{
+		SourceBucket: "vault", SourceObject: "secret", DestinationBucket: "vault", DestinationObject: "secret",
+		CommonObjectRequestParams:     csek(3),
+		CopySourceEncryptionAlgorithm: old.EncryptionAlgorithm,
+		CopySourceEncryptionKeyBytes:  old.EncryptionKeyBytes,
+	}))
+	if err != nil {
+		t.Fatalf("RewriteObject failed: %v", err)
+	}
+	if _, err := read("secret", csek(1)); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("old key must not read after rotation, got %v", err)
+	}
+	if data, err := read("secret", csek(3)); err != nil || data != "secret" {
+		t.Errorf("unexpected read after rotation %q (%v)", data, err)
+	}
+}
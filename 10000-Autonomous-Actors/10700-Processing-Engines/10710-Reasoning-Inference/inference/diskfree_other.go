@@ -0,0 +1,11 @@
+//go:build !unix && !wasm
+
+package inference
+
+import "errors"
+
+// freeDiskBytes is not implemented off Unix; the readiness check skips the
+// free space threshold there.
+func freeDiskBytes(dir string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
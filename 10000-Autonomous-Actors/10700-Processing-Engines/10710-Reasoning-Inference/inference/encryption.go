@@ -0,0 +1,329 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// bucketKeys holds the wrapped metadata key. Its values are never sealed.
+	bucketKeys        = "keys"
+	metadataKeyRecord = "metadata"
+)
+
+// kmsEnvelope records the data encryption key of an object encrypted at
+// rest, wrapped by a KMS key version.
+type kmsEnvelope struct {
+	KeyName    string `json:"keyName"`
+	KeyVersion string `json:"keyVersion"`
+	WrappedKey []byte `json:"wrappedKey"`
+}
+
+// WithKMS encrypts data at rest through kms. Objects are encrypted with the
+// key named at upload or the bucket default; when metadataKey is set, BoltDB
+// record values are sealed with a key it wraps, and existing plaintext
+// records are encrypted on startup.
+func WithKMS(kms KMS, metadataKey string) Option {
+	return func(s *StorageServer) {
+		s.kms = kms
+		s.metadataKeyName = metadataKey
+	}
+}
+
+// openMetadataKey unwraps (creating on first use) the key that seals record
+// values and registers it for the record helpers.
+func (s *StorageServer) openMetadataKey(ctx context.Context) error {
+	if !validKMSKeyName(s.metadataKeyName) {
+		return fmt.Errorf("invalid metadata KMS key name: %q", s.metadataKeyName)
+	}
+	var stored kmsEnvelope
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		data := tx.Bucket([]byte(bucketKeys)).Get([]byte(metadataKeyRecord))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, &stored)
+	})
+	if err != nil {
+		return err
+	}
+
+	var dek []byte
+	if stored.WrappedKey == nil {
+		dek = make([]byte, 32)
+		if _, err := rand.Read(dek); err != nil {
+			return err
+		}
+		slog.Info("Creating metadata encryption key", "kmsKey", s.metadataKeyName)
+	} else if dek, err = s.kms.Decrypt(ctx, stored.KeyName, stored.WrappedKey); err != nil {
+		return fmt.Errorf("failed to unwrap metadata key with %s: %v", stored.KeyName, err)
+	}
+	s.metadataKey = &customerKey{key: dek}
+	if stored.KeyName != s.metadataKeyName {
+		if err := s.wrapMetadataKey(ctx); err != nil {
+			return err
+		}
+	}
+	aead, err := s.metadataKey.aead()
+	if err != nil {
+		return err
+	}
+	s.recordSealer = aead
+	return s.sealPlaintextRecords()
+}
+
+// wrapMetadataKey stores the metadata key wrapped by the primary version of
+// the configured KMS key.
+func (s *StorageServer) wrapMetadataKey(ctx context.Context) error {
+	wrapped, version, err := s.kms.Encrypt(ctx, s.metadataKeyName, s.metadataKey.key)
+	if err != nil {
+		return fmt.Errorf("failed to wrap metadata key: %v", err)
+	}
+	data, err := json.Marshal(kmsEnvelope{KeyName: s.metadataKeyName, KeyVersion: version, WrappedKey: wrapped})
+	if err != nil {
+		return err
+	}
+	return s.update(ctx, func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte(bucketKeys)).Put([]byte(metadataKeyRecord), data)
+	})
+}
+
+// sealPlaintextRecords encrypts record values written before the metadata
+// key was configured, and reseals values sealed before records were bound to
+// their location.
+func (s *StorageServer) sealPlaintextRecords() error {
+	sealed := 0
+	// Nested buckets, such as each bucket's object records, are sealed too;
+	// path names each one the way putRecord's callers do.
+	var seal func(b *bbolt.Bucket, path string) error
+	seal = func(b *bbolt.Bucket, path string) error {
+		var keys, values, nested [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			switch {
+			case v == nil:
+				nested = append(nested, k)
+			case !bytes.HasPrefix(v, sealedRecordPrefix):
+				keys = append(keys, k)
+				values = append(values, v)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for i, k := range keys {
+			plain, err := s.openRecord(path, string(k), values[i])
+			if err != nil {
+				return err
+			}
+			data, err := sealRecord(s.recordSealer, path, string(k), plain)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(k, data); err != nil {
+				return err
+			}
+		}
+		sealed += len(keys)
+		for _, k := range nested {
+			if err := seal(b.Bucket(k), path+"/"+string(k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+			if string(name) == bucketKeys {
+				return nil
+			}
+			return seal(b, string(name))
+		})
+	})
+	if sealed > 0 {
+		slog.Info("Encrypted plaintext metadata records", "count", sealed)
+	}
+	return err
+}
+
+// encrypted reports whether the object's data is stored encrypted.
+func (r *objectRecord) encrypted() bool {
+	return r.CustomerEncryption != nil || r.KMS != nil
+}
+
+// objectKMSKey resolves the KMS key for a new object: the requested key,
+// else the bucket default. Customer-supplied keys take the place of both.
+func (s *StorageServer) objectKMSKey(bucket *bucketRecord, requested string, csek *customerKey) (string, error) {
+	if csek != nil {
+		if requested != "" {
+			return "", connect.NewError(connect.CodeInvalidArgument, errors.New("a customer-supplied key and a KMS key cannot both be specified"))
+		}
+		return "", nil
+	}
+	name := requested
+	if name == "" {
+		name = bucket.DefaultKMSKeyName
+	}
+	if name == "" {
+		return "", nil
+	}
+	if err := s.checkKMSKeyName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func (s *StorageServer) checkKMSKeyName(name string) error {
+	if s.kms == nil {
+		return connect.NewError(connect.CodeFailedPrecondition, errors.New("no KMS is configured"))
+	}
+	if !validKMSKeyName(name) {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid KMS key name: %q", name))
+	}
+	return nil
+}
+
+// sealObjectData encrypts object data for storage with the customer-supplied
+// key or, given kmsKey, a fresh data key wrapped by KMS.
+func (s *StorageServer) sealObjectData(ctx context.Context, data []byte, csek *customerKey, kmsKey string) ([]byte, *kmsEnvelope, error) {
+	if kmsKey == "" {
+		sealed, err := csek.seal(data)
+		if err != nil {
+			return nil, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encrypt object: %v", err))
+		}
+		return sealed, nil, nil
+	}
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, nil, connect.NewError(connect.CodeInternal, err)
+	}
+	wrapped, version, err := s.kms.Encrypt(ctx, kmsKey, dek)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to wrap data key with %s: %v", kmsKey, err))
+	}
+	sealed, err := (&customerKey{key: dek}).seal(data)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encrypt object: %v", err))
+	}
+	return sealed, &kmsEnvelope{KeyName: kmsKey, KeyVersion: version, WrappedKey: wrapped}, nil
+}
+
+// dataKey unwraps the data key of an object encrypted through KMS.
+func (s *StorageServer) dataKey(ctx context.Context, env *kmsEnvelope) (*customerKey, error) {
+	if s.kms == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("object is encrypted with %s but no KMS is configured", env.KeyName))
+	}
+	dek, err := s.kms.Decrypt(ctx, env.KeyName, env.WrappedKey)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to unwrap data key with %s: %v", env.KeyVersion, err))
+	}
+	return &customerKey{key: dek}, nil
+}
+
+func (s *StorageServer) RotateKmsKey(ctx context.Context, req *connect.Request[storagev1.RotateKmsKeyRequest]) (*connect.Response[storagev1.RotateKmsKeyResponse], error) {
+	name := req.Msg.KmsKeyName
+	slog.Info("RotateKmsKey", "kmsKey", name, "principal", principalOf(ctx, req.Header()))
+	// Key management sits outside bucket IAM, as Cloud KMS keys do, and is
+	// left to the server administrators.
+	if err := s.authorizeAdmin(ctx, req.Header(), "rotate KMS keys"); err != nil {
+		return nil, err
+	}
+	if err := s.checkKMSKeyName(name); err != nil {
+		return nil, err
+	}
+	version, err := s.kms.Rotate(ctx, name)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to rotate %s: %v", name, err))
+	}
+	if name == s.metadataKeyName && s.metadataKey != nil {
+		if err := s.wrapMetadataKey(ctx); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		n, err := s.reencryptObjects(context.Background(), name)
+		if err != nil {
+			slog.Error("Re-encryption failed", "kmsKey", name, "error", err)
+			return
+		}
+		slog.Info("Re-encryption finished", "kmsKey", name, "version", version, "objects", n)
+	}()
+	return connect.NewResponse(&storagev1.RotateKmsKeyResponse{PrimaryVersion: version}), nil
+}
+
+// reencryptObjects gives every object whose data key is wrapped by an older
+// version of key a new data key under the primary version. Generations are
+// unchanged; objects replaced meanwhile are skipped.
+func (s *StorageServer) reencryptObjects(ctx context.Context, key string) (int, error) {
+	primary, err := s.kms.PrimaryVersion(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	var stale []*objectRecord
+	err = s.view(ctx, func(tx *bbolt.Tx) error {
+		return s.forEachObject(tx, func(rec *objectRecord) error {
+			if rec.KMS != nil && rec.KMS.KeyName == key && rec.KMS.KeyVersion != primary {
+				stale = append(stale, rec)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, obj := range stale {
+		data, err := s.readObjectData(ctx, obj, nil)
+		if err != nil {
+			return done, err
+		}
+		sealed, env, err := s.sealObjectData(ctx, data, nil, key)
+		if err != nil {
+			return done, err
+		}
+		w := s.newObjectWrite(obj.Bucket, obj.Name)
+		if err := w.stage(ctx, sealed); err != nil {
+			w.close()
+			return done, err
+		}
+		replaced := false
+		err = s.update(ctx, func(tx *bbolt.Tx) error {
+			current, found, err := s.getObjectRecord(tx, obj.Bucket, obj.Name)
+			if err != nil {
+				return err
+			}
+			if !found || current.Generation != obj.Generation {
+				replaced = true
+				return nil
+			}
+			current.KMS = env
+			return w.put(ctx, tx, current)
+		})
+		if err == nil {
+			w.finish(ctx)
+		}
+		w.close()
+		if err != nil {
+			return done, err
+		}
+		if !replaced {
+			done++
+		}
+	}
+	return done, nil
+}
@@ -0,0 +1,242 @@
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	testObjectKey   = "projects/demo/locations/global/keyRings/storage/cryptoKeys/objects"
+	testMetadataKey = "projects/demo/locations/global/keyRings/storage/cryptoKeys/metadata"
+)
+
+// fakeCloudKMS serves the Cloud KMS REST calls used by the KMS client from a
+// local keyring.
+func fakeCloudKMS(keyring KMS) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := strings.TrimPrefix(r.URL.Path, "/v1/")
+		var in map[string]string
+		json.NewDecoder(r.Body).Decode(&in)
+		ctx := r.Context()
+		var out any
+		var err error
+		switch {
+		case strings.HasSuffix(resource, ":encrypt"):
+			plaintext, _ := base64.StdEncoding.DecodeString(in["plaintext"])
+			var ciphertext []byte
+			var version string
+			ciphertext, version, err = keyring.Encrypt(ctx, strings.TrimSuffix(resource, ":encrypt"), plaintext)
+			out = map[string]string{"name": version, "ciphertext": base64.StdEncoding.EncodeToString(ciphertext)}
+		case strings.HasSuffix(resource, ":decrypt"):
+			ciphertext, _ := base64.StdEncoding.DecodeString(in["ciphertext"])
+			var plaintext []byte
+			plaintext, err = keyring.Decrypt(ctx, strings.TrimSuffix(resource, ":decrypt"), ciphertext)
+			out = map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+		case strings.HasSuffix(resource, "/cryptoKeyVersions"):
+			var version string
+			version, err = keyring.Rotate(ctx, strings.TrimSuffix(resource, "/cryptoKeyVersions"))
+			out = map[string]string{"name": version}
+		case strings.HasSuffix(resource, ":updatePrimaryVersion"):
+			out = map[string]string{}
+		default:
+			var version string
+			version, err = keyring.PrimaryVersion(ctx, resource)
+			out = map[string]any{"primary": map[string]string{"name": version}}
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(out)
+	}))
+}
+
+func TestEncryptionAtRest(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// Data written before encryption was configured.
+	plain := NewStorageServer(dir)
+	plain.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "ledger", Labels: map[string]string{"team": "finops"}}))
+	plain.Close()
+
+	keyring, err := NewLocalKeyring(filepath.Join(t.TempDir(), "keyring.json"))
+	if err != nil {
+		t.Fatalf("NewLocalKeyring failed: %v", err)
+	}
+	kms := fakeCloudKMS(keyring)
+	defer kms.Close()
+	server := NewStorageServer(dir, WithKMS(NewKMSClient(kms.URL), testMetadataKey), WithAdmins(alice))
+
+	err = server.db.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+			return b.ForEach(func(k, v []byte) error {
+				if bytes.Contains(v, []byte("finops")) {
+					t.Errorf("plaintext record left in %s/%s", name, k)
+				}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := server.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: "ledger"})); err != nil || got.Msg.Bucket.Labels["team"] != "finops" {
+		t.Fatalf("unexpected bucket after sealing: %v (%v)", got, err)
+	}
+
+	_, err = server.UpdateBucket(ctx, connect.NewRequest(&storagev1.UpdateBucketRequest{Bucket: &storagev1.Bucket{Name: "ledger", DefaultKmsKeyName: testObjectKey}}))
+	if err != nil {
+		t.Fatalf("UpdateBucket failed: %v", err)
+	}
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "ledger", Name: "q1.csv", Data: []byte("revenue,42")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "ledger", Name: "both", Data: []byte("x"), KmsKeyName: testObjectKey, CommonObjectRequestParams: csek(1)})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected InvalidArgument for CSEK with KMS key, got %v", err)
+	}
+	if onDisk, _ := os.ReadFile(server.backend.(*fsBackend).path("ledger", "q1.csv")); bytes.Contains(onDisk, []byte("revenue")) {
+		t.Errorf("object stored unencrypted: %q", onDisk)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	if got := readAll(t, client, "ledger", "q1.csv"); got != "revenue,42" {
+		t.Errorf("unexpected read %q", got)
+	}
+
+	// Only server administrators rotate keys.
+	if _, err := server.RotateKmsKey(ctx, connect.NewRequest(&storagev1.RotateKmsKeyRequest{KmsKeyName: testObjectKey})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated for an anonymous rotation, got %v", err)
+	}
+	if _, err := server.RotateKmsKey(ctx, as(alice, connect.NewRequest(&storagev1.RotateKmsKeyRequest{KmsKeyName: testObjectKey}))); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated for an administrator named only by header, got %v", err)
+	}
+	if _, err := server.RotateKmsKey(WithPrincipal(ctx, "user:bob@example.com"), connect.NewRequest(&storagev1.RotateKmsKeyRequest{KmsKeyName: testMetadataKey})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied for an unprivileged rotation, got %v", err)
+	}
+	rotated, err := server.RotateKmsKey(WithPrincipal(ctx, alice), connect.NewRequest(&storagev1.RotateKmsKeyRequest{KmsKeyName: testObjectKey}))
+	if err != nil || rotated.Msg.PrimaryVersion != testObjectKey+"/cryptoKeyVersions/2" {
+		t.Fatalf("unexpected rotation: %v (%v)", rotated, err)
+	}
+	server.background.Wait()
+	meta, _ := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "ledger", Name: "q1.csv"}))
+	if meta.Msg.KmsKeyName != rotated.Msg.PrimaryVersion {
+		t.Errorf("object not re-encrypted: %s", meta.Msg.KmsKeyName)
+	}
+	if got := readAll(t, client, "ledger", "q1.csv"); got != "revenue,42" {
+		t.Errorf("unexpected read after rotation %q", got)
+	}
+
+	// Rotating the metadata key rewraps it; records stay readable on reopen.
+	if _, err := server.RotateKmsKey(WithPrincipal(ctx, alice), connect.NewRequest(&storagev1.RotateKmsKeyRequest{KmsKeyName: testMetadataKey})); err != nil {
+		t.Fatalf("RotateKmsKey failed: %v", err)
+	}
+	server.Close()
+	reopened := NewStorageServer(dir, WithKMS(NewKMSClient(kms.URL), testMetadataKey))
+	defer reopened.Close()
+	if _, err := reopened.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "ledger", Name: "q1.csv"})); err != nil {
+		t.Errorf("GetObjectMetadata after reopen failed: %v", err)
+	}
+}
+
+func TestSealedRecordsBoundToLocation(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	keyring, err := NewLocalKeyring(filepath.Join(t.TempDir(), "keyring.json"))
+	if err != nil {
+		t.Fatalf("NewLocalKeyring failed: %v", err)
+	}
+	kms := fakeCloudKMS(keyring)
+	defer kms.Close()
+	server := NewStorageServer(dir, WithKMS(NewKMSClient(kms.URL), testMetadataKey))
+	for _, name := range []string{"ledger", "petty"} {
+		if _, err := server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: name})); err != nil {
+			t.Fatalf("CreateBucket %s failed: %v", name, err)
+		}
+	}
+
+	// A sealed value copied under another key no longer opens.
+	err = server.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucketBuckets))
+		return b.Put([]byte("petty"), append([]byte(nil), b.Get([]byte("ledger"))...))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: "petty"})); err == nil {
+		t.Error("expected a record moved to another key to fail to open")
+	}
+
+	// Values sealed before records were bound are resealed on open.
+	data, _ := json.Marshal(bucketRecord{Name: "petty"})
+	nonce := make([]byte, server.recordSealer.NonceSize())
+	legacy := server.recordSealer.Seal(append(append([]byte(nil), unboundRecordPrefix...), nonce...), nonce, data, nil)
+	err = server.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte(bucketBuckets)).Put([]byte("petty"), legacy)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+	reopened := NewStorageServer(dir, WithKMS(NewKMSClient(kms.URL), testMetadataKey))
+	defer reopened.Close()
+	if _, err := reopened.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: "petty"})); err != nil {
+		t.Errorf("GetBucket of a resealed record failed: %v", err)
+	}
+	reopened.db.View(func(tx *bbolt.Tx) error {
+		if v := tx.Bucket([]byte(bucketBuckets)).Get([]byte("petty")); !bytes.HasPrefix(v, sealedRecordPrefix) {
+			t.Errorf("legacy record not resealed: %q", v)
+		}
+		return nil
+	})
+}
+
+func TestLocalKeyringSaveFailure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+	k, err := NewLocalKeyring(filepath.Join(dir, "keyring.json"))
+	if err != nil {
+		t.Fatalf("NewLocalKeyring failed: %v", err)
+	}
+	keyring := k.(*localKeyring)
+	primary, err := keyring.PrimaryVersion(ctx, testObjectKey)
+	if err != nil {
+		t.Fatalf("PrimaryVersion failed: %v", err)
+	}
+
+	// Keys the file could not take are neither created nor rotated.
+	if err := os.WriteFile(filepath.Join(dir, "file"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	keyring.path = filepath.Join(dir, "file", "keyring.json")
+	if _, _, err := keyring.Encrypt(ctx, testMetadataKey, []byte("x")); err == nil {
+		t.Error("expected Encrypt to fail creating a key it cannot save")
+	}
+	if _, ok := keyring.keys[testMetadataKey]; ok {
+		t.Error("unsaved key was kept")
+	}
+	if _, err := keyring.Rotate(ctx, testObjectKey); err == nil {
+		t.Error("expected Rotate to fail when the keyring cannot be saved")
+	}
+	if got, err := keyring.PrimaryVersion(ctx, testObjectKey); err != nil || got != primary {
+		t.Errorf("expected primary %s after the failed rotation, got %s (%v)", primary, got, err)
+	}
+}
@@ -0,0 +1,488 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"gopkg.in/yaml.v3"
+)
+
+// FaultRule makes the StorageService calls it matches misbehave. Procedure
+// (an RPC name such as "ReadObject"), Bucket and Object (a path.Match glob)
+// narrow the calls; empty ones match all. A matching call is affected with
+// Probability, zero meaning always, until the rule has fired Count times,
+// zero meaning forever.
+type FaultRule struct {
+	Procedure   string  `yaml:"procedure,omitempty"`
+	Bucket      string  `yaml:"bucket,omitempty"`
+	Object      string  `yaml:"object,omitempty"`
+	Probability float64 `yaml:"probability,omitempty"`
+	Count       int64   `yaml:"count,omitempty"`
+
+	// Code, a Connect code name such as "unavailable", fails the call
+	// before it takes effect, or ends a stream cut by TruncateAfterBytes.
+	Code    string `yaml:"code,omitempty"`
+	Message string `yaml:"message,omitempty"`
+	// Latency delays the call.
+	Latency time.Duration `yaml:"latency,omitempty"`
+	// TruncateAfterBytes ends a stream once it has carried this much
+	// object or archive data.
+	TruncateAfterBytes int64 `yaml:"truncateAfterBytes,omitempty"`
+	// BytesPerSecond paces the data of streamed messages.
+	BytesPerSecond int64 `yaml:"bytesPerSecond,omitempty"`
+
+	code connect.Code
+	// reset drops the connection instead of failing with code.
+	reset bool
+}
+
+// FaultConfig configures a FaultInjector.
+type FaultConfig struct {
+	Rules []FaultRule `yaml:"rules"`
+}
+
+// LoadFaultConfig reads a fault rule file, YAML or JSON.
+func LoadFaultConfig(path string) (FaultConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FaultConfig{}, fmt.Errorf("failed to read fault file: %v", err)
+	}
+	cfg, err := ParseFaultConfig(data)
+	if err != nil {
+		return FaultConfig{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// ParseFaultConfig parses YAML or JSON fault rules. Latencies are durations
+// such as "250ms"; unknown fields are errors.
+func ParseFaultConfig(data []byte) (FaultConfig, error) {
+	var cfg FaultConfig
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return FaultConfig{}, fmt.Errorf("invalid fault rules: %v", err)
+	}
+	if err := cfg.validate(); err != nil {
+		return FaultConfig{}, fmt.Errorf("invalid fault rules: %v", err)
+	}
+	return cfg, nil
+}
+
+func (c *FaultConfig) validate() error {
+	for i := range c.Rules {
+		r := &c.Rules[i]
+		if _, err := path.Match(r.Object, ""); err != nil {
+			return fmt.Errorf("rule %d: bad object glob %q", i, r.Object)
+		}
+		switch {
+		case r.Probability < 0 || r.Probability > 1:
+			return fmt.Errorf("rule %d: probability %g is not between 0 and 1", i, r.Probability)
+		case r.Count < 0 || r.Latency < 0 || r.TruncateAfterBytes < 0 || r.BytesPerSecond < 0:
+			return fmt.Errorf("rule %d: negative count, latency, truncation or rate", i)
+		}
+		r.code = 0
+		if r.Code != "" {
+			if err := r.code.UnmarshalText([]byte(r.Code)); err != nil {
+				return fmt.Errorf("rule %d: unknown code %q", i, r.Code)
+			}
+		}
+		if r.code == 0 && r.Latency == 0 && r.TruncateAfterBytes == 0 && r.BytesPerSecond == 0 {
+			return fmt.Errorf("rule %d injects nothing", i)
+		}
+	}
+	return nil
+}
+
+func (r *FaultRule) matches(procedure, bucket, object string) bool {
+	if r.Procedure != "" && r.Procedure != path.Base(procedure) {
+		return false
+	}
+	if r.Bucket != "" && r.Bucket != bucket {
+		return false
+	}
+	if r.Object == "" {
+		return true
+	}
+	ok, _ := path.Match(r.Object, object)
+	return ok && object != ""
+}
+
+// fails reports whether the rule ends the calls it fires on.
+func (r *FaultRule) fails() bool {
+	return r.code != 0 || r.reset
+}
+
+// failure is the error the rule injects. A rule that resets the connection
+// aborts the handler instead, which net/http answers by dropping it.
+func (r *FaultRule) failure() error {
+	if r.reset {
+		panic(http.ErrAbortHandler)
+	}
+	code := r.code
+	if code == 0 {
+		code = connect.CodeUnavailable
+	}
+	msg := r.Message
+	if msg == "" {
+		msg = "injected fault"
+	}
+	return connect.NewError(code, errors.New(msg))
+}
+
+// pace waits as long as n bytes take at the rule's rate.
+func (r *FaultRule) pace(ctx context.Context, n int) error {
+	if r.BytesPerSecond == 0 || n == 0 {
+		return nil
+	}
+	return sleep(ctx, time.Duration(float64(n)/float64(r.BytesPerSecond)*float64(time.Second)))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return connect.NewError(connect.CodeCanceled, ctx.Err())
+	}
+}
+
+func (r *FaultRule) toProto(fired int64) *storagev1.FaultRule {
+	p := &storagev1.FaultRule{
+		Procedure:          r.Procedure,
+		Bucket:             r.Bucket,
+		Object:             r.Object,
+		Probability:        r.Probability,
+		Count:              r.Count,
+		Code:               r.Code,
+		Message:            r.Message,
+		TruncateAfterBytes: r.TruncateAfterBytes,
+		BytesPerSecond:     r.BytesPerSecond,
+		Fired:              fired,
+	}
+	if r.Latency > 0 {
+		p.Latency = durationpb.New(r.Latency)
+	}
+	return p
+}
+
+func faultRuleFromProto(p *storagev1.FaultRule) FaultRule {
+	return FaultRule{
+		Procedure:          p.Procedure,
+		Bucket:             p.Bucket,
+		Object:             p.Object,
+		Probability:        p.Probability,
+		Count:              p.Count,
+		Code:               p.Code,
+		Message:            p.Message,
+		Latency:            p.Latency.AsDuration(),
+		TruncateAfterBytes: p.TruncateAfterBytes,
+		BytesPerSecond:     p.BytesPerSecond,
+	}
+}
+
+// faultExempt are the procedures that manage faults, which never suffer
+// them.
+var faultExempt = map[string]bool{
+	storagev1connect.StorageServiceSetFaultRulesProcedure:  true,
+	storagev1connect.StorageServiceListFaultRulesProcedure: true,
+}
+
+// FaultInjector is a Connect interceptor that fails, delays, truncates and
+// slows StorageService calls as its rules say. Install it last, so that the
+// other interceptors see injected failures as clients do, and pass it to
+// the server with WithFaultInjector so SetFaultRules can change it.
+type FaultInjector struct {
+	mu    sync.Mutex
+	rules []FaultRule
+	fired []int64
+	rand  func() float64
+}
+
+func NewFaultInjector(cfg FaultConfig) (*FaultInjector, error) {
+	f := &FaultInjector{rand: rand.Float64}
+	if err := f.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Reload replaces the rules, restarting their counts.
+func (f *FaultInjector) Reload(cfg FaultConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = cfg.Rules
+	f.fired = make([]int64, len(cfg.Rules))
+	slog.Info("Fault rules loaded", "rules", len(cfg.Rules))
+	return nil
+}
+
+// faultPicker returns the rule that fires for a call, if any.
+type faultPicker func(header http.Header, procedure, bucket, object string) *FaultRule
+
+// pick returns the rule that fires for a call, if any, counting it.
+func (f *FaultInjector) pick(_ http.Header, procedure, bucket, object string) *FaultRule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.rules {
+		r := &f.rules[i]
+		if !r.matches(procedure, bucket, object) || (r.Count > 0 && f.fired[i] >= r.Count) {
+			continue
+		}
+		if r.Probability > 0 && f.rand() >= r.Probability {
+			continue
+		}
+		f.fired[i]++
+		slog.Warn("Injecting fault", "procedure", procedure, "bucket", bucket, "object", object, "rule", i)
+		rule := *r
+		return &rule
+	}
+	return nil
+}
+
+// requestObject names the object a request addresses, if any.
+func requestObject(msg any) string {
+	switch m := msg.(type) {
+	case *storagev1.WriteObjectRequest:
+		return m.GetSpec().GetName()
+	case *storagev1.CreateBucketRequest, *storagev1.GetBucketRequest:
+		return ""
+	case interface{ GetDestinationObject() string }:
+		return m.GetDestinationObject()
+	case interface{ GetName() string }:
+		return m.GetName()
+	case interface{ GetObject() string }:
+		return m.GetObject()
+	}
+	return ""
+}
+
+// messageData is the object or archive data a message carries.
+func messageData(msg any) []byte {
+	if m, ok := msg.(interface{ GetData() []byte }); ok {
+		return m.GetData()
+	}
+	return nil
+}
+
+// withData returns a copy of msg carrying data instead of its own.
+func withData(msg any, data []byte) any {
+	m := proto.Clone(msg.(proto.Message))
+	r := m.ProtoReflect()
+	r.Set(r.Descriptor().Fields().ByName("data"), protoreflect.ValueOfBytes(data))
+	return m
+}
+
+func (f *FaultInjector) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return injectUnary(f.pick, next)
+}
+
+func (f *FaultInjector) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (f *FaultInjector) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return injectStreaming(f.pick, next)
+}
+
+// injectUnary applies the fault pick chooses to unary calls.
+func injectUnary(pick faultPicker, next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if req.Spec().IsClient || faultExempt[procedure] {
+			return next(ctx, req)
+		}
+		rule := pick(req.Header(), procedure, requestBucket(req.Any()), requestObject(req.Any()))
+		if rule == nil {
+			return next(ctx, req)
+		}
+		if err := sleep(ctx, rule.Latency); err != nil {
+			return nil, err
+		}
+		if rule.fails() {
+			return nil, rule.failure()
+		}
+		if err := rule.pace(ctx, len(messageData(req.Any()))); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// injectStreaming applies the fault pick chooses to streams, once their
+// first request message, which names the bucket and object, arrives.
+func injectStreaming(pick faultPicker, next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		c := &faultyConn{StreamingHandlerConn: conn, ctx: ctx, pick: pick}
+		err := next(ctx, c)
+		// The handler may wrap or replace the error it saw.
+		if c.fault != nil {
+			return c.fault
+		}
+		return err
+	}
+}
+
+type faultyConn struct {
+	connect.StreamingHandlerConn
+	ctx  context.Context
+	pick faultPicker
+
+	picked bool
+	rule   *FaultRule
+	// carried counts the data received and sent so far.
+	carried int64
+	fault   error
+}
+
+// carry accounts for a message's data, pacing it, and reports how much of
+// it fits before the stream is cut, or -1 if all of it does.
+func (c *faultyConn) carry(data []byte) (int, error) {
+	if err := c.rule.pace(c.ctx, len(data)); err != nil {
+		return 0, err
+	}
+	limit := c.rule.TruncateAfterBytes
+	if limit == 0 || c.carried+int64(len(data)) <= limit {
+		c.carried += int64(len(data))
+		return -1, nil
+	}
+	fits := int(limit - c.carried)
+	c.carried = limit
+	return fits, nil
+}
+
+func (c *faultyConn) Receive(msg any) error {
+	if c.fault != nil {
+		return c.fault
+	}
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	if !c.picked {
+		c.picked = true
+		c.rule = c.pick(c.RequestHeader(), c.Spec().Procedure, requestBucket(msg), requestObject(msg))
+		if c.rule != nil {
+			if err := sleep(c.ctx, c.rule.Latency); err != nil {
+				return err
+			}
+			if c.rule.fails() && c.rule.TruncateAfterBytes == 0 {
+				c.fault = c.rule.failure()
+				return c.fault
+			}
+		}
+	}
+	if c.rule == nil {
+		return nil
+	}
+	fits, err := c.carry(messageData(msg))
+	if err != nil {
+		return err
+	}
+	if fits >= 0 {
+		c.fault = c.rule.failure()
+		return c.fault
+	}
+	return nil
+}
+
+func (c *faultyConn) Send(msg any) error {
+	if c.fault != nil {
+		return c.fault
+	}
+	if c.rule == nil {
+		return c.StreamingHandlerConn.Send(msg)
+	}
+	data := messageData(msg)
+	fits, err := c.carry(data)
+	if err != nil {
+		return err
+	}
+	if fits < 0 {
+		return c.StreamingHandlerConn.Send(msg)
+	}
+	if fits > 0 {
+		if err := c.StreamingHandlerConn.Send(withData(msg, data[:fits])); err != nil {
+			return err
+		}
+	}
+	c.fault = c.rule.failure()
+	return c.fault
+}
+
+// WithFaultInjector lets SetFaultRules and ListFaultRules manage f, which
+// must also be installed as an interceptor. Without it they fail with
+// FailedPrecondition; with it they are open to the server administrators.
+func WithFaultInjector(f *FaultInjector) Option {
+	return func(s *StorageServer) {
+		s.faults = f
+	}
+}
+
+func faultsDisabled() error {
+	return connect.NewError(connect.CodeFailedPrecondition, errors.New("fault injection is not enabled"))
+}
+
+func (s *StorageServer) SetFaultRules(ctx context.Context, req *connect.Request[storagev1.SetFaultRulesRequest]) (*connect.Response[storagev1.SetFaultRulesResponse], error) {
+	slog.Info("SetFaultRules", "rules", len(req.Msg.Rules), "principal", principalOf(ctx, req.Header()))
+	if s.faults == nil {
+		return nil, faultsDisabled()
+	}
+	if err := s.authorizeAdmin(ctx, req.Header(), "manage fault rules"); err != nil {
+		return nil, err
+	}
+	var cfg FaultConfig
+	for _, r := range req.Msg.Rules {
+		cfg.Rules = append(cfg.Rules, faultRuleFromProto(r))
+	}
+	if err := s.faults.Reload(cfg); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	resp := &storagev1.SetFaultRulesResponse{}
+	for _, r := range cfg.Rules {
+		resp.Rules = append(resp.Rules, r.toProto(0))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *StorageServer) ListFaultRules(ctx context.Context, req *connect.Request[storagev1.ListFaultRulesRequest]) (*connect.Response[storagev1.ListFaultRulesResponse], error) {
+	slog.Info("ListFaultRules", "principal", principalOf(ctx, req.Header()))
+	if s.faults == nil {
+		return nil, faultsDisabled()
+	}
+	if err := s.authorizeAdmin(ctx, req.Header(), "list fault rules"); err != nil {
+		return nil, err
+	}
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	resp := &storagev1.ListFaultRulesResponse{}
+	for i := range s.faults.rules {
+		resp.Rules = append(resp.Rules, s.faults.rules[i].toProto(s.faults.fired[i]))
+	}
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,192 @@
+package inference
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestParseFaultConfig(t *testing.T) {
+	t.Parallel()
+	cfg, err := ParseFaultConfig([]byte(`
+rules:
+  - procedure: ReadObject
+    bucket: media
+    object: "videos/*.mp4"
+    probability: 0.25
+    latency: 250ms
+    bytesPerSecond: 1024
+  - procedure: UploadObject
+    code: resource_exhausted
+    count: 3
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Rules) != 2 || cfg.Rules[0].Latency != 250*time.Millisecond || cfg.Rules[1].code != connect.CodeResourceExhausted {
+		t.Errorf("unexpected rules %+v", cfg.Rules)
+	}
+	if cfg, err := ParseFaultConfig([]byte(`{"rules": [{"truncateAfterBytes": 10, "latency": "1s"}]}`)); err != nil || cfg.Rules[0].Latency != time.Second {
+		t.Errorf("unexpected JSON rules %+v (%v)", cfg, err)
+	}
+	for _, bad := range []struct{ rules, want string }{
+		{`rules: [{code: broken}]`, "unknown code"},
+		{`rules: [{code: internal, probability: 2}]`, "between 0 and 1"},
+		{`rules: [{procedure: ReadObject}]`, "injects nothing"},
+		{`rules: [{code: internal, object: "["}]`, "bad object glob"},
+		{`rules: [{code: internal, delay: 1s}]`, "not found"},
+	} {
+		if _, err := ParseFaultConfig([]byte(bad.rules)); err == nil || !strings.Contains(err.Error(), bad.want) {
+			t.Errorf("%s: expected %q, got %v", bad.rules, bad.want, err)
+		}
+	}
+}
+
+func TestFaultProbability(t *testing.T) {
+	t.Parallel()
+	f, err := NewFaultInjector(FaultConfig{Rules: []FaultRule{{Code: "internal", Probability: 0.5}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rolls := []float64{0.7, 0.2}
+	f.rand = func() float64 {
+		r := rolls[0]
+		rolls = rolls[1:]
+		return r
+	}
+	if f.pick(nil, "/storage.v1.StorageService/GetBucket", "b", "") != nil {
+		t.Error("rule fired on a roll above its probability")
+	}
+	if f.pick(nil, "/storage.v1.StorageService/GetBucket", "b", "") == nil {
+		t.Error("rule did not fire on a roll below its probability")
+	}
+}
+
+// bearer authenticates req with token, which the test's AuthInterceptor maps
+// to a principal.
+func bearer[T any](token string, req *connect.Request[T]) *connect.Request[T] {
+	req.Header().Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestFaultInjector(t *testing.T) {
+	t.Parallel()
+	faults, err := NewFaultInjector(FaultConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewStorageServer("", WithInMemory(), WithFaultInjector(faults), WithAdmins(alice))
+	defer server.Close()
+	auth, err := NewAuthInterceptor(AuthConfig{Dev: true})
+	if err != nil {
+		t.Fatalf("NewAuthInterceptor failed: %v", err)
+	}
+	auth.tokens = map[string]string{"alice": alice, "bob": "user:bob@example.com"}
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(auth, faults)))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	ctx := context.Background()
+
+	setRules := func(rules ...*storagev1.FaultRule) {
+		t.Helper()
+		if _, err := client.SetFaultRules(ctx, bearer("alice", connect.NewRequest(&storagev1.SetFaultRulesRequest{Rules: rules}))); err != nil {
+			t.Fatalf("SetFaultRules failed: %v", err)
+		}
+	}
+	upload := func(name string) error {
+		_, err := client.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: name, Data: []byte("0123456789")}))
+		return err
+	}
+	read := func(name string) (string, error) {
+		stream, err := client.ReadObject(ctx, connect.NewRequest(&storagev1.ReadObjectRequest{Bucket: "b", Name: name}))
+		if err != nil {
+			return "", err
+		}
+		data, err := receiveAll(stream)
+		return string(data), err
+	}
+	if _, err := client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"})); err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	// The administrator owning the anonymously created bucket opens it up.
+	open := &storagev1.Policy{Bindings: []*storagev1.Binding{{Role: "roles/storage.admin", Members: []string{memberAllUsers}}}}
+	if _, err := client.SetIamPolicy(ctx, as(alice, connect.NewRequest(&storagev1.SetIamPolicyRequest{Bucket: "b", Policy: open}))); err != nil {
+		t.Fatalf("SetIamPolicy failed: %v", err)
+	}
+
+	// A counted error fails matching uploads before they take effect.
+	setRules(&storagev1.FaultRule{Procedure: "UploadObject", Bucket: "b", Object: "flaky/*", Code: "unavailable", Message: "disk on fire", Count: 2})
+	for range 2 {
+		if err := upload("flaky/x"); connect.CodeOf(err) != connect.CodeUnavailable || !strings.Contains(err.Error(), "disk on fire") {
+			t.Errorf("expected the injected failure, got %v", err)
+		}
+	}
+	if _, err := read("flaky/x"); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("a failed upload must not store the object, got %v", err)
+	}
+	if err := upload("flaky/x"); err != nil {
+		t.Errorf("expected the rule to lapse after its count, got %v", err)
+	}
+	if err := upload("steady"); err != nil {
+		t.Errorf("unmatched upload failed: %v", err)
+	}
+	listed, err := client.ListFaultRules(ctx, bearer("alice", connect.NewRequest(&storagev1.ListFaultRulesRequest{})))
+	if err != nil || len(listed.Msg.Rules) != 1 || listed.Msg.Rules[0].Fired != 2 {
+		t.Errorf("unexpected rules %v (%v)", listed, err)
+	}
+
+	// Streams are cut after the given bytes, with the code given.
+	setRules(&storagev1.FaultRule{Procedure: "ReadObject", TruncateAfterBytes: 4})
+	if data, err := read("steady"); data != "0123" || connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Errorf("expected a truncated read, got %q (%v)", data, err)
+	}
+	setRules(&storagev1.FaultRule{Procedure: "WriteObject", TruncateAfterBytes: 2, Code: "data_loss"})
+	write := client.WriteObject(ctx)
+	write.Send(&storagev1.WriteObjectRequest{Spec: &storagev1.UploadObjectRequest{Bucket: "b", Name: "cut"}, Data: []byte("abc")})
+	if _, err := write.CloseAndReceive(); connect.CodeOf(err) != connect.CodeDataLoss {
+		t.Errorf("expected a truncated upload, got %v", err)
+	}
+
+	// Latency delays calls and a byte rate paces streamed data.
+	setRules(
+		&storagev1.FaultRule{Procedure: "GetObjectMetadata", Latency: durationpb.New(50 * time.Millisecond)},
+		&storagev1.FaultRule{Procedure: "ReadObject", BytesPerSecond: 100},
+	)
+	start := time.Now()
+	if _, err := client.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "b", Name: "steady"})); err != nil || time.Since(start) < 50*time.Millisecond {
+		t.Errorf("expected a delayed call, got %v after %v", err, time.Since(start))
+	}
+	start = time.Now()
+	if data, err := read("steady"); data != "0123456789" || err != nil || time.Since(start) < 100*time.Millisecond {
+		t.Errorf("expected a paced read, got %q (%v) after %v", data, err, time.Since(start))
+	}
+
+	if _, err := client.SetFaultRules(ctx, bearer("alice", connect.NewRequest(&storagev1.SetFaultRulesRequest{Rules: []*storagev1.FaultRule{{Code: "nope"}}}))); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected InvalidArgument for a bad rule, got %v", err)
+	}
+	// Only server administrators manage faults.
+	if _, err := client.SetFaultRules(ctx, connect.NewRequest(&storagev1.SetFaultRulesRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated for an anonymous caller, got %v", err)
+	}
+	if _, err := client.SetFaultRules(ctx, as(alice, connect.NewRequest(&storagev1.SetFaultRulesRequest{}))); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated for an administrator named only by header, got %v", err)
+	}
+	if _, err := client.ListFaultRules(ctx, bearer("bob", connect.NewRequest(&storagev1.ListFaultRulesRequest{}))); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied for an unprivileged caller, got %v", err)
+	}
+	plain := NewStorageServer("", WithInMemory())
+	defer plain.Close()
+	if _, err := plain.ListFaultRules(ctx, connect.NewRequest(&storagev1.ListFaultRulesRequest{})); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Errorf("expected FailedPrecondition without fault injection, got %v", err)
+	}
+}
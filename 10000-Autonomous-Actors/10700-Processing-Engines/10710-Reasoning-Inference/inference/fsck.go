@@ -0,0 +1,344 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"log/slog"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+// quarantineBucket is the backend bucket Fsck moves orphaned data into,
+// named "bucket/name" after where it was found.
+const quarantineBucket = ".quarantine"
+
+// FsckIssueKind classifies an inconsistency found by Fsck.
+type FsckIssueKind string
+
+const (
+	// FsckOrphanedFile is data stored under an object name with no record.
+	FsckOrphanedFile FsckIssueKind = "orphaned_file"
+	// FsckDanglingRecord is a record whose data is missing.
+	FsckDanglingRecord FsckIssueKind = "dangling_record"
+	// FsckChecksumMismatch is stored data that no longer matches its
+	// checksum. It is only reported: the stored bytes are all there is.
+	FsckChecksumMismatch FsckIssueKind = "checksum_mismatch"
+	// FsckTempFile is a temporary file left by an interrupted write.
+	FsckTempFile FsckIssueKind = "temp_file"
+)
+
+// Orphaned file repairs.
+const (
+	// OrphansAdopt gives orphaned data a record, making it an object.
+	OrphansAdopt = "adopt"
+	// OrphansQuarantine moves orphaned data into the ".quarantine" bucket.
+	OrphansQuarantine = "quarantine"
+)
+
+// FsckOptions selects what Fsck checks and repairs. The zero value checks
+// everything but checksums and repairs nothing.
+type FsckOptions struct {
+	// VerifyChecksums reads all stored data to compare it with its
+	// checksum.
+	VerifyChecksums bool
+	// Orphans is OrphansAdopt, OrphansQuarantine, or empty to leave
+	// orphaned files in place.
+	Orphans string
+	// DropDangling deletes records whose data is missing.
+	DropDangling bool
+	// RemoveTempFiles deletes temporary files left by interrupted writes.
+	RemoveTempFiles bool
+}
+
+// FsckIssue is one inconsistency, with the repair made if any.
+type FsckIssue struct {
+	Kind   FsckIssueKind `json:"kind"`
+	Bucket string        `json:"bucket,omitempty"`
+	Name   string        `json:"name,omitempty"`
+	Detail string        `json:"detail,omitempty"`
+	// Fixed names the repair: "adopted", "quarantined", "dropped" or
+	// "removed".
+	Fixed string `json:"fixed,omitempty"`
+}
+
+// FsckResult reports a consistency check.
+type FsckResult struct {
+	Buckets int         `json:"buckets"`
+	Objects int         `json:"objects"`
+	Issues  []FsckIssue `json:"issues"`
+}
+
+// Unfixed counts the issues left in place.
+func (r *FsckResult) Unfixed() int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Fixed == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Fsck checks that the object index and the backend agree: every record has
+// its data, all data has a record, checksums match and no temporary files
+// are left over. It is meant to run before the server takes requests, as
+// StorageManager and storage-admin do. Repairs are made in one transaction
+// that first confirms each issue still stands.
+func (s *StorageServer) Fsck(ctx context.Context, opts FsckOptions) (*FsckResult, error) {
+	if opts.Orphans != "" && opts.Orphans != OrphansAdopt && opts.Orphans != OrphansQuarantine {
+		return nil, fmt.Errorf("unknown orphan repair %q: use %q or %q", opts.Orphans, OrphansAdopt, OrphansQuarantine)
+	}
+	res := &FsckResult{Issues: []FsckIssue{}}
+	var buckets []string
+	var records []*objectRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		err := tx.Bucket([]byte(bucketBuckets)).ForEach(func(k, _ []byte) error {
+			buckets = append(buckets, string(k))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return s.forEachObject(tx, func(rec *objectRecord) error {
+			records = append(records, rec)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Buckets = len(buckets)
+	res.Objects = len(records)
+
+	var dangling, orphans []*FsckIssue
+	blobs := map[string]error{}
+	for _, rec := range records {
+		kind, detail, err := s.checkObjectData(ctx, rec, opts.VerifyChecksums, blobs)
+		if err != nil {
+			return nil, err
+		}
+		if kind != "" {
+			res.Issues = append(res.Issues, FsckIssue{Kind: kind, Bucket: rec.Bucket, Name: rec.Name, Detail: detail})
+		}
+	}
+	for _, bucket := range buckets {
+		names, err := s.backend.List(ctx, bucket, "")
+		if err != nil {
+			return nil, err
+		}
+		err = s.view(ctx, func(tx *bbolt.Tx) error {
+			for _, name := range names {
+				_, found, err := s.getObjectRecord(tx, bucket, name)
+				if err != nil {
+					return err
+				}
+				if !found {
+					res.Issues = append(res.Issues, FsckIssue{Kind: FsckOrphanedFile, Bucket: bucket, Name: name})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	tb, _ := s.backend.(tempFileBackend)
+	if tb != nil {
+		paths, err := tb.TempFiles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// Objects stored under staged names before those were reserved are
+		// not temporary files.
+		err = s.view(ctx, func(tx *bbolt.Tx) error {
+			for _, path := range paths {
+				bucket, name, _ := strings.Cut(path, "/")
+				_, found, err := s.getObjectRecord(tx, bucket, name)
+				if err != nil {
+					return err
+				}
+				if !found {
+					res.Issues = append(res.Issues, FsckIssue{Kind: FsckTempFile, Name: path})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range res.Issues {
+		switch res.Issues[i].Kind {
+		case FsckDanglingRecord:
+			dangling = append(dangling, &res.Issues[i])
+		case FsckOrphanedFile:
+			orphans = append(orphans, &res.Issues[i])
+		}
+	}
+
+	if (opts.DropDangling && len(dangling) > 0) || (opts.Orphans != "" && len(orphans) > 0) {
+		err = s.update(ctx, func(tx *bbolt.Tx) error {
+			if opts.DropDangling {
+				for _, issue := range dangling {
+					if err := s.dropDangling(ctx, tx, issue); err != nil {
+						return err
+					}
+				}
+			}
+			if opts.Orphans != "" {
+				for _, issue := range orphans {
+					if err := s.repairOrphan(ctx, tx, issue, opts.Orphans); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.RemoveTempFiles && tb != nil {
+		for i, issue := range res.Issues {
+			if issue.Kind != FsckTempFile {
+				continue
+			}
+			if err := tb.RemoveTempFile(ctx, issue.Name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+			res.Issues[i].Fixed = "removed"
+		}
+	}
+	for _, issue := range res.Issues {
+		slog.Warn("Fsck issue", "kind", issue.Kind, "bucket", issue.Bucket, "name", issue.Name, "detail", issue.Detail, "fixed", issue.Fixed)
+	}
+	return res, nil
+}
+
+// checkObjectData reports whether the data of rec is missing or, when
+// verifying, corrupt. Blob results are cached in blobs, as blobs are shared.
+func (s *StorageServer) checkObjectData(ctx context.Context, rec *objectRecord, verify bool, blobs map[string]error) (FsckIssueKind, string, error) {
+	if !rec.contentAddressed() {
+		_, err := s.backend.Stat(ctx, rec.Bucket, rec.Name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return FsckDanglingRecord, "data is missing", nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if !verify || rec.StoredCRC32C == nil {
+			return "", "", nil
+		}
+		data, err := s.readStoredData(ctx, rec)
+		if err != nil {
+			return "", "", err
+		}
+		if sum := crc32.Checksum(data, crc32c); sum != *rec.StoredCRC32C {
+			return FsckChecksumMismatch, fmt.Sprintf("crc32c is %08x, recorded %08x", sum, *rec.StoredCRC32C), nil
+		}
+		return "", "", nil
+	}
+	for _, ref := range rec.Blobs {
+		err, checked := blobs[ref.Hash]
+		if !checked {
+			err = s.checkBlob(ctx, ref, verify)
+			blobs[ref.Hash] = err
+		}
+		var mismatch *blobMismatchError
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return FsckDanglingRecord, fmt.Sprintf("blob %s is missing", ref.Hash), nil
+		case errors.As(err, &mismatch):
+			return FsckChecksumMismatch, mismatch.Error(), nil
+		case err != nil:
+			return "", "", err
+		}
+	}
+	return "", "", nil
+}
+
+type blobMismatchError struct {
+	hash, sum string
+}
+
+func (e *blobMismatchError) Error() string {
+	return fmt.Sprintf("blob %s hashes to %s", e.hash, e.sum)
+}
+
+func (s *StorageServer) checkBlob(ctx context.Context, ref blobRef, verify bool) error {
+	if !verify {
+		_, err := s.backend.Stat(ctx, blobBucket, blobName(ref.Hash))
+		return err
+	}
+	r, err := s.backend.Get(ctx, blobBucket, blobName(ref.Hash), 0, -1)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != ref.Hash {
+		return &blobMismatchError{hash: ref.Hash, sum: sum}
+	}
+	return nil
+}
+
+// dropDangling deletes the record of issue if its data is still missing.
+func (s *StorageServer) dropDangling(ctx context.Context, tx *bbolt.Tx, issue *FsckIssue) error {
+	rec, found, err := s.getObjectRecord(tx, issue.Bucket, issue.Name)
+	if err != nil || !found {
+		return err
+	}
+	kind, _, err := s.checkObjectData(ctx, rec, false, map[string]error{})
+	if err != nil || kind != FsckDanglingRecord {
+		return err
+	}
+	if err := s.deleteObjectRecord(tx, rec.Bucket, rec.Name); err != nil {
+		return err
+	}
+	issue.Fixed = "dropped"
+	return nil
+}
+
+// repairOrphan adopts or quarantines the data of issue if it still has no
+// record.
+func (s *StorageServer) repairOrphan(ctx context.Context, tx *bbolt.Tx, issue *FsckIssue, action string) error {
+	if _, found, err := s.getObjectRecord(tx, issue.Bucket, issue.Name); err != nil || found {
+		return err
+	}
+	if action == OrphansAdopt {
+		rec, err := s.adoptObject(ctx, issue.Bucket, issue.Name, nil)
+		if err != nil {
+			return err
+		}
+		if err := s.putObjectRecord(tx, rec); err != nil {
+			return err
+		}
+		issue.Fixed = "adopted"
+		return nil
+	}
+	data, err := s.readStoredData(ctx, &objectRecord{Bucket: issue.Bucket, Name: issue.Name})
+	if err != nil {
+		return err
+	}
+	if err := s.backend.Put(ctx, quarantineBucket, objectKey(issue.Bucket, issue.Name), data); err != nil {
+		return err
+	}
+	if err := s.backend.Delete(ctx, issue.Bucket, issue.Name); err != nil {
+		return err
+	}
+	issue.Detail = "moved to " + objectKey(quarantineBucket, objectKey(issue.Bucket, issue.Name))
+	issue.Fixed = "quarantined"
+	return nil
+}
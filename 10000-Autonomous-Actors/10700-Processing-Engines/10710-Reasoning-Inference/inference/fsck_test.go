@@ -0,0 +1,163 @@
+package inference
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+// issueSummary renders issues as "kind bucket/name fixed" for comparison.
+func issueSummary(res *FsckResult) []string {
+	var out []string
+	for _, issue := range res.Issues {
+		out = append(out, string(issue.Kind)+" "+objectKey(issue.Bucket, issue.Name)+" "+issue.Fixed)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func TestFsck(t *testing.T) {
+	dir := t.TempDir()
+	server := NewStorageServer(dir)
+	defer server.Close()
+	ctx := context.Background()
+	fsck := func(opts FsckOptions) *FsckResult {
+		t.Helper()
+		res, err := server.Fsck(ctx, opts)
+		if err != nil {
+			t.Fatalf("Fsck failed: %v", err)
+		}
+		return res
+	}
+
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	for _, name := range []string{"keep", "gone", "corrupt"} {
+		if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: name, Data: []byte("payload")})); err != nil {
+			t.Fatalf("UploadObject %s failed: %v", name, err)
+		}
+	}
+	os.Remove(filepath.Join(dir, "b", "gone"))
+	os.WriteFile(filepath.Join(dir, "b", "corrupt"), []byte("paYload"), 0644)
+	os.WriteFile(filepath.Join(dir, "b", "stray"), []byte("crash leftover"), 0644)
+	os.WriteFile(filepath.Join(dir, "b", stagedPrefix+"123"), []byte("partial"), 0644)
+
+	if got := issueSummary(fsck(FsckOptions{})); !slices.Equal(got, []string{
+		"dangling_record b/gone ",
+		"orphaned_file b/stray ",
+		"temp_file /b/" + stagedPrefix + "123 ",
+	}) {
+		t.Errorf("unexpected issues without checksums: %q", got)
+	}
+
+	res := fsck(FsckOptions{VerifyChecksums: true, Orphans: OrphansQuarantine, DropDangling: true, RemoveTempFiles: true})
+	if got := issueSummary(res); !slices.Equal(got, []string{
+		"checksum_mismatch b/corrupt ",
+		"dangling_record b/gone dropped",
+		"orphaned_file b/stray quarantined",
+		"temp_file /b/" + stagedPrefix + "123 removed",
+	}) {
+		t.Errorf("unexpected repairs: %q", got)
+	}
+	if res.Buckets != 1 || res.Objects != 3 || res.Unfixed() != 1 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, quarantineBucket, "b", "stray")); err != nil || string(data) != "crash leftover" {
+		t.Errorf("orphan not quarantined: %q (%v)", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b", stagedPrefix+"123")); !os.IsNotExist(err) {
+		t.Errorf("temporary file not removed: %v", err)
+	}
+	if _, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "b", Name: "gone"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("dangling record not dropped: %v", err)
+	}
+	usage, err := server.GetStorageUsage(ctx, connect.NewRequest(&storagev1.GetStorageUsageRequest{Bucket: "b"}))
+	if err != nil || usage.Msg.Objects != 2 {
+		t.Errorf("usage not updated by the repair: %v (%v)", usage, err)
+	}
+	if got := issueSummary(fsck(FsckOptions{VerifyChecksums: true})); !slices.Equal(got, []string{"checksum_mismatch b/corrupt "}) {
+		t.Errorf("unexpected issues after repair: %q", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, "b", "found"), []byte("keep me"), 0644)
+	fsck(FsckOptions{Orphans: OrphansAdopt})
+	meta, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "b", Name: "found"}))
+	if err != nil || meta.Msg.Size != 7 {
+		t.Errorf("orphan not adopted: %v (%v)", meta, err)
+	}
+	if _, err := server.Fsck(ctx, FsckOptions{Orphans: "delete"}); err == nil {
+		t.Error("expected an unknown orphan repair to be rejected")
+	}
+}
+
+// TestFsckReservedNames checks that user objects are never taken for the
+// temporary files fsck removes.
+func TestFsckReservedNames(t *testing.T) {
+	dir := t.TempDir()
+	server := NewStorageServer(dir)
+	defer server.Close()
+	ctx := context.Background()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	for _, name := range []string{stagedPrefix + "x", "a/" + stagedPrefix + "y/z"} {
+		if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: name, Data: []byte("payload")})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s: expected InvalidArgument for a reserved name, got %v", name, err)
+		}
+	}
+
+	// An object stored under such a name before it was reserved survives
+	// a repair.
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "keep", Data: []byte("payload")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	legacy, err := server.lookupObject(ctx, "b", "keep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy.Name = stagedPrefix + "legacy"
+	server.update(ctx, func(tx *bbolt.Tx) error { return server.putObjectRecord(tx, legacy) })
+	os.WriteFile(filepath.Join(dir, "b", legacy.Name), []byte("payload"), 0644)
+	res, err := server.Fsck(ctx, FsckOptions{VerifyChecksums: true, Orphans: OrphansQuarantine, DropDangling: true, RemoveTempFiles: true})
+	if err != nil || len(res.Issues) != 0 {
+		t.Errorf("expected no issues, got %q (%v)", issueSummary(res), err)
+	}
+	if _, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "b", Name: legacy.Name})); err != nil {
+		t.Errorf("object with a staged name lost its record: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "b", legacy.Name)); err != nil || string(data) != "payload" {
+		t.Errorf("object with a staged name lost its data: %q (%v)", data, err)
+	}
+}
+
+func TestFsckBlobs(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory(), WithContentAddressing())
+	defer server.Close()
+	ctx := context.Background()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	for name, data := range map[string]string{"lost": "lost data", "rotten": "rotten data", "copy": "rotten data"} {
+		if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: name, Data: []byte(data)})); err != nil {
+			t.Fatalf("UploadObject %s failed: %v", name, err)
+		}
+	}
+	lost, _ := server.lookupObject(ctx, "b", "lost")
+	rotten, _ := server.lookupObject(ctx, "b", "rotten")
+	server.backend.Delete(ctx, blobBucket, blobName(lost.Blobs[0].Hash))
+	server.backend.Put(ctx, blobBucket, blobName(rotten.Blobs[0].Hash), []byte("r0tten data"))
+
+	res, err := server.Fsck(ctx, FsckOptions{VerifyChecksums: true, DropDangling: true})
+	if err != nil {
+		t.Fatalf("Fsck failed: %v", err)
+	}
+	if got := issueSummary(res); !slices.Equal(got, []string{
+		"checksum_mismatch b/copy ",
+		"checksum_mismatch b/rotten ",
+		"dangling_record b/lost dropped",
+	}) {
+		t.Errorf("unexpected issues: %q", got)
+	}
+}
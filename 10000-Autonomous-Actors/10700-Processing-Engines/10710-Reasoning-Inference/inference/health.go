@@ -0,0 +1,132 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/grpchealth"
+	"go.etcd.io/bbolt"
+)
+
+// ReadinessCheck is the outcome of one readiness probe.
+type ReadinessCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Readiness probes what serving depends on: the database is open, the data
+// directory is writable and its filesystem has at least minFreeBytes free.
+// The directory probes pass trivially in memory.
+func (s *StorageServer) Readiness(minFreeBytes uint64) []ReadinessCheck {
+	checks := []ReadinessCheck{{Name: "database", OK: true}, {Name: "dataDir", OK: true}, {Name: "disk", OK: true}}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte(bucketBuckets)) == nil {
+			return errors.New("bucket index missing")
+		}
+		return nil
+	})
+	if err != nil {
+		checks[0] = ReadinessCheck{Name: "database", Detail: err.Error()}
+	}
+	if s.dir == "" {
+		return checks
+	}
+
+	f, err := os.CreateTemp(s.dir, ".ready-*")
+	if err == nil {
+		f.Close()
+		err = os.Remove(f.Name())
+	}
+	if err != nil {
+		checks[1] = ReadinessCheck{Name: "dataDir", Detail: err.Error()}
+	}
+	free, err := freeDiskBytes(s.dir)
+	switch {
+	case errors.Is(err, errors.ErrUnsupported):
+		checks[2].Detail = "free space unknown"
+	case err != nil:
+		checks[2] = ReadinessCheck{Name: "disk", Detail: err.Error()}
+	case free < minFreeBytes:
+		checks[2] = ReadinessCheck{Name: "disk", Detail: fmt.Sprintf("%d bytes free, below the %d byte threshold", free, minFreeBytes)}
+	default:
+		checks[2].Detail = fmt.Sprintf("%d bytes free", free)
+	}
+	return checks
+}
+
+// HealthChecker answers the gRPC health protocol and /pulse. A service is
+// serving while its own status, set with SetStatus, is serving and the
+// storage is ready; the empty service stands for the whole server.
+type HealthChecker struct {
+	server       *StorageServer
+	minFreeBytes uint64
+	statuses     *grpchealth.StaticChecker
+}
+
+// NewHealthChecker reports the StorageService and the whole server as
+// serving until SetStatus says otherwise.
+func NewHealthChecker(server *StorageServer, minFreeBytes uint64) *HealthChecker {
+	return &HealthChecker{
+		server:       server,
+		minFreeBytes: minFreeBytes,
+		statuses:     grpchealth.NewStaticChecker("", storagev1connect.StorageServiceName),
+	}
+}
+
+// SetStatus sets the status of a service, for example to take the server
+// out of rotation before shutting down.
+func (h *HealthChecker) SetStatus(service string, status grpchealth.Status) {
+	h.statuses.SetStatus(service, status)
+}
+
+// Check implements grpchealth.Checker.
+func (h *HealthChecker) Check(ctx context.Context, req *grpchealth.CheckRequest) (*grpchealth.CheckResponse, error) {
+	resp, err := h.statuses.Check(ctx, req)
+	if err != nil || resp.Status != grpchealth.StatusServing {
+		return resp, err
+	}
+	if !ready(h.server.Readiness(h.minFreeBytes)) {
+		return &grpchealth.CheckResponse{Status: grpchealth.StatusNotServing}, nil
+	}
+	return resp, nil
+}
+
+func ready(checks []ReadinessCheck) bool {
+	for _, c := range checks {
+		if !c.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// ServeHTTP serves /pulse: the readiness checks, with 503 Service
+// Unavailable when the server is not serving.
+func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	checks := h.server.Readiness(h.minFreeBytes)
+	resp, _ := h.statuses.Check(r.Context(), &grpchealth.CheckRequest{})
+	status, code := "HEALTHY", http.StatusOK
+	switch {
+	case resp.Status != grpchealth.StatusServing:
+		status, code = "STOPPING", http.StatusServiceUnavailable
+	case !ready(checks):
+		status, code = "UNHEALTHY", http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]any{
+		"status":    status,
+		"workspace": "OlympusGCP-Storage",
+		"time":      time.Now().Format(time.RFC3339),
+		"checks":    checks,
+	})
+}
@@ -0,0 +1,114 @@
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"connectrpc.com/grpchealth"
+)
+
+func TestHealthChecker(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	server := NewStorageServer(dir)
+	defer server.Close()
+	health := NewHealthChecker(server, 1)
+
+	mux := http.NewServeMux()
+	mux.Handle(grpchealth.NewHandler(health))
+	mux.Handle("/pulse", health)
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+
+	check := func(service string) (string, int) {
+		t.Helper()
+		resp, err := http.Post(httpServer.URL+"/grpc.health.v1.Health/Check", "application/json", strings.NewReader(`{"service":"`+service+`"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var body struct{ Status string }
+		json.NewDecoder(resp.Body).Decode(&body)
+		return body.Status, resp.StatusCode
+	}
+	pulse := func() (string, []ReadinessCheck, int) {
+		t.Helper()
+		resp, err := http.Get(httpServer.URL + "/pulse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var body struct {
+			Status string
+			Checks []ReadinessCheck
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return body.Status, body.Checks, resp.StatusCode
+	}
+
+	for _, service := range []string{"", storagev1connect.StorageServiceName} {
+		if status, code := check(service); status != "SERVING_STATUS_SERVING" || code != http.StatusOK {
+			t.Errorf("Check(%q) = %s (%d), want SERVING_STATUS_SERVING", service, status, code)
+		}
+	}
+	if _, code := check("acme.v1.Unknown"); code != http.StatusNotFound {
+		t.Errorf("expected NotFound for an unknown service, got %d", code)
+	}
+	if status, checks, code := pulse(); status != "HEALTHY" || code != http.StatusOK || len(checks) != 3 {
+		t.Errorf("unexpected pulse %s %v (%d)", status, checks, code)
+	}
+
+	// Marking the server down, as on shutdown, leaves the service status alone.
+	health.SetStatus("", grpchealth.StatusNotServing)
+	if status, _ := check(""); status != "SERVING_STATUS_NOT_SERVING" {
+		t.Errorf("expected the server to be down, got %s", status)
+	}
+	if status, _ := check(storagev1connect.StorageServiceName); status != "SERVING_STATUS_SERVING" {
+		t.Errorf("expected the service to keep its status, got %s", status)
+	}
+	if status, _, code := pulse(); status != "STOPPING" || code != http.StatusServiceUnavailable {
+		t.Errorf("unexpected pulse while stopping: %s (%d)", status, code)
+	}
+	health.SetStatus("", grpchealth.StatusServing)
+
+	strict := NewHealthChecker(server, math.MaxUint64)
+	resp, err := strict.Check(context.Background(), &grpchealth.CheckRequest{Service: storagev1connect.StorageServiceName})
+	if err != nil || resp.Status != grpchealth.StatusNotServing {
+		t.Errorf("expected NOT_SERVING below the free space threshold, got %v (%v)", resp, err)
+	}
+	rec := httptest.NewRecorder()
+	strict.ServeHTTP(rec, httptest.NewRequest("GET", "/pulse", nil))
+	if body := rec.Body.String(); rec.Code != http.StatusServiceUnavailable || !strings.Contains(body, "UNHEALTHY") || !strings.Contains(body, "threshold") {
+		t.Errorf("unexpected pulse below the free space threshold: %s (%d)", body, rec.Code)
+	}
+
+	os.RemoveAll(dir)
+	if checks := server.Readiness(1); !checks[0].OK || checks[1].OK {
+		t.Errorf("expected only the data dir probe to fail: %v", checks)
+	}
+}
+
+func TestReadinessInMemory(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	health := NewHealthChecker(server, math.MaxUint64)
+	resp, err := health.Check(context.Background(), &grpchealth.CheckRequest{})
+	if err != nil || resp.Status != grpchealth.StatusServing {
+		t.Errorf("in-memory server must not depend on disk space: %v (%v)", resp, err)
+	}
+	server.Close()
+	if checks := server.Readiness(0); checks[0].OK {
+		t.Errorf("expected the database probe to fail once closed: %v", checks)
+	}
+	if _, err := health.Check(context.Background(), &grpchealth.CheckRequest{Service: "acme.v1.Unknown"}); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("expected NotFound for an unknown service, got %v", err)
+	}
+}
@@ -0,0 +1,385 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	bucketIAM = "iam"
+
+	// PrincipalHeader names the caller when no authenticated principal has
+	// been placed in the request context.
+	PrincipalHeader = "X-Olympus-Principal"
+
+	memberAllUsers              = "allUsers"
+	memberAllAuthenticatedUsers = "allAuthenticatedUsers"
+)
+
+const (
+	permBucketsGet          = "storage.buckets.get"
+	permBucketsUpdate       = "storage.buckets.update"
+	permBucketsGetIamPolicy = "storage.buckets.getIamPolicy"
+	permBucketsSetIamPolicy = "storage.buckets.setIamPolicy"
+	permObjectsCreate       = "storage.objects.create"
+	permObjectsDelete       = "storage.objects.delete"
+	permObjectsGet          = "storage.objects.get"
+	permObjectsList         = "storage.objects.list"
+	permObjectsUpdate       = "storage.objects.update"
+	permObjectsGetIamPolicy = "storage.objects.getIamPolicy"
+	permObjectsSetIamPolicy = "storage.objects.setIamPolicy"
+)
+
+// rolePermissions maps the supported predefined roles to the permissions
+// they grant on a bucket and its objects.
+var rolePermissions = map[string][]string{
+	"roles/storage.objectViewer":  {permObjectsGet, permObjectsList},
+	"roles/storage.objectCreator": {permObjectsCreate},
+	"roles/storage.objectAdmin": {
+		permObjectsCreate, permObjectsDelete, permObjectsGet, permObjectsList, permObjectsUpdate,
+		permObjectsGetIamPolicy, permObjectsSetIamPolicy,
+	},
+	"roles/storage.admin": {
+		permBucketsGet, permBucketsUpdate, permBucketsGetIamPolicy, permBucketsSetIamPolicy,
+		permObjectsCreate, permObjectsDelete, permObjectsGet, permObjectsList, permObjectsUpdate,
+		permObjectsGetIamPolicy, permObjectsSetIamPolicy,
+	},
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated caller as an IAM
+// member string such as "user:alice@example.com".
+func WithPrincipal(ctx context.Context, member string) context.Context {
+	return context.WithValue(ctx, principalKey{}, member)
+}
+
+// PrincipalFromContext returns the member placed by WithPrincipal, if any.
+func PrincipalFromContext(ctx context.Context) (string, bool) {
+	member, ok := ctx.Value(principalKey{}).(string)
+	return member, ok
+}
+
+// principalOf identifies the caller of a request: the authenticated
+// principal from ctx when present, otherwise PrincipalHeader. Bare emails
+// are treated as users. The empty string is the anonymous caller.
+func principalOf(ctx context.Context, header http.Header) string {
+	if member, ok := PrincipalFromContext(ctx); ok {
+		return member
+	}
+	member := strings.TrimSpace(header.Get(PrincipalHeader))
+	if member != "" && !strings.Contains(member, ":") {
+		member = "user:" + member
+	}
+	return member
+}
+
+// WithAdmins names the members who administer the server itself: only they
+// may rotate KMS keys and manage fault rules, and only once authenticated
+// by AuthInterceptor. They also own the buckets created anonymously or
+// before bucket policies were stored, which are otherwise open to allUsers.
+func WithAdmins(members ...string) Option {
+	return func(s *StorageServer) {
+		s.admins = members
+	}
+}
+
+// authorizeAdmin checks that the caller of a request is one of the server
+// administrators, who alone may perform action. Administrators must be
+// authenticated by AuthInterceptor: PrincipalHeader, which any caller may
+// set, is never trusted here. Without WithAdmins nobody may.
+func (s *StorageServer) authorizeAdmin(ctx context.Context, header http.Header, action string) error {
+	member, ok := PrincipalFromContext(ctx)
+	if ok && slices.Contains(s.admins, member) {
+		return nil
+	}
+	slog.Warn("Permission denied", "principal", principalOf(ctx, header), "authenticated", ok, "action", action)
+	if !ok || member == "" {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("%s requires an authenticated administrator", action))
+	}
+	_, id, _ := strings.Cut(member, ":")
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s may not %s", id, action))
+}
+
+// policyRecord is the stored bucket IAM policy, keyed by bucket name in the
+// "iam" bucket. Etag is derived from Revision, which increases on each set.
+type policyRecord struct {
+	Version  int32           `json:"version"`
+	Revision uint64          `json:"revision"`
+	Bindings []bindingRecord `json:"bindings"`
+}
+
+type bindingRecord struct {
+	Role    string   `json:"role"`
+	Members []string `json:"members"`
+}
+
+func (p *policyRecord) etag() string {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, p.Revision)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func (p *policyRecord) toProto() *storagev1.Policy {
+	out := &storagev1.Policy{Version: p.Version, Etag: p.etag()}
+	for _, b := range p.Bindings {
+		out.Bindings = append(out.Bindings, &storagev1.Binding{Role: b.Role, Members: b.Members})
+	}
+	return out
+}
+
+// grants reports whether member holds perm under the policy.
+func (p *policyRecord) grants(member, perm string) bool {
+	for _, b := range p.Bindings {
+		if !containsString(rolePermissions[b.Role], perm) {
+			continue
+		}
+		for _, m := range b.Members {
+			if m == memberAllUsers || m == member || (m == memberAllAuthenticatedUsers && member != "") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// defaultPolicy is installed on new buckets. The creator administers the
+// bucket, or the owners when it was created anonymously. Without owners
+// such buckets stay open to allUsers so that deployments without
+// authentication behave as before.
+func defaultPolicy(creator string, owners []string) *policyRecord {
+	members := slices.Clone(owners)
+	if creator != "" {
+		members = []string{creator}
+	}
+	if len(members) == 0 {
+		members = []string{memberAllUsers}
+	}
+	return &policyRecord{
+		Version:  1,
+		Revision: 1,
+		Bindings: []bindingRecord{{Role: "roles/storage.admin", Members: members}},
+	}
+}
+
+// openToAllUsers reports whether the policy lets anyone administer the
+// bucket.
+func (p *policyRecord) openToAllUsers() bool {
+	return p.grants("", permBucketsSetIamPolicy)
+}
+
+// ownPolicylessBuckets stores a policy making the server administrators
+// the owners of buckets that have none, which getPolicyRecord would treat as
+// open to allUsers, and warns about buckets anyone may administer.
+func (s *StorageServer) ownPolicylessBuckets() error {
+	if len(s.admins) == 0 {
+		return nil
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte(bucketBuckets)).ForEach(func(k, _ []byte) error {
+			var rec policyRecord
+			found, err := s.getRecord(tx.Bucket([]byte(bucketIAM)), bucketIAM, string(k), &rec)
+			if err != nil {
+				return err
+			}
+			if !found {
+				slog.Info("Giving the server administrators a bucket without a policy", "bucket", string(k))
+				return s.putPolicyRecord(tx, string(k), defaultPolicy("", s.admins))
+			}
+			if rec.openToAllUsers() {
+				slog.Warn("Bucket policy lets anyone administer the bucket", "bucket", string(k))
+			}
+			return nil
+		})
+	})
+}
+
+func validMember(m string) bool {
+	if m == memberAllUsers || m == memberAllAuthenticatedUsers {
+		return true
+	}
+	kind, id, ok := strings.Cut(m, ":")
+	if !ok || id == "" {
+		return false
+	}
+	switch kind {
+	case "user", "serviceAccount", "group", "domain":
+		return true
+	}
+	return false
+}
+
+func policyFromProto(p *storagev1.Policy) (*policyRecord, error) {
+	if p == nil {
+		return nil, errors.New("policy is required")
+	}
+	rec := &policyRecord{Version: p.Version}
+	if rec.Version == 0 {
+		rec.Version = 1
+	}
+	for _, b := range p.Bindings {
+		if _, ok := rolePermissions[b.Role]; !ok {
+			return nil, fmt.Errorf("unsupported role: %q", b.Role)
+		}
+		for _, m := range b.Members {
+			if !validMember(m) {
+				return nil, fmt.Errorf("invalid member: %q", m)
+			}
+		}
+		rec.Bindings = append(rec.Bindings, bindingRecord{Role: b.Role, Members: b.Members})
+	}
+	return rec, nil
+}
+
+// getPolicyRecord loads a bucket's policy. Buckets that predate IAM have no
+// stored policy and are treated as open to allUsers.
+func (s *StorageServer) getPolicyRecord(tx *bbolt.Tx, bucket string) (*policyRecord, error) {
+	var rec policyRecord
+	found, err := s.getRecord(tx.Bucket([]byte(bucketIAM)), bucketIAM, bucket, &rec)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return defaultPolicy("", nil), nil
+	}
+	return &rec, nil
+}
+
+func (s *StorageServer) putPolicyRecord(tx *bbolt.Tx, bucket string, rec *policyRecord) error {
+	return s.putRecord(tx.Bucket([]byte(bucketIAM)), bucketIAM, bucket, rec)
+}
+
+// permissionError builds the error GCS returns for a missing permission:
+// Unauthenticated for anonymous callers, PermissionDenied otherwise.
+func permissionError(member, perm, bucket string) error {
+	if member == "" {
+		return connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("anonymous caller does not have %s access to bucket %s", perm, bucket))
+	}
+	_, id, _ := strings.Cut(member, ":")
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s does not have %s access to bucket %s", id, perm, bucket))
+}
+
+// authorize checks that the caller of a request holds every perm on bucket.
+func (s *StorageServer) authorize(ctx context.Context, header http.Header, bucket string, perms ...string) error {
+	return s.authorizeObject(ctx, header, bucket, "", perms...)
+}
+
+// authorizeObject is authorize for permissions on an existing object, which
+// its object ACL may also grant.
+func (s *StorageServer) authorizeObject(ctx context.Context, header http.Header, bucket, object string, perms ...string) error {
+	member := principalOf(ctx, header)
+	return s.view(ctx, func(tx *bbolt.Tx) error {
+		return s.authorizeObjectTx(tx, member, bucket, object, perms...)
+	})
+}
+
+func (s *StorageServer) authorizeTx(tx *bbolt.Tx, member, bucket string, perms ...string) error {
+	return s.authorizeObjectTx(tx, member, bucket, "", perms...)
+}
+
+func (s *StorageServer) authorizeObjectTx(tx *bbolt.Tx, member, bucket, object string, perms ...string) error {
+	for _, perm := range perms {
+		ok, err := s.canAccessTx(tx, member, bucket, object, perm)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if !ok {
+			slog.Warn("Permission denied", "principal", member, "bucket", bucket, "object", object, "permission", perm)
+			return permissionError(member, perm, bucket)
+		}
+	}
+	return nil
+}
+
+func (s *StorageServer) GetIamPolicy(ctx context.Context, req *connect.Request[storagev1.GetIamPolicyRequest]) (*connect.Response[storagev1.GetIamPolicyResponse], error) {
+	slog.Info("GetIamPolicy", "bucket", req.Msg.Bucket)
+	if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permBucketsGetIamPolicy); err != nil {
+		return nil, err
+	}
+
+	var policy *policyRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		if _, err := s.getBucketRecord(tx, req.Msg.Bucket); err != nil {
+			return err
+		}
+		var err error
+		policy, err = s.getPolicyRecord(tx, req.Msg.Bucket)
+		return err
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.GetIamPolicyResponse{Policy: policy.toProto()}), nil
+}
+
+func (s *StorageServer) SetIamPolicy(ctx context.Context, req *connect.Request[storagev1.SetIamPolicyRequest]) (*connect.Response[storagev1.SetIamPolicyResponse], error) {
+	slog.Info("SetIamPolicy", "bucket", req.Msg.Bucket)
+
+	policy, err := policyFromProto(req.Msg.Policy)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	member := principalOf(ctx, req.Header())
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		if err := s.authorizeTx(tx, member, req.Msg.Bucket, permBucketsSetIamPolicy); err != nil {
+			return err
+		}
+		if _, err := s.getBucketRecord(tx, req.Msg.Bucket); err != nil {
+			return err
+		}
+		current, err := s.getPolicyRecord(tx, req.Msg.Bucket)
+		if err != nil {
+			return err
+		}
+		if etag := req.Msg.Policy.Etag; etag != "" && etag != current.etag() {
+			return connect.NewError(connect.CodeAborted, fmt.Errorf("policy etag mismatch: got %s, current %s", etag, current.etag()))
+		}
+		policy.Revision = current.Revision + 1
+		return s.putPolicyRecord(tx, req.Msg.Bucket, policy)
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.SetIamPolicyResponse{Policy: policy.toProto()}), nil
+}
+
+func (s *StorageServer) TestIamPermissions(ctx context.Context, req *connect.Request[storagev1.TestIamPermissionsRequest]) (*connect.Response[storagev1.TestIamPermissionsResponse], error) {
+	slog.Info("TestIamPermissions", "bucket", req.Msg.Bucket, "permissions", req.Msg.Permissions)
+
+	member := principalOf(ctx, req.Header())
+	var held []string
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		if _, err := s.getBucketRecord(tx, req.Msg.Bucket); err != nil {
+			return err
+		}
+		for _, perm := range req.Msg.Permissions {
+			ok, err := s.canAccessTx(tx, member, req.Msg.Bucket, "", perm)
+			if err != nil {
+				return err
+			}
+			if ok {
+				held = append(held, perm)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	sort.Strings(held)
+	return connect.NewResponse(&storagev1.TestIamPermissionsResponse{Permissions: held}), nil
+}
@@ -0,0 +1,109 @@
+package inference
+
+import (
+	"context"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+// as sets the caller identity header on req.
+func as[T any](member string, req *connect.Request[T]) *connect.Request[T] {
+	req.Header().Set(PrincipalHeader, member)
+	return req
+}
+
+func TestBucketIAM(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	ctx := context.Background()
+
+	alice, bob := "user:alice@example.com", "user:bob@example.com"
+	if _, err := server.CreateBucket(ctx, as(alice, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "private"}))); err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+
+	upload := func(member, name string) error {
+		_, err := server.UploadObject(ctx, as(member, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "private", Name: name, Data: []byte("x")})))
+		return err
+	}
+	if err := upload(bob, "o"); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied for bob, got %v", err)
+	}
+	if err := upload("", "o"); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated for anonymous caller, got %v", err)
+	}
+
+	got, err := server.GetIamPolicy(ctx, as(alice, connect.NewRequest(&storagev1.GetIamPolicyRequest{Bucket: "private"})))
+	if err != nil {
+		t.Fatalf("GetIamPolicy failed: %v", err)
+	}
+	policy := got.Msg.Policy
+	policy.Bindings = append(policy.Bindings, &storagev1.Binding{Role: "roles/storage.objectCreator", Members: []string{bob}})
+	if _, err := server.SetIamPolicy(ctx, as(alice, connect.NewRequest(&storagev1.SetIamPolicyRequest{Bucket: "private", Policy: policy}))); err != nil {
+		t.Fatalf("SetIamPolicy failed: %v", err)
+	}
+	// Reusing the old etag must fail now that the policy has changed.
+	if _, err := server.SetIamPolicy(ctx, as(alice, connect.NewRequest(&storagev1.SetIamPolicyRequest{Bucket: "private", Policy: policy}))); connect.CodeOf(err) != connect.CodeAborted {
+		t.Errorf("expected Aborted on stale etag, got %v", err)
+	}
+
+	if err := upload(bob, "o"); err != nil {
+		t.Errorf("expected objectCreator to upload, got %v", err)
+	}
+	if err := upload(bob, "o"); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("objectCreator must not overwrite without delete permission, got %v", err)
+	}
+	if _, err := server.GetObjectMetadata(ctx, as(bob, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "private", Name: "o"}))); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("objectCreator must not read, got %v", err)
+	}
+
+	perms, err := server.TestIamPermissions(ctx, as(bob, connect.NewRequest(&storagev1.TestIamPermissionsRequest{
+		Bucket:      "private",
+		Permissions: []string{"storage.objects.get", "storage.objects.create", "storage.buckets.setIamPolicy"},
+	})))
+	if err != nil || len(perms.Msg.Permissions) != 1 || perms.Msg.Permissions[0] != "storage.objects.create" {
+		t.Errorf("unexpected held permissions: %v (%v)", perms, err)
+	}
+
+	list, _ := server.ListBuckets(ctx, as(bob, connect.NewRequest(&storagev1.ListBucketsRequest{})))
+	if len(list.Msg.Buckets) != 0 {
+		t.Errorf("bob should not see the private bucket: %v", list.Msg.Buckets)
+	}
+}
+
+func TestAdminsOwnAnonymousBuckets(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	server := NewStorageServer(dir)
+	for _, name := range []string{"legacy", "open"} {
+		if _, err := server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: name})); err != nil {
+			t.Fatalf("CreateBucket failed: %v", err)
+		}
+	}
+	// Buckets predating IAM have no stored policy.
+	server.db.Update(func(tx *bbolt.Tx) error { return tx.Bucket([]byte(bucketIAM)).Delete([]byte("legacy")) })
+	server.Close()
+
+	server = NewStorageServer(dir, WithAdmins(alice))
+	defer server.Close()
+	if _, err := server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "fresh"})); err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	for _, name := range []string{"legacy", "fresh"} {
+		if _, err := server.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: name})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+			t.Errorf("%s: expected Unauthenticated for an anonymous caller, got %v", name, err)
+		}
+		policy, err := server.GetIamPolicy(ctx, as(alice, connect.NewRequest(&storagev1.GetIamPolicyRequest{Bucket: name})))
+		if err != nil || len(policy.Msg.Policy.Bindings) != 1 || policy.Msg.Policy.Bindings[0].Members[0] != alice {
+			t.Errorf("%s: expected the administrator to own the bucket, got %v (%v)", name, policy, err)
+		}
+	}
+	// Policies granting allUsers explicitly are kept.
+	if _, err := server.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: "open"})); err != nil {
+		t.Errorf("expected the open bucket to stay open, got %v", err)
+	}
+}
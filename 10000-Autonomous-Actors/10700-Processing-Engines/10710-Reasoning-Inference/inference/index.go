@@ -0,0 +1,205 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// bucketObjects holds one nested bucket per storage bucket, mapping
+	// object names to their records. It is the source of truth for which
+	// objects exist; cursors over it list them in name order.
+	bucketObjects = "objects"
+	// maxPageSize is the default and largest ListObjects page, as in GCS.
+	maxPageSize = 1000
+)
+
+// objectBucket returns the records of bucket's objects, or nil when none
+// has been stored yet.
+func objectBucket(tx *bbolt.Tx, bucket string) *bbolt.Bucket {
+	return tx.Bucket([]byte(bucketObjects)).Bucket([]byte(bucket))
+}
+
+// objectBucketPath names the records of bucket's objects for sealing.
+func objectBucketPath(bucket string) string {
+	return bucketObjects + "/" + bucket
+}
+
+// indexObjectRecord stores rec without the accounting putObjectRecord does.
+func (s *StorageServer) indexObjectRecord(tx *bbolt.Tx, rec *objectRecord) error {
+	b, err := tx.Bucket([]byte(bucketObjects)).CreateBucketIfNotExists([]byte(rec.Bucket))
+	if err != nil {
+		return err
+	}
+	return s.putRecord(b, objectBucketPath(rec.Bucket), rec.Name, rec)
+}
+
+// scanObjects calls fn, in name order, with each record in bucket whose name
+// starts with prefix and sorts after startAfter, until fn returns false.
+func (s *StorageServer) scanObjects(tx *bbolt.Tx, bucket, prefix, startAfter string, fn func(*objectRecord) (bool, error)) error {
+	b := objectBucket(tx, bucket)
+	if b == nil {
+		return nil
+	}
+	c := b.Cursor()
+	for k, v := c.Seek([]byte(max(prefix, startAfter))); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, v = c.Next() {
+		if string(k) == startAfter {
+			continue
+		}
+		var rec objectRecord
+		if err := s.decodeRecord(objectBucketPath(bucket), string(k), v, &rec); err != nil {
+			return err
+		}
+		if more, err := fn(&rec); err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachObject calls fn with the record of every object in every bucket.
+func (s *StorageServer) forEachObject(tx *bbolt.Tx, fn func(*objectRecord) error) error {
+	return tx.Bucket([]byte(bucketObjects)).ForEach(func(bucket, _ []byte) error {
+		return s.scanObjects(tx, string(bucket), "", "", func(rec *objectRecord) (bool, error) {
+			return true, fn(rec)
+		})
+	})
+}
+
+// encodePageToken makes the ListObjects token resuming after name.
+func encodePageToken(name string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+func decodePageToken(token string) (string, error) {
+	name, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid page token: %q", token)
+	}
+	return string(name), nil
+}
+
+// adoptObject makes a full record for data stored under bucket/name, which
+// has none: custom metadata at most. Absent data is reported with an error
+// matching fs.ErrNotExist.
+func (s *StorageServer) adoptObject(ctx context.Context, bucket, name string, metadata map[string]string) (*objectRecord, error) {
+	info, err := s.backend.Stat(ctx, bucket, name)
+	if err != nil {
+		return nil, err
+	}
+	return &objectRecord{
+		Bucket:                  bucket,
+		Name:                    name,
+		Size:                    info.Size,
+		Metadata:                metadata,
+		StorageClass:            StorageClassStandard,
+		Generation:              s.nextGeneration(info.Modified),
+		Metageneration:          1,
+		TimeCreated:             info.Modified,
+		Updated:                 info.Modified,
+		TimeStorageClassUpdated: info.Modified,
+	}, nil
+}
+
+// migrateObjectIndex builds the object index on the first start of a
+// database that predates it. Records move out of the flat "metadata"
+// bucket, keyed "bucket/name"; the bare custom-metadata maps of older
+// releases, and objects stored with no record at all, get full records from
+// their stored data. Records whose data is gone are dropped, as they were
+// never served. Usage totals are then recomputed from the index.
+func (s *StorageServer) migrateObjectIndex() error {
+	ctx := context.Background()
+	return s.update(ctx, func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte(bucketObjects)) != nil {
+			return nil
+		}
+		if _, err := tx.CreateBucket([]byte(bucketObjects)); err != nil {
+			return err
+		}
+		moved, adopted := 0, 0
+		if flat := tx.Bucket([]byte(bucketMetadata)); flat != nil {
+			err := flat.ForEach(func(k, v []byte) error {
+				bucket, name, _ := strings.Cut(string(k), "/")
+				rec := &objectRecord{}
+				decodeErr := s.decodeRecord(bucketMetadata, string(k), v, rec)
+				var err error
+				switch {
+				case decodeErr != nil || rec.Generation == 0:
+					legacy := make(map[string]string)
+					if err := s.decodeRecord(bucketMetadata, string(k), v, &legacy); err != nil {
+						return err
+					}
+					rec, err = s.adoptObject(ctx, bucket, name, legacy)
+				case !rec.contentAddressed():
+					_, err = s.backend.Stat(ctx, bucket, name)
+				}
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				moved++
+				return s.indexObjectRecord(tx, rec)
+			})
+			if err != nil {
+				return err
+			}
+			if err := tx.DeleteBucket([]byte(bucketMetadata)); err != nil {
+				return err
+			}
+		}
+
+		var buckets []string
+		err := tx.Bucket([]byte(bucketBuckets)).ForEach(func(k, _ []byte) error {
+			buckets = append(buckets, string(k))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, bucket := range buckets {
+			names, err := s.backend.List(ctx, bucket, "")
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				_, found, err := s.getObjectRecord(tx, bucket, name)
+				if err != nil {
+					return err
+				}
+				if found {
+					continue
+				}
+				rec, err := s.adoptObject(ctx, bucket, name, nil)
+				if err != nil {
+					return err
+				}
+				if err := s.indexObjectRecord(tx, rec); err != nil {
+					return err
+				}
+				adopted++
+			}
+		}
+
+		if tx.Bucket([]byte(bucketUsage)) != nil {
+			if err := tx.DeleteBucket([]byte(bucketUsage)); err != nil {
+				return err
+			}
+		}
+		if moved > 0 || adopted > 0 {
+			slog.Info("Built object index", "moved", moved, "adopted", adopted)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,150 @@
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+func TestListObjectsPagination(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	ctx := context.Background()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "pages"}))
+	for _, name := range []string{"logs/3", "logs/1", "data/b", "logs/2", "data/a", "logs2"} {
+		if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "pages", Name: name, Data: []byte(name)})); err != nil {
+			t.Fatalf("UploadObject %s failed: %v", name, err)
+		}
+	}
+	list := func(req *storagev1.ListObjectsRequest) []string {
+		t.Helper()
+		req.Bucket = "pages"
+		var names []string
+		for {
+			resp, err := server.ListObjects(ctx, connect.NewRequest(req))
+			if err != nil {
+				t.Fatalf("ListObjects failed: %v", err)
+			}
+			if req.PageSize > 0 && len(resp.Msg.ObjectNames) > int(req.PageSize) {
+				t.Fatalf("page of %d exceeds the page size %d", len(resp.Msg.ObjectNames), req.PageSize)
+			}
+			names = append(names, resp.Msg.ObjectNames...)
+			if resp.Msg.NextPageToken == "" {
+				return names
+			}
+			req.PageToken = resp.Msg.NextPageToken
+		}
+	}
+
+	if got := list(&storagev1.ListObjectsRequest{}); !slices.Equal(got, []string{"data/a", "data/b", "logs/1", "logs/2", "logs/3", "logs2"}) {
+		t.Errorf("unexpected listing %v", got)
+	}
+	if got := list(&storagev1.ListObjectsRequest{PageSize: 2}); len(got) != 6 || !slices.IsSorted(got) {
+		t.Errorf("unexpected paged listing %v", got)
+	}
+	if got := list(&storagev1.ListObjectsRequest{Prefix: "logs/", PageSize: 1}); !slices.Equal(got, []string{"logs/1", "logs/2", "logs/3"}) {
+		t.Errorf("unexpected prefix listing %v", got)
+	}
+	resp, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "pages", PageSize: 6}))
+	if err != nil || len(resp.Msg.ObjectNames) != 6 || resp.Msg.NextPageToken != "" {
+		t.Errorf("an exact last page must not carry a token: %v (%v)", resp, err)
+	}
+	if _, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "pages", PageToken: "%%"})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected InvalidArgument for a bad token, got %v", err)
+	}
+	if _, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "pages", PageSize: -1})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected InvalidArgument for a negative page size, got %v", err)
+	}
+}
+
+func TestObjectIndexMigration(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	server := NewStorageServer(dir)
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "old"}))
+	server.Close()
+
+	// Rebuild the flat layout: a full record, a bare metadata map from the
+	// oldest releases, a record whose data is gone, and a file with none.
+	files := map[string]string{"full": "full data", "bare": "bare", "dropped": "by hand"}
+	os.MkdirAll(filepath.Join(dir, "old"), 0755)
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, "old", name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now().UTC()
+	full, _ := json.Marshal(&objectRecord{Bucket: "old", Name: "full", Size: 9, StorageClass: StorageClassNearline, Generation: 42, Metageneration: 3, TimeCreated: now, Updated: now, TimeStorageClassUpdated: now})
+	gone, _ := json.Marshal(&objectRecord{Bucket: "old", Name: "gone", Size: 1, Generation: 7, Metageneration: 1})
+	bare, _ := json.Marshal(map[string]string{"owner": "agent"})
+	db, err := bbolt.Open(filepath.Join(dir, "storage.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket([]byte(bucketObjects)); err != nil {
+			return err
+		}
+		flat, err := tx.CreateBucket([]byte(bucketMetadata))
+		if err != nil {
+			return err
+		}
+		flat.Put([]byte("old/full"), full)
+		flat.Put([]byte("old/gone"), gone)
+		return flat.Put([]byte("old/bare"), bare)
+	})
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server = NewStorageServer(dir)
+	defer server.Close()
+	list, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "old"}))
+	if err != nil || !slices.Equal(list.Msg.ObjectNames, []string{"bare", "dropped", "full"}) {
+		t.Errorf("unexpected listing after migration: %v (%v)", list, err)
+	}
+	meta := func(name string) *storagev1.GetObjectMetadataResponse {
+		t.Helper()
+		resp, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "old", Name: name}))
+		if err != nil {
+			t.Fatalf("GetObjectMetadata %s failed: %v", name, err)
+		}
+		return resp.Msg
+	}
+	if m := meta("full"); m.Generation != 42 || m.Metageneration != 3 || m.StorageClass != StorageClassNearline {
+		t.Errorf("full record not moved intact: %+v", m)
+	}
+	if m := meta("bare"); m.Size != 4 || m.Metadata["owner"] != "agent" || m.Generation == 0 {
+		t.Errorf("bare record not completed: %+v", m)
+	}
+	if m := meta("dropped"); m.Size != 7 || m.Generation == 0 || m.StorageClass != StorageClassStandard {
+		t.Errorf("unindexed file not adopted: %+v", m)
+	}
+	if _, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "old", Name: "gone"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("expected NotFound for a record without data, got %v", err)
+	}
+	usage, err := server.GetStorageUsage(ctx, connect.NewRequest(&storagev1.GetStorageUsageRequest{Bucket: "old"}))
+	if err != nil || usage.Msg.Objects != 3 || usage.Msg.Bytes != 20 {
+		t.Errorf("usage not recomputed from the index: %v (%v)", usage, err)
+	}
+
+	// Once indexed, files dropped in by hand are not objects.
+	os.WriteFile(filepath.Join(dir, "old", "later"), []byte("ignored"), 0644)
+	list, err = server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "old"}))
+	if err != nil || slices.Contains(list.Msg.ObjectNames, "later") {
+		t.Errorf("unindexed file listed: %v (%v)", list, err)
+	}
+	if _, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "old", Name: "later"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("expected NotFound for an unindexed file, got %v", err)
+	}
+}
@@ -0,0 +1,309 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KMS wraps data encryption keys with named key-encryption keys, in the
+// manner of Cloud KMS. Key names take the Cloud KMS form
+// "projects/{p}/locations/{l}/keyRings/{r}/cryptoKeys/{k}"; versions append
+// "/cryptoKeyVersions/{n}". Ciphertext identifies the version that produced
+// it, so rotation never breaks decryption of older data.
+type KMS interface {
+	// Encrypt encrypts plaintext with the primary version of key and returns
+	// the version used.
+	Encrypt(ctx context.Context, key string, plaintext []byte) (ciphertext []byte, version string, err error)
+	Decrypt(ctx context.Context, key string, ciphertext []byte) ([]byte, error)
+	// PrimaryVersion returns the version Encrypt currently uses.
+	PrimaryVersion(ctx context.Context, key string) (string, error)
+	// Rotate creates a new version of key, makes it primary and returns it.
+	Rotate(ctx context.Context, key string) (string, error)
+}
+
+func validKMSKeyName(name string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) == 8 && parts[0] == "projects" && parts[2] == "locations" && parts[4] == "keyRings" && parts[6] == "cryptoKeys" &&
+		parts[1] != "" && parts[3] != "" && parts[5] != "" && parts[7] != ""
+}
+
+func keyVersionName(key string, version uint32) string {
+	return key + "/cryptoKeyVersions/" + strconv.FormatUint(uint64(version), 10)
+}
+
+// localKeyring is a KMS backed by a JSON file of AES-256 key versions. Keys
+// are created on first use. It suits development: the keyring protects data
+// only as well as the file itself is protected.
+type localKeyring struct {
+	path string
+
+	mu   sync.Mutex
+	keys map[string]*localKey
+}
+
+type localKey struct {
+	Primary  uint32            `json:"primary"`
+	Versions map[uint32][]byte `json:"versions"`
+}
+
+// NewLocalKeyring opens the keyring file at path, creating it when absent.
+func NewLocalKeyring(path string) (KMS, error) {
+	k := &localKeyring{path: path, keys: map[string]*localKey{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return k, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring: %v", err)
+	}
+	if err := json.Unmarshal(data, &k.keys); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring: %v", err)
+	}
+	return k, nil
+}
+
+// save writes keys as the keyring through a temporary file so a crash never
+// leaves it truncated. Callers hold mu.
+func (k *localKeyring) save(keys map[string]*localKey) error {
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(k.path), 0700); err != nil {
+		return err
+	}
+	tmp := k.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, k.path)
+}
+
+// put stores key as name once the keyring file holds it, so that nothing is
+// encrypted with key material a restart would lose. Callers hold mu.
+func (k *localKeyring) put(name string, key *localKey) error {
+	keys := maps.Clone(k.keys)
+	keys[name] = key
+	if err := k.save(keys); err != nil {
+		return fmt.Errorf("failed to save keyring: %v", err)
+	}
+	k.keys = keys
+	return nil
+}
+
+// key returns the named key, creating it when create is set. Callers hold mu.
+func (k *localKeyring) key(name string, create bool) (*localKey, error) {
+	if !validKMSKeyName(name) {
+		return nil, fmt.Errorf("invalid KMS key name: %q", name)
+	}
+	if key, ok := k.keys[name]; ok {
+		return key, nil
+	}
+	if !create {
+		return nil, fmt.Errorf("KMS key not found: %s", name)
+	}
+	key := &localKey{Versions: map[uint32][]byte{}}
+	if err := key.addVersion(); err != nil {
+		return nil, err
+	}
+	return key, k.put(name, key)
+}
+
+func (key *localKey) addVersion() error {
+	material := make([]byte, 32)
+	if _, err := rand.Read(material); err != nil {
+		return err
+	}
+	key.Primary++
+	key.Versions[key.Primary] = material
+	return nil
+}
+
+func localAEAD(material []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(material)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt produces version (4 bytes, big endian) || nonce || sealed data.
+func (k *localKeyring) Encrypt(_ context.Context, name string, plaintext []byte) ([]byte, string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, err := k.key(name, true)
+	if err != nil {
+		return nil, "", err
+	}
+	aead, err := localAEAD(key.Versions[key.Primary])
+	if err != nil {
+		return nil, "", err
+	}
+	out := binary.BigEndian.AppendUint32(nil, key.Primary)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", err
+	}
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, []byte(name)), keyVersionName(name, key.Primary), nil
+}
+
+func (k *localKeyring) Decrypt(_ context.Context, name string, ciphertext []byte) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, err := k.key(name, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < 4 {
+		return nil, errors.New("ciphertext is truncated")
+	}
+	material, ok := key.Versions[binary.BigEndian.Uint32(ciphertext)]
+	if !ok {
+		return nil, fmt.Errorf("key version %d of %s not found", binary.BigEndian.Uint32(ciphertext), name)
+	}
+	aead, err := localAEAD(material)
+	if err != nil {
+		return nil, err
+	}
+	rest := ciphertext[4:]
+	if len(rest) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is truncated")
+	}
+	return aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], []byte(name))
+}
+
+func (k *localKeyring) PrimaryVersion(_ context.Context, name string) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, err := k.key(name, true)
+	if err != nil {
+		return "", err
+	}
+	return keyVersionName(name, key.Primary), nil
+}
+
+func (k *localKeyring) Rotate(_ context.Context, name string) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, err := k.key(name, false)
+	if err != nil {
+		return "", err
+	}
+	rotated := &localKey{Primary: key.Primary, Versions: maps.Clone(key.Versions)}
+	if err := rotated.addVersion(); err != nil {
+		return "", err
+	}
+	if err := k.put(name, rotated); err != nil {
+		return "", err
+	}
+	return keyVersionName(name, rotated.Primary), nil
+}
+
+// httpKMS talks to a KMS emulator exposing the Cloud KMS REST API, such as
+// the sibling Vault/KMS workspace.
+type httpKMS struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewKMSClient returns a KMS backed by the Cloud KMS REST API at endpoint
+// (for example "http://localhost:8092").
+func NewKMSClient(endpoint string) KMS {
+	return &httpKMS{endpoint: strings.TrimRight(endpoint, "/"), client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (h *httpKMS) call(ctx context.Context, method, resource string, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, h.endpoint+"/v1/"+resource, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("kms %s %s returned %s", method, resource, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (h *httpKMS) Encrypt(ctx context.Context, key string, plaintext []byte) ([]byte, string, error) {
+	var out struct {
+		Name       string `json:"name"`
+		Ciphertext string `json:"ciphertext"`
+	}
+	err := h.call(ctx, http.MethodPost, key+":encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}, &out)
+	if err != nil {
+		return nil, "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(out.Ciphertext)
+	return ciphertext, out.Name, err
+}
+
+func (h *httpKMS) Decrypt(ctx context.Context, key string, ciphertext []byte) ([]byte, error) {
+	var out struct {
+		Plaintext string `json:"plaintext"`
+	}
+	err := h.call(ctx, http.MethodPost, key+":decrypt", map[string]string{"ciphertext": base64.StdEncoding.EncodeToString(ciphertext)}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(out.Plaintext)
+}
+
+func (h *httpKMS) PrimaryVersion(ctx context.Context, key string) (string, error) {
+	var out struct {
+		Primary struct {
+			Name string `json:"name"`
+		} `json:"primary"`
+	}
+	if err := h.call(ctx, http.MethodGet, key, nil, &out); err != nil {
+		return "", err
+	}
+	return out.Primary.Name, nil
+}
+
+// Rotate creates a key version and promotes it, as Cloud KMS manual
+// rotation does.
+func (h *httpKMS) Rotate(ctx context.Context, key string) (string, error) {
+	var version struct {
+		Name string `json:"name"`
+	}
+	if err := h.call(ctx, http.MethodPost, key+"/cryptoKeyVersions", map[string]any{}, &version); err != nil {
+		return "", err
+	}
+	var updated map[string]any
+	if err := h.call(ctx, http.MethodPost, key+":updatePrimaryVersion", map[string]string{"cryptoKeyVersionId": path.Base(version.Name)}, &updated); err != nil {
+		return "", err
+	}
+	return version.Name, nil
+}
@@ -0,0 +1,179 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	lifecycleDelete          = "Delete"
+	lifecycleSetStorageClass = "SetStorageClass"
+)
+
+// lifecycleRule is the stored form of a bucket lifecycle rule.
+type lifecycleRule struct {
+	Action              string   `json:"action"`
+	StorageClass        string   `json:"storageClass,omitempty"`
+	AgeDays             int32    `json:"ageDays,omitempty"`
+	MatchesStorageClass []string `json:"matchesStorageClass,omitempty"`
+	MatchesPrefix       []string `json:"matchesPrefix,omitempty"`
+}
+
+func lifecycleRulesFromProto(rules []*storagev1.LifecycleRule) ([]lifecycleRule, error) {
+	var out []lifecycleRule
+	for _, r := range rules {
+		rule := lifecycleRule{
+			Action:              r.GetAction().GetType(),
+			AgeDays:             r.GetCondition().GetAgeDays(),
+			MatchesStorageClass: r.GetCondition().GetMatchesStorageClass(),
+			MatchesPrefix:       r.GetCondition().GetMatchesPrefix(),
+		}
+		switch rule.Action {
+		case lifecycleDelete:
+		case lifecycleSetStorageClass:
+			class, err := normalizeStorageClass(r.GetAction().GetStorageClass(), "")
+			if err != nil || class == "" {
+				return nil, fmt.Errorf("SetStorageClass rule needs a valid storage class: %q", r.GetAction().GetStorageClass())
+			}
+			rule.StorageClass = class
+		default:
+			return nil, fmt.Errorf("unsupported lifecycle action: %q", rule.Action)
+		}
+		if rule.AgeDays < 0 {
+			return nil, errors.New("lifecycle age must not be negative")
+		}
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+func lifecycleRulesToProto(rules []lifecycleRule) []*storagev1.LifecycleRule {
+	var out []*storagev1.LifecycleRule
+	for _, r := range rules {
+		out = append(out, &storagev1.LifecycleRule{
+			Action: &storagev1.LifecycleRule_Action{Type: r.Action, StorageClass: r.StorageClass},
+			Condition: &storagev1.LifecycleRule_Condition{
+				AgeDays:             r.AgeDays,
+				MatchesStorageClass: r.MatchesStorageClass,
+				MatchesPrefix:       r.MatchesPrefix,
+			},
+		})
+	}
+	return out
+}
+
+func (r *lifecycleRule) matches(obj *objectRecord, now time.Time) bool {
+	if now.Sub(obj.TimeCreated) < time.Duration(r.AgeDays)*day {
+		return false
+	}
+	if len(r.MatchesStorageClass) > 0 && !containsString(r.MatchesStorageClass, obj.StorageClass) {
+		return false
+	}
+	if len(r.MatchesPrefix) > 0 {
+		for _, p := range r.MatchesPrefix {
+			if strings.HasPrefix(obj.Name, p) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyLifecycle evaluates every bucket's lifecycle rules against its objects
+// as of now. As in GCS, Delete wins over SetStorageClass, and a class is only
+// ever moved colder. Deletions are subject to early-deletion accounting;
+// class transitions are not and keep the original creation time.
+func (s *StorageServer) ApplyLifecycle(ctx context.Context, now time.Time) error {
+	var events []objectEvent
+	err := s.update(ctx, func(tx *bbolt.Tx) error {
+		type action struct {
+			obj   *objectRecord
+			rule  lifecycleRule
+			class string
+		}
+		var actions []action
+
+		err := tx.Bucket([]byte(bucketBuckets)).ForEach(func(k, _ []byte) error {
+			bucket, err := s.getBucketRecord(tx, string(k))
+			if err != nil || len(bucket.Lifecycle) == 0 {
+				return err
+			}
+			return s.scanObjects(tx, bucket.Name, "", "", func(obj *objectRecord) (bool, error) {
+				var chosen *action
+				for _, rule := range bucket.Lifecycle {
+					if !rule.matches(obj, now) {
+						continue
+					}
+					if rule.Action == lifecycleDelete {
+						chosen = &action{obj: obj, rule: rule}
+						break
+					}
+					if storageClassRank(rule.StorageClass) > storageClassRank(obj.StorageClass) &&
+						(chosen == nil || storageClassRank(rule.StorageClass) > storageClassRank(chosen.class)) {
+						chosen = &action{obj: obj, rule: rule, class: rule.StorageClass}
+					}
+				}
+				if chosen != nil {
+					actions = append(actions, *chosen)
+				}
+				return true, nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, a := range actions {
+			if a.rule.Action == lifecycleDelete {
+				slog.Info("Lifecycle delete", "bucket", a.obj.Bucket, "name", a.obj.Name)
+				if err := s.recordEarlyDeletion(tx, a.obj, now, "lifecycle"); err != nil {
+					return err
+				}
+				if err := s.deleteObjectRecord(tx, a.obj.Bucket, a.obj.Name); err != nil {
+					return err
+				}
+				events = append(events, objectEvent{eventType: EventObjectDelete, object: a.obj})
+				continue
+			}
+			slog.Info("Lifecycle storage class change", "bucket", a.obj.Bucket, "name", a.obj.Name, "from", a.obj.StorageClass, "to", a.class)
+			a.obj.StorageClass = a.class
+			a.obj.TimeStorageClassUpdated = now
+			a.obj.Updated = now
+			a.obj.Metageneration++
+			if err := s.putObjectRecord(tx, a.obj); err != nil {
+				return err
+			}
+			events = append(events, objectEvent{eventType: EventObjectMetadataUpdate, object: a.obj})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if e.eventType == EventObjectDelete {
+			s.removeObjectData(ctx, e.object.Bucket, e.object.Name)
+		}
+	}
+	s.emit(events...)
+	return nil
+}
@@ -0,0 +1,28 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"fmt"
+	"os"
+
+	"go.etcd.io/bbolt"
+	"golang.org/x/sys/unix"
+)
+
+// openMemoryDB opens a BoltDB backed by an anonymous memory file, so the
+// database never touches a filesystem. The memory is released when the
+// database is closed.
+func openMemoryDB() (*bbolt.DB, func(), error) {
+	fd, err := unix.MemfdCreate("storage.db", unix.MFD_CLOEXEC)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create memory file: %v", err)
+	}
+	f := os.NewFile(uintptr(fd), "storage.db")
+	defer f.Close()
+	db, err := bbolt.Open(fmt.Sprintf("/proc/self/fd/%d", fd), 0600, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return db, nil, nil
+}
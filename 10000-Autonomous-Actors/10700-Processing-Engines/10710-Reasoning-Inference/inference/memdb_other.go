@@ -0,0 +1,26 @@
+//go:build !linux && !wasm
+
+package inference
+
+import (
+	"os"
+
+	"go.etcd.io/bbolt"
+)
+
+// openMemoryDB opens a BoltDB in a temporary file, removed when the database
+// is closed. Only Linux offers anonymous memory files to back it instead.
+func openMemoryDB() (*bbolt.DB, func(), error) {
+	f, err := os.CreateTemp("", "storage-*.db")
+	if err != nil {
+		return nil, nil, err
+	}
+	path := f.Name()
+	f.Close()
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		os.Remove(path)
+		return nil, nil, err
+	}
+	return db, func() { os.Remove(path) }, nil
+}
@@ -0,0 +1,100 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithInMemory keeps object data and BoltDB metadata in memory. Nothing is
+// written under the storage directory, which may be empty, and everything
+// is discarded on Close.
+func WithInMemory() Option {
+	return func(s *StorageServer) {
+		s.inMemory = true
+	}
+}
+
+type memObject struct {
+	data     []byte
+	modified time.Time
+}
+
+// memBackend holds object data in a map keyed by "bucket/name".
+type memBackend struct {
+	mu      sync.RWMutex
+	objects map[string]memObject
+}
+
+// NewMemoryBackend returns a Backend that keeps object data in memory.
+func NewMemoryBackend() Backend {
+	return &memBackend{objects: map[string]memObject{}}
+}
+
+func (b *memBackend) Put(_ context.Context, bucket, name string, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objects[objectKey(bucket, name)] = memObject{data: bytes.Clone(data), modified: time.Now().UTC()}
+	return nil
+}
+
+func (b *memBackend) object(bucket, name string) (memObject, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	obj, ok := b.objects[objectKey(bucket, name)]
+	if !ok {
+		return memObject{}, fmt.Errorf("%s: %w", objectKey(bucket, name), fs.ErrNotExist)
+	}
+	return obj, nil
+}
+
+// Get serves the stored slice directly: Put never modifies data in place.
+func (b *memBackend) Get(_ context.Context, bucket, name string, offset, length int64) (io.ReadCloser, error) {
+	obj, err := b.object(bucket, name)
+	if err != nil {
+		return nil, err
+	}
+	size := int64(len(obj.data))
+	offset = min(max(offset, 0), size)
+	end := size
+	if length >= 0 {
+		end = min(offset+length, size)
+	}
+	return io.NopCloser(bytes.NewReader(obj.data[offset:end])), nil
+}
+
+func (b *memBackend) Stat(_ context.Context, bucket, name string) (ObjectInfo, error) {
+	obj, err := b.object(bucket, name)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return ObjectInfo{Size: int64(len(obj.data)), Modified: obj.modified}, nil
+}
+
+func (b *memBackend) Delete(_ context.Context, bucket, name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.objects, objectKey(bucket, name))
+	return nil
+}
+
+func (b *memBackend) List(_ context.Context, bucket, prefix string) ([]string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var names []string
+	for key := range b.objects {
+		if name, ok := strings.CutPrefix(key, bucket+"/"); ok && strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,42 @@
+package inference
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	t.Parallel()
+	testBackend(t, NewMemoryBackend())
+}
+
+func TestInMemoryServer(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "unused")
+	ctx := context.Background()
+	first := NewStorageServer(dir, WithInMemory())
+	second := NewStorageServer(dir, WithInMemory())
+	defer second.Close()
+
+	first.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "ephemeral"}))
+	if _, err := first.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "ephemeral", Name: "a", Data: []byte("hello")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	meta, err := first.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "ephemeral", Name: "a"}))
+	if err != nil || meta.Msg.Size != 5 {
+		t.Errorf("unexpected metadata: %v (%v)", meta, err)
+	}
+	if _, err := second.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: "ephemeral"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("in-memory servers must not share state, got %v", err)
+	}
+	first.Close()
+
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("in-memory server wrote to %s: %v", dir, err)
+	}
+}
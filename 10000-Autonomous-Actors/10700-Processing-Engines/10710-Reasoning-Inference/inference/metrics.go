@@ -0,0 +1,240 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the request latency
+// histogram: the Prometheus client defaults.
+var latencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics counts StorageService requests as a Connect interceptor and
+// serves them, with gauges read from the metadata store at scrape time, in
+// the Prometheus text format.
+type Metrics struct {
+	server *StorageServer
+
+	mu       sync.Mutex
+	requests map[requestKey]*latencyHistogram
+
+	uploaded   atomic.Int64
+	downloaded atomic.Int64
+}
+
+type requestKey struct{ procedure, code string }
+
+type latencyHistogram struct {
+	counts []uint64 // per bucket of latencyBuckets, not cumulative
+	count  uint64
+	sum    float64
+}
+
+func NewMetrics(server *StorageServer) *Metrics {
+	return &Metrics{server: server, requests: map[requestKey]*latencyHistogram{}}
+}
+
+func (m *Metrics) observe(procedure string, err error, elapsed time.Duration) {
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.requests[requestKey{procedure, code}]
+	if !ok {
+		h = &latencyHistogram{counts: make([]uint64, len(latencyBuckets))}
+		m.requests[requestKey{procedure, code}] = h
+	}
+	seconds := elapsed.Seconds()
+	if i, _ := slices.BinarySearch(latencyBuckets, seconds); i < len(latencyBuckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// countData adds the object data a message carries to the transfer totals.
+func (m *Metrics) countData(msg any) {
+	switch msg := msg.(type) {
+	case *storagev1.UploadObjectRequest:
+		m.uploaded.Add(int64(len(msg.Data)))
+	case *storagev1.WriteObjectRequest:
+		m.uploaded.Add(int64(len(msg.Data)))
+	case *storagev1.ReadObjectResponse:
+		m.downloaded.Add(int64(len(msg.Data)))
+	}
+}
+
+func (m *Metrics) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		start := time.Now()
+		resp, err := next(ctx, req)
+		m.observe(req.Spec().Procedure, err, time.Since(start))
+		if err == nil {
+			m.countData(req.Any())
+			m.countData(resp.Any())
+		}
+		return resp, err
+	}
+}
+
+func (m *Metrics) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler times the whole stream and counts the data of each
+// message as it passes.
+func (m *Metrics) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		err := next(ctx, &meteredConn{StreamingHandlerConn: conn, metrics: m})
+		m.observe(conn.Spec().Procedure, err, time.Since(start))
+		return err
+	}
+}
+
+type meteredConn struct {
+	connect.StreamingHandlerConn
+	metrics *Metrics
+}
+
+func (c *meteredConn) Receive(msg any) error {
+	err := c.StreamingHandlerConn.Receive(msg)
+	if err == nil {
+		c.metrics.countData(msg)
+	}
+	return err
+}
+
+func (c *meteredConn) Send(msg any) error {
+	err := c.StreamingHandlerConn.Send(msg)
+	if err == nil {
+		c.metrics.countData(msg)
+	}
+	return err
+}
+
+// bucketUsage returns the usage totals of every bucket.
+func (s *StorageServer) bucketUsage() (map[string]usageRecord, error) {
+	usage := map[string]usageRecord{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte(bucketBuckets)).ForEach(func(k, _ []byte) error {
+			u, err := s.getUsage(tx, bucketUsageKey(string(k)))
+			usage[string(k)] = u
+			return err
+		})
+	})
+	return usage, err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	usage, err := m.server.bucketUsage()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read bucket usage: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	out := bufio.NewWriter(w)
+	defer out.Flush()
+
+	m.mu.Lock()
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b requestKey) int {
+		return strings.Compare(a.procedure+" "+a.code, b.procedure+" "+b.code)
+	})
+	header(out, "storage_requests_total", "counter", "StorageService requests handled, by procedure and Connect code.")
+	for _, k := range keys {
+		fmt.Fprintf(out, "storage_requests_total{procedure=%q,code=%q} %d\n", k.procedure, k.code, m.requests[k].count)
+	}
+	header(out, "storage_request_duration_seconds", "histogram", "StorageService request latency, by procedure and Connect code.")
+	for _, k := range keys {
+		h := m.requests[k]
+		labels := fmt.Sprintf("procedure=%q,code=%q", k.procedure, k.code)
+		var cumulative uint64
+		for i, le := range latencyBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(out, "storage_request_duration_seconds_bucket{%s,le=\"%g\"} %d\n", labels, le, cumulative)
+		}
+		fmt.Fprintf(out, "storage_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(out, "storage_request_duration_seconds_sum{%s} %g\n", labels, h.sum)
+		fmt.Fprintf(out, "storage_request_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+	m.mu.Unlock()
+
+	header(out, "storage_uploaded_bytes_total", "counter", "Object data received by UploadObject and WriteObject.")
+	fmt.Fprintf(out, "storage_uploaded_bytes_total %d\n", m.uploaded.Load())
+	header(out, "storage_downloaded_bytes_total", "counter", "Object data sent by ReadObject.")
+	fmt.Fprintf(out, "storage_downloaded_bytes_total %d\n", m.downloaded.Load())
+
+	buckets := make([]string, 0, len(usage))
+	for name := range usage {
+		buckets = append(buckets, name)
+	}
+	slices.Sort(buckets)
+	header(out, "storage_bucket_objects", "gauge", "Live objects per bucket.")
+	for _, name := range buckets {
+		fmt.Fprintf(out, "storage_bucket_objects{bucket=%q} %d\n", name, usage[name].Objects)
+	}
+	header(out, "storage_bucket_bytes", "gauge", "Bytes of live objects per bucket.")
+	for _, name := range buckets {
+		fmt.Fprintf(out, "storage_bucket_bytes{bucket=%q} %d\n", name, usage[name].Bytes)
+	}
+
+	stats := m.server.db.Stats()
+	for _, g := range []struct {
+		name, kind, help string
+		value            float64
+	}{
+		{"storage_boltdb_read_transactions_total", "counter", "BoltDB read transactions started.", float64(stats.TxN)},
+		{"storage_boltdb_open_read_transactions", "gauge", "BoltDB read transactions currently open.", float64(stats.OpenTxN)},
+		{"storage_boltdb_free_pages", "gauge", "BoltDB pages on the freelist.", float64(stats.FreePageN)},
+		{"storage_boltdb_pending_pages", "gauge", "BoltDB pages freed but held by open transactions.", float64(stats.PendingPageN)},
+		{"storage_boltdb_page_allocations_total", "counter", "BoltDB page allocations by transactions.", float64(stats.TxStats.GetPageCount())},
+		{"storage_boltdb_writes_total", "counter", "BoltDB writes to disk by transactions.", float64(stats.TxStats.GetWrite())},
+		{"storage_boltdb_write_seconds_total", "counter", "Time BoltDB transactions spent writing to disk.", stats.TxStats.GetWriteTime().Seconds()},
+	} {
+		header(out, g.name, g.kind, g.help)
+		fmt.Fprintf(out, "%s %g\n", g.name, g.value)
+	}
+
+	if fds, err := openFiles(); err == nil {
+		header(out, "process_open_fds", "gauge", "Open file descriptors of the process.")
+		fmt.Fprintf(out, "process_open_fds %d\n", fds)
+	}
+}
+
+func header(out *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// openFiles counts the descriptors the process holds open. Only Linux
+// exposes them, under /proc.
+func openFiles() (int, error) {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
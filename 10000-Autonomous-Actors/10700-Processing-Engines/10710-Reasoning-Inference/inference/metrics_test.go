@@ -0,0 +1,100 @@
+package inference
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	metrics := NewMetrics(server)
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(metrics)))
+	mux.Handle("/metrics", metrics)
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	ctx := context.Background()
+
+	client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "metered"}))
+	client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "empty"}))
+	client.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "metered", Name: "a", Data: []byte("hello")}))
+	write := client.WriteObject(ctx)
+	write.Send(&storagev1.WriteObjectRequest{Spec: &storagev1.UploadObjectRequest{Bucket: "metered", Name: "b"}, Data: []byte("abc")})
+	write.Send(&storagev1.WriteObjectRequest{Data: []byte("def")})
+	if _, err := write.CloseAndReceive(); err != nil {
+		t.Fatalf("WriteObject failed: %v", err)
+	}
+	read, err := client.ReadObject(ctx, connect.NewRequest(&storagev1.ReadObjectRequest{Bucket: "metered", Name: "a"}))
+	if err != nil {
+		t.Fatalf("ReadObject failed: %v", err)
+	}
+	for read.Receive() {
+	}
+	read.Close()
+	client.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "metered", Name: "missing"}))
+
+	resp, err := http.Get(httpServer.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	const upload = `procedure="/storage.v1.StorageService/UploadObject",code="ok"`
+	for _, want := range []string{
+		`storage_requests_total{` + upload + `} 1`,
+		`storage_requests_total{procedure="/storage.v1.StorageService/WriteObject",code="ok"} 1`,
+		`storage_requests_total{procedure="/storage.v1.StorageService/GetObjectMetadata",code="not_found"} 1`,
+		`storage_request_duration_seconds_bucket{` + upload + `,le="+Inf"} 1`,
+		`storage_request_duration_seconds_count{` + upload + `} 1`,
+		`storage_uploaded_bytes_total 11`,
+		`storage_downloaded_bytes_total 5`,
+		`storage_bucket_objects{bucket="metered"} 2`,
+		`storage_bucket_bytes{bucket="metered"} 11`,
+		`storage_bucket_objects{bucket="empty"} 0`,
+		`# TYPE storage_boltdb_read_transactions_total counter`,
+		`# TYPE process_open_fds gauge`,
+	} {
+		if !strings.Contains(string(body), want+"\n") {
+			t.Errorf("metrics lack %s", want)
+		}
+	}
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	metrics := NewMetrics(server)
+	for _, d := range []time.Duration{time.Millisecond, 30 * time.Millisecond, 50 * time.Millisecond, time.Minute} {
+		metrics.observe("/p", nil, d)
+	}
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`storage_request_duration_seconds_bucket{procedure="/p",code="ok",le="0.005"} 1`,
+		`storage_request_duration_seconds_bucket{procedure="/p",code="ok",le="0.025"} 1`,
+		`storage_request_duration_seconds_bucket{procedure="/p",code="ok",le="0.05"} 3`,
+		`storage_request_duration_seconds_bucket{procedure="/p",code="ok",le="10"} 3`,
+		`storage_request_duration_seconds_bucket{procedure="/p",code="ok",le="+Inf"} 4`,
+		`storage_request_duration_seconds_sum{procedure="/p",code="ok"} 60.081`,
+	} {
+		if !strings.Contains(rec.Body.String(), want+"\n") {
+			t.Errorf("histogram lacks %s", want)
+		}
+	}
+}
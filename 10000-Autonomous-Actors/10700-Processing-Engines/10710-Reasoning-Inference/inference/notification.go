@@ -0,0 +1,399 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	bucketNotifications = "notifications"
+
+	EventObjectFinalize       = "OBJECT_FINALIZE"
+	EventObjectDelete         = "OBJECT_DELETE"
+	EventObjectMetadataUpdate = "OBJECT_METADATA_UPDATE"
+	EventObjectArchive        = "OBJECT_ARCHIVE"
+
+	payloadJSONAPIV1 = "JSON_API_V1"
+	payloadNone      = "NONE"
+
+	// notificationQueueSize bounds events waiting to be published before
+	// further events are dropped.
+	notificationQueueSize = 1024
+)
+
+var eventTypes = []string{EventObjectFinalize, EventObjectDelete, EventObjectMetadataUpdate, EventObjectArchive}
+
+// notificationRecord is stored in the "notifications" bucket under
+// "bucket/id".
+type notificationRecord struct {
+	ID               string            `json:"id"`
+	Bucket           string            `json:"bucket"`
+	Topic            string            `json:"topic"`
+	EventTypes       []string          `json:"eventTypes,omitempty"`
+	ObjectNamePrefix string            `json:"objectNamePrefix,omitempty"`
+	CustomAttributes map[string]string `json:"customAttributes,omitempty"`
+	PayloadFormat    string            `json:"payloadFormat"`
+}
+
+func (r *notificationRecord) toProto() *storagev1.NotificationConfig {
+	return &storagev1.NotificationConfig{
+		Id:               r.ID,
+		Topic:            r.Topic,
+		EventTypes:       r.EventTypes,
+		ObjectNamePrefix: r.ObjectNamePrefix,
+		CustomAttributes: r.CustomAttributes,
+		PayloadFormat:    r.PayloadFormat,
+	}
+}
+
+func (r *notificationRecord) matches(eventType, objectName string) bool {
+	if len(r.EventTypes) > 0 && !containsString(r.EventTypes, eventType) {
+		return false
+	}
+	return strings.HasPrefix(objectName, r.ObjectNamePrefix)
+}
+
+// topicPath strips the optional "//pubsub.googleapis.com/" prefix GCS
+// accepts on topic names.
+func topicPath(topic string) string {
+	return strings.TrimPrefix(topic, "//pubsub.googleapis.com/")
+}
+
+func notificationFromProto(bucket string, cfg *storagev1.NotificationConfig) (*notificationRecord, error) {
+	if cfg == nil {
+		return nil, errors.New("notification_config is required")
+	}
+	topic := topicPath(cfg.Topic)
+	parts := strings.Split(topic, "/")
+	if len(parts) != 4 || parts[0] != "projects" || parts[2] != "topics" || parts[1] == "" || parts[3] == "" {
+		return nil, fmt.Errorf("topic must be projects/{project}/topics/{topic}: %q", cfg.Topic)
+	}
+	for _, e := range cfg.EventTypes {
+		if !containsString(eventTypes, e) {
+			return nil, fmt.Errorf("unsupported event type: %q", e)
+		}
+	}
+	format := cfg.PayloadFormat
+	if format == "" {
+		format = payloadJSONAPIV1
+	}
+	if format != payloadJSONAPIV1 && format != payloadNone {
+		return nil, fmt.Errorf("unsupported payload format: %q", format)
+	}
+	return &notificationRecord{
+		Bucket:           bucket,
+		Topic:            topic,
+		EventTypes:       cfg.EventTypes,
+		ObjectNamePrefix: cfg.ObjectNamePrefix,
+		CustomAttributes: copyStrings(cfg.CustomAttributes),
+		PayloadFormat:    format,
+	}, nil
+}
+
+// WithPubSubEndpoint publishes object change notifications to the Pub/Sub
+// emulator REST API at endpoint (for example "http://localhost:8085").
+// Without it, notification configs are stored but nothing is published.
+func WithPubSubEndpoint(endpoint string) Option {
+	return func(s *StorageServer) {
+		s.notifier = newNotifier(strings.TrimRight(endpoint, "/"))
+	}
+}
+
+// objectEvent is a change to report once the transaction that made it has
+// committed.
+type objectEvent struct {
+	eventType string
+	object    *objectRecord
+	attrs     map[string]string
+}
+
+// overwriteEvents returns the events for replacing old (if any) with obj.
+func overwriteEvents(old, obj *objectRecord) []objectEvent {
+	if old == nil {
+		return []objectEvent{{eventType: EventObjectFinalize, object: obj}}
+	}
+	return []objectEvent{
+		{eventType: EventObjectFinalize, object: obj, attrs: map[string]string{"overwroteGeneration": strconv.FormatInt(old.Generation, 10)}},
+		{eventType: EventObjectDelete, object: old, attrs: map[string]string{"overwrittenByGeneration": strconv.FormatInt(obj.Generation, 10)}},
+	}
+}
+
+// pubsubMessage is a message in a Pub/Sub REST publish request.
+type pubsubMessage struct {
+	Data       string            `json:"data,omitempty"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+type pendingPublish struct {
+	topic   string
+	message pubsubMessage
+}
+
+// notifier publishes messages in order from a single background worker so
+// RPC handlers never wait on the Pub/Sub emulator.
+type notifier struct {
+	endpoint string
+	client   *http.Client
+	queue    chan pendingPublish
+	done     sync.WaitGroup
+}
+
+func newNotifier(endpoint string) *notifier {
+	n := &notifier{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		queue:    make(chan pendingPublish, notificationQueueSize),
+	}
+	n.done.Add(1)
+	go n.run()
+	return n
+}
+
+func (n *notifier) run() {
+	defer n.done.Done()
+	for p := range n.queue {
+		if err := n.publish(p); err != nil {
+			slog.Error("Failed to publish notification", "topic", p.topic, "error", err)
+		}
+	}
+}
+
+func (n *notifier) publish(p pendingPublish) error {
+	body, err := json.Marshal(map[string]any{"messages": []pubsubMessage{p.message}})
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(n.endpoint+"/v1/"+p.topic+":publish", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pubsub publish returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (n *notifier) enqueue(p pendingPublish) {
+	select {
+	case n.queue <- p:
+	default:
+		slog.Error("Notification queue full, dropping event", "topic", p.topic)
+	}
+}
+
+// close publishes anything still queued and stops the worker.
+func (n *notifier) close() {
+	close(n.queue)
+	n.done.Wait()
+}
+
+// emit publishes events to every matching notification config of their
+// buckets.
+func (s *StorageServer) emit(events ...objectEvent) {
+	if s.notifier == nil || len(events) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	for _, ev := range events {
+		var configs []*notificationRecord
+		err := s.db.View(func(tx *bbolt.Tx) error {
+			var err error
+			configs, err = s.listNotificationRecords(tx, ev.object.Bucket)
+			return err
+		})
+		if err != nil {
+			slog.Error("Failed to load notification configs", "bucket", ev.object.Bucket, "error", err)
+			continue
+		}
+		for _, cfg := range configs {
+			if !cfg.matches(ev.eventType, ev.object.Name) {
+				continue
+			}
+			s.notifier.enqueue(pendingPublish{topic: cfg.Topic, message: notificationMessage(cfg, ev, now)})
+		}
+	}
+}
+
+// notificationMessage builds the message GCS would publish for ev.
+func notificationMessage(cfg *notificationRecord, ev objectEvent, now time.Time) pubsubMessage {
+	attrs := map[string]string{
+		"notificationConfig": fmt.Sprintf("projects/_/buckets/%s/notificationConfigs/%s", cfg.Bucket, cfg.ID),
+		"eventType":          ev.eventType,
+		"payloadFormat":      cfg.PayloadFormat,
+		"bucketId":           ev.object.Bucket,
+		"objectId":           ev.object.Name,
+		"objectGeneration":   strconv.FormatInt(ev.object.Generation, 10),
+		"eventTime":          now.Format("2006-01-02T15:04:05.000000Z07:00"),
+	}
+	for k, v := range ev.attrs {
+		attrs[k] = v
+	}
+	for k, v := range cfg.CustomAttributes {
+		attrs[k] = v
+	}
+	msg := pubsubMessage{Attributes: attrs}
+	if cfg.PayloadFormat == payloadJSONAPIV1 {
+		data, _ := json.Marshal(objectResource(ev.object))
+		msg.Data = base64.StdEncoding.EncodeToString(data)
+	}
+	return msg
+}
+
+// objectResource renders obj as a GCS JSON API object resource.
+func objectResource(obj *objectRecord) map[string]any {
+	gen := strconv.FormatInt(obj.Generation, 10)
+	res := map[string]any{
+		"kind":                    "storage#object",
+		"id":                      obj.Bucket + "/" + obj.Name + "/" + gen,
+		"name":                    obj.Name,
+		"bucket":                  obj.Bucket,
+		"generation":              gen,
+		"metageneration":          strconv.FormatInt(obj.Metageneration, 10),
+		"size":                    strconv.FormatInt(obj.Size, 10),
+		"storageClass":            obj.StorageClass,
+		"timeCreated":             obj.TimeCreated.Format(time.RFC3339Nano),
+		"updated":                 obj.Updated.Format(time.RFC3339Nano),
+		"timeStorageClassUpdated": obj.TimeStorageClassUpdated.Format(time.RFC3339Nano),
+	}
+	if len(obj.Metadata) > 0 {
+		res["metadata"] = obj.Metadata
+	}
+	if obj.CustomerEncryption != nil {
+		res["customerEncryption"] = customerEncryptionResource(obj.CustomerEncryption)
+	}
+	if obj.KMS != nil {
+		res["kmsKeyName"] = obj.KMS.KeyVersion
+	}
+	return res
+}
+
+func (s *StorageServer) listNotificationRecords(tx *bbolt.Tx, bucket string) ([]*notificationRecord, error) {
+	var out []*notificationRecord
+	prefix := bucket + "/"
+	b := tx.Bucket([]byte(bucketNotifications))
+	c := b.Cursor()
+	for k, v := c.Seek([]byte(prefix)); k != nil && strings.HasPrefix(string(k), prefix); k, v = c.Next() {
+		var rec notificationRecord
+		if err := s.decodeRecord(bucketNotifications, string(k), v, &rec); err != nil {
+			return nil, err
+		}
+		out = append(out, &rec)
+	}
+	return out, nil
+}
+
+func (s *StorageServer) getNotificationRecord(tx *bbolt.Tx, bucket, id string) (*notificationRecord, error) {
+	var rec notificationRecord
+	found, err := s.getRecord(tx.Bucket([]byte(bucketNotifications)), bucketNotifications, bucket+"/"+id, &rec)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("notification config not found: %s/%s", bucket, id))
+	}
+	return &rec, nil
+}
+
+func (s *StorageServer) CreateNotificationConfig(ctx context.Context, req *connect.Request[storagev1.CreateNotificationConfigRequest]) (*connect.Response[storagev1.CreateNotificationConfigResponse], error) {
+	slog.Info("CreateNotificationConfig", "bucket", req.Msg.Bucket, "topic", req.Msg.GetNotificationConfig().GetTopic())
+	if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permBucketsUpdate); err != nil {
+		return nil, err
+	}
+
+	rec, err := notificationFromProto(req.Msg.Bucket, req.Msg.NotificationConfig)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		if _, err := s.getBucketRecord(tx, rec.Bucket); err != nil {
+			return err
+		}
+		b := tx.Bucket([]byte(bucketNotifications))
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		rec.ID = strconv.FormatUint(seq, 10)
+		return s.putRecord(b, bucketNotifications, rec.Bucket+"/"+rec.ID, rec)
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.CreateNotificationConfigResponse{NotificationConfig: rec.toProto()}), nil
+}
+
+func (s *StorageServer) GetNotificationConfig(ctx context.Context, req *connect.Request[storagev1.GetNotificationConfigRequest]) (*connect.Response[storagev1.GetNotificationConfigResponse], error) {
+	slog.Info("GetNotificationConfig", "bucket", req.Msg.Bucket, "id", req.Msg.Id)
+	if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permBucketsGet); err != nil {
+		return nil, err
+	}
+
+	var rec *notificationRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		rec, err = s.getNotificationRecord(tx, req.Msg.Bucket, req.Msg.Id)
+		return err
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.GetNotificationConfigResponse{NotificationConfig: rec.toProto()}), nil
+}
+
+func (s *StorageServer) ListNotificationConfigs(ctx context.Context, req *connect.Request[storagev1.ListNotificationConfigsRequest]) (*connect.Response[storagev1.ListNotificationConfigsResponse], error) {
+	slog.Info("ListNotificationConfigs", "bucket", req.Msg.Bucket)
+	if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permBucketsGet); err != nil {
+		return nil, err
+	}
+
+	var out []*storagev1.NotificationConfig
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		if _, err := s.getBucketRecord(tx, req.Msg.Bucket); err != nil {
+			return err
+		}
+		recs, err := s.listNotificationRecords(tx, req.Msg.Bucket)
+		for _, rec := range recs {
+			out = append(out, rec.toProto())
+		}
+		return err
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.ListNotificationConfigsResponse{NotificationConfigs: out}), nil
+}
+
+func (s *StorageServer) DeleteNotificationConfig(ctx context.Context, req *connect.Request[storagev1.DeleteNotificationConfigRequest]) (*connect.Response[storagev1.DeleteNotificationConfigResponse], error) {
+	slog.Info("DeleteNotificationConfig", "bucket", req.Msg.Bucket, "id", req.Msg.Id)
+	if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permBucketsUpdate); err != nil {
+		return nil, err
+	}
+
+	err := s.update(ctx, func(tx *bbolt.Tx) error {
+		if _, err := s.getNotificationRecord(tx, req.Msg.Bucket, req.Msg.Id); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(bucketNotifications)).Delete([]byte(req.Msg.Bucket + "/" + req.Msg.Id))
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.DeleteNotificationConfigResponse{}), nil
+}
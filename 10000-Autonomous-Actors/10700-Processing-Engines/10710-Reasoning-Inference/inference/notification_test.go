@@ -0,0 +1,98 @@
+package inference
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+)
+
+func TestNotificationPublishing(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	var messages []pubsubMessage
+	pubsub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []pubsubMessage `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		messages = append(messages, body.Messages...)
+		mu.Unlock()
+		w.Write([]byte(`{"messageIds":["1"]}`))
+	}))
+	defer pubsub.Close()
+
+	server := NewStorageServer("", WithInMemory(), WithPubSubEndpoint(pubsub.URL))
+	ctx := context.Background()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "events"}))
+
+	created, err := server.CreateNotificationConfig(ctx, connect.NewRequest(&storagev1.CreateNotificationConfigRequest{
+		Bucket: "events",
+		NotificationConfig: &storagev1.NotificationConfig{
+			Topic:            "projects/demo/topics/uploads",
+			ObjectNamePrefix: "in/",
+			CustomAttributes: map[string]string{"team": "ingest"},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("CreateNotificationConfig failed: %v", err)
+	}
+	_, err = server.CreateNotificationConfig(ctx, connect.NewRequest(&storagev1.CreateNotificationConfigRequest{
+		Bucket:             "events",
+		NotificationConfig: &storagev1.NotificationConfig{Topic: "uploads"},
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected InvalidArgument for malformed topic, got %v", err)
+	}
+
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "events", Name: "in/a.json", Data: []byte("1")}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "events", Name: "in/a.json", Data: []byte("2")}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "events", Name: "out/skip", Data: []byte("x")}))
+	server.UpdateObject(ctx, connect.NewRequest(&storagev1.UpdateObjectRequest{Bucket: "events", Name: "in/a.json", Metadata: map[string]string{"k": "v"}}))
+	server.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "events", Name: "in/a.json"}))
+
+	list, err := server.ListNotificationConfigs(ctx, connect.NewRequest(&storagev1.ListNotificationConfigsRequest{Bucket: "events"}))
+	if err != nil || len(list.Msg.NotificationConfigs) != 1 || list.Msg.NotificationConfigs[0].PayloadFormat != "JSON_API_V1" {
+		t.Errorf("unexpected configs: %v (%v)", list, err)
+	}
+
+	// Close drains the publish queue.
+	server.Close()
+
+	var got []string
+	for _, m := range messages {
+		got = append(got, m.Attributes["eventType"])
+		if m.Attributes["team"] != "ingest" || m.Attributes["objectId"] != "in/a.json" {
+			t.Errorf("unexpected attributes: %v", m.Attributes)
+		}
+		if m.Attributes["notificationConfig"] != "projects/_/buckets/events/notificationConfigs/"+created.Msg.NotificationConfig.Id {
+			t.Errorf("unexpected notificationConfig attribute: %s", m.Attributes["notificationConfig"])
+		}
+	}
+	want := []string{"OBJECT_FINALIZE", "OBJECT_FINALIZE", "OBJECT_DELETE", "OBJECT_METADATA_UPDATE", "OBJECT_DELETE"}
+	if len(got) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], got[i])
+		}
+	}
+	if paths[0] != "/v1/projects/demo/topics/uploads:publish" {
+		t.Errorf("unexpected publish path: %s", paths[0])
+	}
+
+	data, _ := base64.StdEncoding.DecodeString(messages[0].Data)
+	var resource map[string]any
+	if err := json.Unmarshal(data, &resource); err != nil || resource["kind"] != "storage#object" || resource["size"] != "1" {
+		t.Errorf("unexpected payload: %s (%v)", data, err)
+	}
+}
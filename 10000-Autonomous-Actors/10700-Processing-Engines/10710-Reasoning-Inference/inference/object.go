@@ -0,0 +1,563 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// readChunkSize bounds the payload of each ReadObject response message.
+const readChunkSize = 64 * 1024
+
+var (
+	errObjectNotFound = errors.New("object not found")
+	errObjectChanged  = errors.New("object changed")
+)
+
+// objectRecord is the BoltDB representation of an object, keyed by name in
+// its bucket's nested bucket of "objects".
+type objectRecord struct {
+	Bucket                  string              `json:"bucket"`
+	Name                    string              `json:"name"`
+	Size                    int64               `json:"size"`
+	Metadata                map[string]string   `json:"metadata,omitempty"`
+	StorageClass            string              `json:"storageClass"`
+	Generation              int64               `json:"generation"`
+	Metageneration          int64               `json:"metageneration"`
+	TimeCreated             time.Time           `json:"timeCreated"`
+	Updated                 time.Time           `json:"updated"`
+	TimeStorageClassUpdated time.Time           `json:"timeStorageClassUpdated"`
+	Acl                     []aclEntry          `json:"acl,omitempty"`
+	CustomerEncryption      *customerEncryption `json:"customerEncryption,omitempty"`
+	KMS                     *kmsEnvelope        `json:"kms,omitempty"`
+	// Blobs lists the parts of content-addressed data; objects stored by
+	// name have none.
+	Blobs []blobRef `json:"blobs,omitempty"`
+	// StoredCRC32C checksums the bytes stored by name, ciphertext for
+	// encrypted objects, so Fsck can detect corruption. Blobs are checked
+	// against their hash instead.
+	StoredCRC32C *uint32 `json:"storedCrc32c,omitempty"`
+}
+
+func (r *objectRecord) toProto() *storagev1.GetObjectMetadataResponse {
+	return &storagev1.GetObjectMetadataResponse{
+		Bucket:                 r.Bucket,
+		Name:                   r.Name,
+		Size:                   r.Size,
+		Metadata:               r.Metadata,
+		StorageClass:           r.StorageClass,
+		Generation:             r.Generation,
+		Metageneration:         r.Metageneration,
+		CreateTime:             timestamppb.New(r.TimeCreated),
+		UpdateTime:             timestamppb.New(r.Updated),
+		StorageClassUpdateTime: timestamppb.New(r.TimeStorageClassUpdated),
+		Acl:                    aclToProto(r.Acl),
+		CustomerEncryption:     r.CustomerEncryption.toProto(),
+		KmsKeyName:             r.kmsKeyVersion(),
+	}
+}
+
+func (r *objectRecord) kmsKeyVersion() string {
+	if r.KMS == nil {
+		return ""
+	}
+	return r.KMS.KeyVersion
+}
+
+func objectKey(bucket, name string) string {
+	return bucket + "/" + name
+}
+
+// getObjectRecord loads the record for bucket/name.
+func (s *StorageServer) getObjectRecord(tx *bbolt.Tx, bucket, name string) (*objectRecord, bool, error) {
+	b := objectBucket(tx, bucket)
+	if b == nil {
+		return nil, false, nil
+	}
+	var rec objectRecord
+	found, err := s.getRecord(b, objectBucketPath(bucket), name, &rec)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	return &rec, true, nil
+}
+
+// putObjectRecord stores rec and moves the usage totals to account for it.
+func (s *StorageServer) putObjectRecord(tx *bbolt.Tx, rec *objectRecord) error {
+	old, _, err := s.getObjectRecord(tx, rec.Bucket, rec.Name)
+	if err != nil {
+		return err
+	}
+	if err := s.addUsage(tx, rec.Bucket, old, rec); err != nil {
+		return err
+	}
+	if err := s.addBlobRefs(tx, old, rec); err != nil {
+		return err
+	}
+	return s.indexObjectRecord(tx, rec)
+}
+
+func (s *StorageServer) deleteObjectRecord(tx *bbolt.Tx, bucket, name string) error {
+	old, _, err := s.getObjectRecord(tx, bucket, name)
+	if err != nil {
+		return err
+	}
+	if err := s.addUsage(tx, bucket, old, nil); err != nil {
+		return err
+	}
+	if err := s.addBlobRefs(tx, old, nil); err != nil {
+		return err
+	}
+	if b := objectBucket(tx, bucket); b != nil {
+		return b.Delete([]byte(name))
+	}
+	return nil
+}
+
+func validateObjectName(name string) error {
+	if name == "" {
+		return errors.New("object name is required")
+	}
+	clean := filepath.ToSlash(filepath.Clean(name))
+	if filepath.IsAbs(name) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("invalid object name: %q", name)
+	}
+	// The filesystem backend stages writes in files named so, which fsck
+	// treats as leftovers.
+	for _, segment := range strings.Split(clean, "/") {
+		if strings.HasPrefix(segment, stagedPrefix) {
+			return fmt.Errorf("invalid object name %q: the %s prefix is reserved", name, stagedPrefix)
+		}
+	}
+	return nil
+}
+
+// nextGeneration returns a GCS-style microsecond generation number that is
+// strictly greater than any this server has handed out.
+func (s *StorageServer) nextGeneration(now time.Time) int64 {
+	for {
+		last := s.lastGeneration.Load()
+		gen := now.UnixMicro()
+		if gen <= last {
+			gen = last + 1
+		}
+		if s.lastGeneration.CompareAndSwap(last, gen) {
+			return gen
+		}
+	}
+}
+
+// observeGeneration makes later generations exceed gen, which was handed out
+// elsewhere, as for imported objects.
+func (s *StorageServer) observeGeneration(gen int64) {
+	for {
+		last := s.lastGeneration.Load()
+		if gen <= last || s.lastGeneration.CompareAndSwap(last, gen) {
+			return
+		}
+	}
+}
+
+// lookupObject returns the record for bucket/name. The index is the source
+// of truth: data in the backend without a record is not an object.
+func (s *StorageServer) lookupObject(ctx context.Context, bucket, name string) (*objectRecord, error) {
+	var rec *objectRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		var found bool
+		var err error
+		rec, found, err = s.getObjectRecord(tx, bucket, name)
+		if err == nil && !found {
+			err = fmt.Errorf("%w: %s/%s", errObjectNotFound, bucket, name)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// currentObject returns the record of obj as committed in tx, failing if the
+// object was replaced or deleted since obj was read.
+func (s *StorageServer) currentObject(tx *bbolt.Tx, obj *objectRecord) (*objectRecord, error) {
+	current, found, err := s.getObjectRecord(tx, obj.Bucket, obj.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", errObjectNotFound, objectKey(obj.Bucket, obj.Name))
+	}
+	if current.Generation != obj.Generation {
+		return nil, fmt.Errorf("%w: %s was replaced", errObjectChanged, objectKey(obj.Bucket, obj.Name))
+	}
+	return current, nil
+}
+
+// objectError maps object lookup failures onto Connect codes.
+func objectError(err error) error {
+	if errors.Is(err, errObjectNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	if errors.Is(err, errObjectChanged) {
+		return connect.NewError(connect.CodeAborted, err)
+	}
+	return bucketError(err)
+}
+
+func (s *StorageServer) ReadObject(ctx context.Context, req *connect.Request[storagev1.ReadObjectRequest], stream *connect.ServerStream[storagev1.ReadObjectResponse]) error {
+	slog.Info("ReadObject", "bucket", req.Msg.Bucket, "name", req.Msg.Name, "offset", req.Msg.ReadOffset, "limit", req.Msg.ReadLimit)
+	if err := s.authorizeObject(ctx, req.Header(), req.Msg.Bucket, req.Msg.Name, permObjectsGet); err != nil {
+		return err
+	}
+	key, err := customerKeyFromProto(req.Msg.CommonObjectRequestParams)
+	if err != nil {
+		return err
+	}
+
+	obj, err := s.lookupObject(ctx, req.Msg.Bucket, req.Msg.Name)
+	if err != nil {
+		return objectError(err)
+	}
+	if err := checkCustomerKey(obj, key); err != nil {
+		return err
+	}
+	if req.Msg.ReadOffset < 0 || req.Msg.ReadLimit < 0 || req.Msg.ReadOffset > obj.Size {
+		return connect.NewError(connect.CodeOutOfRange, fmt.Errorf("invalid read range: offset %d, limit %d, size %d", req.Msg.ReadOffset, req.Msg.ReadLimit, obj.Size))
+	}
+
+	length := int64(-1)
+	if req.Msg.ReadLimit > 0 {
+		length = min(req.Msg.ReadLimit, obj.Size-req.Msg.ReadOffset)
+	}
+	// Encrypted objects are decrypted whole; plain ones are read in place.
+	var r io.Reader
+	if obj.encrypted() {
+		data, err := s.readObjectData(ctx, obj, key)
+		if err != nil {
+			return err
+		}
+		r = io.NewSectionReader(bytes.NewReader(data), req.Msg.ReadOffset, obj.Size-req.Msg.ReadOffset)
+		if length >= 0 {
+			r = io.LimitReader(r, length)
+		}
+	} else {
+		rc, err := s.openStoredData(ctx, obj, req.Msg.ReadOffset, length)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to open object: %v", err))
+		}
+		defer rc.Close()
+		r = rc
+	}
+
+	if d := s.firstByteLatency[obj.StorageClass]; d > 0 {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	buf := make([]byte, readChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&storagev1.ReadObjectResponse{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read object: %v", err))
+		}
+	}
+}
+
+func (s *StorageServer) DeleteObject(ctx context.Context, req *connect.Request[storagev1.DeleteObjectRequest]) (*connect.Response[storagev1.DeleteObjectResponse], error) {
+	slog.Info("DeleteObject", "bucket", req.Msg.Bucket, "name", req.Msg.Name)
+	if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permObjectsDelete); err != nil {
+		return nil, err
+	}
+
+	obj, err := s.lookupObject(ctx, req.Msg.Bucket, req.Msg.Name)
+	if err != nil {
+		return nil, objectError(err)
+	}
+	if match := req.Msg.IfGenerationMatch; match != 0 && obj.Generation != match {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("object %s is at generation %d, not %d", objectKey(obj.Bucket, obj.Name), obj.Generation, match))
+	}
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		// The delete applies to the generation checked, charged and
+		// reported, so an overwrite in between fails it.
+		if _, err := s.currentObject(tx, obj); err != nil {
+			return err
+		}
+		if err := s.recordEarlyDeletion(tx, obj, time.Now().UTC(), "delete"); err != nil {
+			return err
+		}
+		return s.deleteObjectRecord(tx, obj.Bucket, obj.Name)
+	})
+	if errors.Is(err, errObjectNotFound) || errors.Is(err, errObjectChanged) {
+		return nil, objectError(err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete object: %v", err))
+	}
+	s.removeObjectData(ctx, obj.Bucket, obj.Name)
+	s.emit(objectEvent{eventType: EventObjectDelete, object: obj})
+	return connect.NewResponse(&storagev1.DeleteObjectResponse{}), nil
+}
+
+func (s *StorageServer) RewriteObject(ctx context.Context, req *connect.Request[storagev1.RewriteObjectRequest]) (*connect.Response[storagev1.RewriteObjectResponse], error) {
+	m := req.Msg
+	slog.Info("RewriteObject", "source", objectKey(m.SourceBucket, m.SourceObject), "destination", objectKey(m.DestinationBucket, m.DestinationObject), "class", m.DestinationStorageClass)
+
+	if err := validateObjectName(m.DestinationObject); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	member := principalOf(ctx, req.Header())
+	if err := s.authorizeObject(ctx, req.Header(), m.SourceBucket, m.SourceObject, permObjectsGet); err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, req.Header(), m.DestinationBucket, permObjectsCreate); err != nil {
+		return nil, err
+	}
+	srcKey, err := newCustomerKey(m.CopySourceEncryptionAlgorithm, m.CopySourceEncryptionKeyBytes, m.CopySourceEncryptionKeySha256Bytes)
+	if err != nil {
+		return nil, err
+	}
+	dstKey, err := customerKeyFromProto(m.CommonObjectRequestParams)
+	if err != nil {
+		return nil, err
+	}
+	src, err := s.lookupObject(ctx, m.SourceBucket, m.SourceObject)
+	if err != nil {
+		return nil, objectError(err)
+	}
+	var dstBucket *bucketRecord
+	err = s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		dstBucket, err = s.getBucketRecord(tx, m.DestinationBucket)
+		return err
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	class, err := normalizeStorageClass(m.DestinationStorageClass, dstBucket.StorageClass)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	acl, err := newObjectAcl(dstBucket, m.DestinationPredefinedAcl, member)
+	if err != nil {
+		return nil, err
+	}
+	kmsKey, err := s.objectKMSKey(dstBucket, m.DestinationKmsKeyName, dstKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unencrypted content-addressed data is copied by reference.
+	byRef := src.contentAddressed() && !src.encrypted() && dstKey == nil && kmsKey == ""
+	size := src.Size
+	var sealed []byte
+	var env *kmsEnvelope
+	if byRef {
+		if err := checkCustomerKey(src, srcKey); err != nil {
+			return nil, err
+		}
+	} else {
+		data, err := s.readObjectData(ctx, src, srcKey)
+		if err != nil {
+			return nil, err
+		}
+		if sealed, env, err = s.sealObjectData(ctx, data, dstKey, kmsKey); err != nil {
+			return nil, err
+		}
+		size = int64(len(data))
+	}
+
+	now := time.Now().UTC()
+	dst := &objectRecord{
+		Bucket:                  m.DestinationBucket,
+		Name:                    m.DestinationObject,
+		Size:                    size,
+		Metadata:                copyStrings(src.Metadata),
+		StorageClass:            class,
+		Generation:              s.nextGeneration(now),
+		Metageneration:          1,
+		TimeCreated:             now,
+		Updated:                 now,
+		TimeStorageClassUpdated: now,
+		Acl:                     acl,
+		CustomerEncryption:      dstKey.encryption(),
+		KMS:                     env,
+	}
+	w := s.newObjectWrite(dst.Bucket, dst.Name)
+	defer w.close()
+	if !byRef {
+		if err := s.precheckQuota(ctx, dst); err != nil {
+			return nil, objectError(err)
+		}
+		if err := w.stage(ctx, sealed); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to store object data: %v", err))
+		}
+	}
+	var old *objectRecord
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		var found bool
+		var err error
+		old, found, err = s.getObjectRecord(tx, dst.Bucket, dst.Name)
+		if err != nil {
+			return err
+		}
+		if found {
+			if err := s.authorizeTx(tx, member, dst.Bucket, permObjectsDelete); err != nil {
+				return err
+			}
+			if err := s.recordEarlyDeletion(tx, old, now, "rewrite"); err != nil {
+				return err
+			}
+		}
+		if err := s.checkQuota(tx, old, dst); err != nil {
+			return err
+		}
+		if byRef {
+			if dst.Blobs, err = s.currentBlobs(tx, src); err != nil {
+				return err
+			}
+			return w.putBlobs(tx, dst)
+		}
+		return w.put(ctx, tx, dst)
+	})
+	if err != nil {
+		return nil, objectError(err)
+	}
+	w.finish(ctx)
+	s.emit(overwriteEvents(old, dst)...)
+	return connect.NewResponse(&storagev1.RewriteObjectResponse{Resource: dst.toProto()}), nil
+}
+
+func (s *StorageServer) UpdateObject(ctx context.Context, req *connect.Request[storagev1.UpdateObjectRequest]) (*connect.Response[storagev1.UpdateObjectResponse], error) {
+	paths := req.Msg.GetUpdateMask().GetPaths()
+	slog.Info("UpdateObject", "bucket", req.Msg.Bucket, "name", req.Msg.Name, "paths", paths)
+	if err := s.authorizeObject(ctx, req.Header(), req.Msg.Bucket, req.Msg.Name, permObjectsUpdate); err != nil {
+		return nil, err
+	}
+
+	for _, p := range paths {
+		if p != "metadata" && !strings.HasPrefix(p, "metadata.") {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update_mask path: %q", p))
+		}
+	}
+	obj, err := s.lookupObject(ctx, req.Msg.Bucket, req.Msg.Name)
+	if err != nil {
+		return nil, objectError(err)
+	}
+
+	now := time.Now().UTC()
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		obj, err = s.updateObjectTx(tx, obj, req.Msg, now)
+		return err
+	})
+	if err != nil {
+		return nil, objectError(err)
+	}
+	s.emit(objectEvent{eventType: EventObjectMetadataUpdate, object: obj})
+	return connect.NewResponse(&storagev1.UpdateObjectResponse{Resource: obj.toProto()}), nil
+}
+
+// updateObjectTx applies msg to obj as committed in tx, which may have new
+// metadata or a new KMS envelope since obj was read. An object replaced
+// meanwhile fails with errObjectChanged rather than being written back
+// stale.
+func (s *StorageServer) updateObjectTx(tx *bbolt.Tx, obj *objectRecord, msg *storagev1.UpdateObjectRequest, now time.Time) (*objectRecord, error) {
+	old, err := s.currentObject(tx, obj)
+	if err != nil {
+		return nil, err
+	}
+	updated := *old
+	updated.Metadata = copyStrings(old.Metadata)
+	paths := msg.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		updated.Metadata = mergeStrings(updated.Metadata, msg.Metadata)
+	}
+	for _, p := range paths {
+		if p == "metadata" {
+			updated.Metadata = copyStrings(msg.Metadata)
+			continue
+		}
+		key := strings.TrimPrefix(p, "metadata.")
+		if v, ok := msg.Metadata[key]; ok {
+			updated.Metadata = mergeStrings(updated.Metadata, map[string]string{key: v})
+		} else {
+			delete(updated.Metadata, key)
+		}
+	}
+	updated.Metageneration++
+	updated.Updated = now
+	if err := s.checkQuota(tx, old, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, s.putObjectRecord(tx, &updated)
+}
+
+// WriteObject is the streaming form of UploadObject. The stream is buffered
+// and committed through UploadObject once the client closes it; a stream
+// outgrowing the object size quota or maxStreamedObjectSize fails as soon
+// as it does.
+func (s *StorageServer) WriteObject(ctx context.Context, stream *connect.ClientStream[storagev1.WriteObjectRequest]) (*connect.Response[storagev1.WriteObjectResponse], error) {
+	var spec *storagev1.UploadObjectRequest
+	var data []byte
+	var limit int64
+	var subject string
+	for stream.Receive() {
+		msg := stream.Msg()
+		if spec == nil {
+			if msg.Spec == nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("the first message must carry the object spec"))
+			}
+			spec = proto.Clone(msg.Spec).(*storagev1.UploadObjectRequest)
+			var err error
+			if limit, subject, err = s.streamSizeLimit(ctx, spec.Bucket); err != nil {
+				return nil, bucketError(err)
+			}
+		}
+		if size := int64(len(data) + len(msg.Data)); size > limit {
+			return nil, streamSizeError(spec, subject, size, limit)
+		}
+		data = append(data, msg.Data...)
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	if spec == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("empty write stream"))
+	}
+	slog.Info("WriteObject", "bucket", spec.Bucket, "name", spec.Name, "size", len(data))
+
+	spec.Data = data
+	upload := connect.NewRequest(spec)
+	for k, v := range stream.RequestHeader() {
+		upload.Header()[k] = v
+	}
+	resp, err := s.UploadObject(ctx, upload)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&storagev1.WriteObjectResponse{Generation: resp.Msg.Generation, PersistedSize: int64(len(data))}), nil
+}
@@ -0,0 +1,345 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// bucketUsage holds running byte and object totals keyed by "bucket/{name}"
+// and "project/{id}", maintained alongside object records.
+const bucketUsage = "usage"
+
+// Quota limits a bucket or project. Zero fields are unlimited.
+type Quota struct {
+	MaxBytes              int64 `json:"maxBytes,omitempty"`
+	MaxObjects            int64 `json:"maxObjects,omitempty"`
+	MaxObjectSize         int64 `json:"maxObjectSize,omitempty"`
+	MaxCustomMetadataSize int64 `json:"maxCustomMetadataSize,omitempty"`
+}
+
+// Quotas configures limits per bucket and per project. The defaults apply to
+// buckets and projects without an entry of their own.
+type Quotas struct {
+	DefaultBucket  Quota            `json:"defaultBucket"`
+	DefaultProject Quota            `json:"defaultProject"`
+	Buckets        map[string]Quota `json:"buckets,omitempty"`
+	Projects       map[string]Quota `json:"projects,omitempty"`
+}
+
+// LoadQuotas reads a Quotas JSON file.
+func LoadQuotas(path string) (Quotas, error) {
+	var q Quotas
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return q, fmt.Errorf("failed to read quotas file: %v", err)
+	}
+	if err := json.Unmarshal(data, &q); err != nil {
+		return q, fmt.Errorf("failed to parse quotas file: %v", err)
+	}
+	return q, nil
+}
+
+// WithQuotas enforces q on uploads, copies and compositions.
+func WithQuotas(q Quotas) Option {
+	return func(s *StorageServer) {
+		s.quotas = q
+	}
+}
+
+func (q Quotas) bucket(name string) Quota {
+	if quota, ok := q.Buckets[name]; ok {
+		return quota
+	}
+	return q.DefaultBucket
+}
+
+func (q Quotas) project(id string) Quota {
+	if quota, ok := q.Projects[id]; ok {
+		return quota
+	}
+	return q.DefaultProject
+}
+
+func (q Quota) toProto() *storagev1.StorageQuota {
+	return &storagev1.StorageQuota{
+		MaxBytes:              q.MaxBytes,
+		MaxObjects:            q.MaxObjects,
+		MaxObjectSize:         q.MaxObjectSize,
+		MaxCustomMetadataSize: q.MaxCustomMetadataSize,
+	}
+}
+
+type usageRecord struct {
+	Bytes   int64 `json:"bytes"`
+	Objects int64 `json:"objects"`
+}
+
+func bucketUsageKey(name string) string { return "bucket/" + name }
+func projectUsageKey(id string) string  { return "project/" + id }
+
+func (s *StorageServer) getUsage(tx *bbolt.Tx, key string) (usageRecord, error) {
+	var u usageRecord
+	_, err := s.getRecord(tx.Bucket([]byte(bucketUsage)), bucketUsage, key, &u)
+	return u, err
+}
+
+// project returns the bucket's project; buckets created before projects
+// were recorded belong to the emulator project.
+func (r *bucketRecord) project() string {
+	if r.Project == "" {
+		return emulatorProject
+	}
+	return r.Project
+}
+
+func (s *StorageServer) bucketProject(tx *bbolt.Tx, bucket string) (string, error) {
+	rec, err := s.getBucketRecord(tx, bucket)
+	if errors.Is(err, errBucketNotFound) {
+		return emulatorProject, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return rec.project(), nil
+}
+
+// usageOf returns the bytes and object count an object record contributes
+// to its bucket and project; absent objects contribute nothing.
+func usageOf(rec *objectRecord) usageRecord {
+	if rec == nil {
+		return usageRecord{}
+	}
+	return usageRecord{Bytes: rec.Size, Objects: 1}
+}
+
+func customMetadataSize(metadata map[string]string) int64 {
+	var n int64
+	for k, v := range metadata {
+		n += int64(len(k) + len(v))
+	}
+	return n
+}
+
+// addUsage moves the totals of bucket and its project from old to rec.
+func (s *StorageServer) addUsage(tx *bbolt.Tx, bucket string, old, rec *objectRecord) error {
+	before, after := usageOf(old), usageOf(rec)
+	if before == after {
+		return nil
+	}
+	project, err := s.bucketProject(tx, bucket)
+	if err != nil {
+		return err
+	}
+	b := tx.Bucket([]byte(bucketUsage))
+	for _, key := range []string{bucketUsageKey(bucket), projectUsageKey(project)} {
+		u, err := s.getUsage(tx, key)
+		if err != nil {
+			return err
+		}
+		u.Bytes += after.Bytes - before.Bytes
+		u.Objects += after.Objects - before.Objects
+		if err := s.putRecord(b, bucketUsage, key, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initUsage computes the usage totals from the object records when the
+// database predates them.
+func (s *StorageServer) initUsage() error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte(bucketUsage)) != nil {
+			return nil
+		}
+		return s.computeUsage(tx)
+	})
+}
+
+// computeUsage creates the usage bucket, which must be absent, with totals
+// summed from the object index.
+func (s *StorageServer) computeUsage(tx *bbolt.Tx) error {
+	if _, err := tx.CreateBucket([]byte(bucketUsage)); err != nil {
+		return err
+	}
+	var records []*objectRecord
+	err := s.forEachObject(tx, func(rec *objectRecord) error {
+		records = append(records, rec)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if err := s.addUsage(tx, rec.Bucket, nil, rec); err != nil {
+			return err
+		}
+	}
+	slog.Info("Computed storage usage", "objects", len(records))
+	return nil
+}
+
+// checkQuota reports ResourceExhausted, with a QuotaFailure detail, when
+// writing rec in place of old would take the object, its bucket or its
+// project past a limit. Limits only stop growth, so lowering one never
+// blocks writes that shrink usage.
+func (s *StorageServer) checkQuota(tx *bbolt.Tx, old, rec *objectRecord) error {
+	project, err := s.bucketProject(tx, rec.Bucket)
+	if err != nil {
+		return err
+	}
+	before, after := usageOf(old), usageOf(rec)
+	var oldMetadata int64
+	if old != nil {
+		oldMetadata = customMetadataSize(old.Metadata)
+	}
+	metadata := customMetadataSize(rec.Metadata)
+
+	var violations []*errdetails.QuotaFailure_Violation
+	check := func(subject, metric string, limit, value, previous int64) {
+		if limit > 0 && value > limit && value > previous {
+			violations = append(violations, &errdetails.QuotaFailure_Violation{
+				Subject:     subject,
+				Description: fmt.Sprintf("%s %d exceeds the limit of %d", metric, value, limit),
+				QuotaMetric: metric,
+			})
+		}
+	}
+	scopes := []struct {
+		subject, key string
+		quota        Quota
+	}{
+		{"buckets/" + rec.Bucket, bucketUsageKey(rec.Bucket), s.quotas.bucket(rec.Bucket)},
+		{"projects/" + project, projectUsageKey(project), s.quotas.project(project)},
+	}
+	for _, scope := range scopes {
+		q := scope.quota
+		if q == (Quota{}) {
+			continue
+		}
+		u, err := s.getUsage(tx, scope.key)
+		if err != nil {
+			return err
+		}
+		check(scope.subject, "object_size", q.MaxObjectSize, after.Bytes, before.Bytes)
+		check(scope.subject, "custom_metadata_size", q.MaxCustomMetadataSize, metadata, oldMetadata)
+		check(scope.subject, "total_bytes", q.MaxBytes, u.Bytes-before.Bytes+after.Bytes, u.Bytes)
+		check(scope.subject, "object_count", q.MaxObjects, u.Objects-before.Objects+after.Objects, u.Objects)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return quotaError(rec.Bucket, rec.Name, violations)
+}
+
+// precheckQuota runs checkQuota against the committed state ahead of
+// staging rec's data, so a write bound to exceed a quota fails before
+// storing anything. Writers check again within their transaction, as usage
+// may change in between.
+func (s *StorageServer) precheckQuota(ctx context.Context, rec *objectRecord) error {
+	return s.view(ctx, func(tx *bbolt.Tx) error {
+		old, _, err := s.getObjectRecord(tx, rec.Bucket, rec.Name)
+		if err != nil {
+			return err
+		}
+		return s.checkQuota(tx, old, rec)
+	})
+}
+
+// quotaError reports violations of the quotas for writing bucket/name.
+func quotaError(bucket, name string, violations []*errdetails.QuotaFailure_Violation) error {
+	cerr := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("quota exceeded writing %s: %s", objectKey(bucket, name), violations[0].Description))
+	if detail, err := connect.NewErrorDetail(&errdetails.QuotaFailure{Violations: violations}); err == nil {
+		cerr.AddDetail(detail)
+	}
+	return cerr
+}
+
+// maxStreamedObjectSize caps the objects WriteObject takes, as it buffers
+// them in memory before committing them.
+const maxStreamedObjectSize = 1 << 30
+
+// streamSizeLimit returns the largest object a stream may write to bucket,
+// and the quota subject setting it, empty for maxStreamedObjectSize.
+func (s *StorageServer) streamSizeLimit(ctx context.Context, bucket string) (int64, string, error) {
+	var project string
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		project, err = s.bucketProject(tx, bucket)
+		return err
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	limit, subject := int64(maxStreamedObjectSize), ""
+	for _, scope := range []struct {
+		subject string
+		quota   Quota
+	}{
+		{"buckets/" + bucket, s.quotas.bucket(bucket)},
+		{"projects/" + project, s.quotas.project(project)},
+	} {
+		if max := scope.quota.MaxObjectSize; max > 0 && max < limit {
+			limit, subject = max, scope.subject
+		}
+	}
+	return limit, subject, nil
+}
+
+// streamSizeError reports a stream uploading spec outgrowing the limit
+// streamSizeLimit set.
+func streamSizeError(spec *storagev1.UploadObjectRequest, subject string, size, limit int64) error {
+	if subject == "" {
+		return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("streamed object %s exceeds %d bytes", objectKey(spec.Bucket, spec.Name), limit))
+	}
+	return quotaError(spec.Bucket, spec.Name, []*errdetails.QuotaFailure_Violation{{
+		Subject:     subject,
+		Description: fmt.Sprintf("object_size %d exceeds the limit of %d", size, limit),
+		QuotaMetric: "object_size",
+	}})
+}
+
+func (s *StorageServer) GetStorageUsage(ctx context.Context, req *connect.Request[storagev1.GetStorageUsageRequest]) (*connect.Response[storagev1.GetStorageUsageResponse], error) {
+	slog.Info("GetStorageUsage", "bucket", req.Msg.Bucket, "project", req.Msg.Project)
+	if (req.Msg.Bucket == "") == (req.Msg.Project == "") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("exactly one of bucket and project is required"))
+	}
+
+	var key string
+	var quota Quota
+	if req.Msg.Bucket != "" {
+		if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permBucketsGet); err != nil {
+			return nil, err
+		}
+		key, quota = bucketUsageKey(req.Msg.Bucket), s.quotas.bucket(req.Msg.Bucket)
+	} else {
+		// Project totals reveal no object data, so any caller may read them.
+		key, quota = projectUsageKey(req.Msg.Project), s.quotas.project(req.Msg.Project)
+	}
+	var u usageRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		if req.Msg.Bucket != "" {
+			if _, err := s.getBucketRecord(tx, req.Msg.Bucket); err != nil {
+				return err
+			}
+		}
+		var err error
+		u, err = s.getUsage(tx, key)
+		return err
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.GetStorageUsageResponse{Bytes: u.Bytes, Objects: u.Objects, Quota: quota.toProto()}), nil
+}
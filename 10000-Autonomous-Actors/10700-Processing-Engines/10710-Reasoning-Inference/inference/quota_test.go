@@ -0,0 +1,177 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func quotaViolation(t *testing.T, err error) *errdetails.QuotaFailure_Violation {
+	t.Helper()
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) || cerr.Code() != connect.CodeResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	for _, d := range cerr.Details() {
+		if v, err := d.Value(); err == nil {
+			if qf, ok := v.(*errdetails.QuotaFailure); ok && len(qf.Violations) > 0 {
+				return qf.Violations[0]
+			}
+		}
+	}
+	t.Fatalf("no QuotaFailure detail on %v", err)
+	return nil
+}
+
+func TestQuotas(t *testing.T) {
+	dir := t.TempDir()
+	server := NewStorageServer(dir, WithQuotas(Quotas{
+		DefaultBucket: Quota{MaxObjects: 3, MaxObjectSize: 10, MaxCustomMetadataSize: 8},
+		Projects:      map[string]Quota{"agents": {MaxBytes: 25}},
+	}))
+	ctx := context.Background()
+	upload := func(bucket, name, data string, metadata map[string]string) error {
+		_, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: bucket, Name: name, Data: []byte(data), Metadata: metadata}))
+		return err
+	}
+	usage := func(req *storagev1.GetStorageUsageRequest) (int64, int64) {
+		resp, err := server.GetStorageUsage(ctx, connect.NewRequest(req))
+		if err != nil {
+			t.Fatalf("GetStorageUsage failed: %v", err)
+		}
+		return resp.Msg.Bytes, resp.Msg.Objects
+	}
+
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "scratch", Project: "agents"}))
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "spill", Project: "agents"}))
+	for _, name := range []string{"a", "b", "c"} {
+		if err := upload("scratch", name, "12345678", nil); err != nil {
+			t.Fatalf("UploadObject %s failed: %v", name, err)
+		}
+	}
+	if v := quotaViolation(t, upload("scratch", "d", "x", nil)); v.Subject != "buckets/scratch" || v.QuotaMetric != "object_count" {
+		t.Errorf("unexpected violation %v", v)
+	}
+	if v := quotaViolation(t, upload("spill", "big", "12345678901", nil)); v.QuotaMetric != "object_size" {
+		t.Errorf("unexpected violation %v", v)
+	}
+	if v := quotaViolation(t, upload("spill", "tagged", "x", map[string]string{"owner": "agent-7"})); v.QuotaMetric != "custom_metadata_size" {
+		t.Errorf("unexpected violation %v", v)
+	}
+	// The project holds 24 of its 25 bytes.
+	if v := quotaViolation(t, upload("spill", "e", "12", nil)); v.Subject != "projects/agents" || v.QuotaMetric != "total_bytes" {
+		t.Errorf("unexpected violation %v", v)
+	}
+	if bytes, objects := usage(&storagev1.GetStorageUsageRequest{Project: "agents"}); bytes != 24 || objects != 3 {
+		t.Errorf("unexpected project usage %d bytes, %d objects", bytes, objects)
+	}
+
+	// Shrinking an object is allowed and frees quota.
+	if err := upload("scratch", "a", "1", nil); err != nil {
+		t.Fatalf("overwrite failed: %v", err)
+	}
+	if _, err := server.RewriteObject(ctx, connect.NewRequest(&storagev1.RewriteObjectRequest{SourceBucket: "scratch", SourceObject: "b", DestinationBucket: "spill", DestinationObject: "b"})); err != nil {
+		t.Fatalf("RewriteObject failed: %v", err)
+	}
+	_, err := server.RewriteObject(ctx, connect.NewRequest(&storagev1.RewriteObjectRequest{SourceBucket: "scratch", SourceObject: "c", DestinationBucket: "spill", DestinationObject: "c"}))
+	quotaViolation(t, err)
+	_, err = server.ComposeObject(ctx, connect.NewRequest(&storagev1.ComposeObjectRequest{Bucket: "spill", DestinationObject: "ab", SourceObjects: []string{"b", "b"}}))
+	if v := quotaViolation(t, err); v.QuotaMetric != "object_size" {
+		t.Errorf("unexpected violation %v", v)
+	}
+	server.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "scratch", Name: "c"}))
+	resp, err := server.ComposeObject(ctx, connect.NewRequest(&storagev1.ComposeObjectRequest{Bucket: "spill", DestinationObject: "ab", SourceObjects: []string{"b"}}))
+	if err != nil || resp.Msg.Resource.Size != 8 {
+		t.Fatalf("unexpected ComposeObject result: %v (%v)", resp, err)
+	}
+	bytes, objects := usage(&storagev1.GetStorageUsageRequest{Bucket: "spill"})
+	if bytes != 16 || objects != 2 {
+		t.Errorf("unexpected bucket usage %d bytes, %d objects", bytes, objects)
+	}
+	server.Close()
+
+	// Usage computed from the object records matches the running totals.
+	reopened := NewStorageServer(dir)
+	defer reopened.Close()
+	reopened.db.Update(func(tx *bbolt.Tx) error { return tx.DeleteBucket([]byte(bucketUsage)) })
+	if err := reopened.initUsage(); err != nil {
+		t.Fatalf("initUsage failed: %v", err)
+	}
+	got, err := reopened.GetStorageUsage(ctx, connect.NewRequest(&storagev1.GetStorageUsageRequest{Project: "agents"}))
+	if err != nil || got.Msg.Bytes != 1+8+16 || got.Msg.Objects != 4 {
+		t.Errorf("unexpected recomputed usage: %v (%v)", got, err)
+	}
+	if _, err := reopened.GetStorageUsage(ctx, connect.NewRequest(&storagev1.GetStorageUsageRequest{})); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestWriteObjectSizeLimit(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory(), WithQuotas(Quotas{Buckets: map[string]Quota{"small": {MaxObjectSize: 10}}}))
+	defer server.Close()
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	ctx := context.Background()
+	for _, name := range []string{"small", "large"} {
+		if _, err := client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: name})); err != nil {
+			t.Fatalf("CreateBucket failed: %v", err)
+		}
+	}
+	write := func(bucket string, chunks ...string) error {
+		stream := client.WriteObject(ctx)
+		for i, chunk := range chunks {
+			msg := &storagev1.WriteObjectRequest{Data: []byte(chunk)}
+			if i == 0 {
+				msg.Spec = &storagev1.UploadObjectRequest{Bucket: bucket, Name: "o"}
+			}
+			if stream.Send(msg) != nil {
+				break
+			}
+		}
+		_, err := stream.CloseAndReceive()
+		return err
+	}
+
+	// The stream fails on the chunk taking it past the quota.
+	err := write("small", "012345", "678901", "234567")
+	if v := quotaViolation(t, err); v.QuotaMetric != "object_size" || v.Subject != "buckets/small" {
+		t.Errorf("unexpected violation %v", v)
+	}
+	if err := write("small", "01234", "56789"); err != nil {
+		t.Errorf("expected an upload at the limit to succeed, got %v", err)
+	}
+	if err := write("large", "012345", "678901"); err != nil {
+		t.Errorf("expected an upload without a quota to succeed, got %v", err)
+	}
+	if err := write("missing", "x"); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("expected NotFound for a missing bucket, got %v", err)
+	}
+}
+
+func TestQuotaCheckedBeforeStaging(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	server := NewStorageServer("", WithInMemory(), WithContentAddressing(), WithQuotas(Quotas{DefaultBucket: Quota{MaxObjectSize: 4}}))
+	defer server.Close()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "small", Data: []byte("abc")}))
+
+	_, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "big", Data: []byte("too big")}))
+	quotaViolation(t, err)
+	// Over-quota writes store no data for a collection to find.
+	if blobs, err := server.backend.List(ctx, blobBucket, ""); err != nil || len(blobs) != 1 {
+		t.Errorf("expected only the small object's blob, got %v (%v)", blobs, err)
+	}
+}
@@ -0,0 +1,298 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimit is a token bucket admitting Rate requests per second on average
+// and bursts of up to Burst. A zero Rate is unlimited.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+// RateLimitConfig configures a RateLimiter. Each scope has a default limit
+// and overrides keyed by IAM member (or "peer:" and the address of
+// unauthenticated callers), bucket name or RPC name (for example
+// "UploadObject"). Every scope that applies must admit a request.
+type RateLimitConfig struct {
+	Principal  RateLimit            `json:"principal"`
+	Principals map[string]RateLimit `json:"principals,omitempty"`
+	Bucket     RateLimit            `json:"bucket"`
+	Buckets    map[string]RateLimit `json:"buckets,omitempty"`
+	RPC        RateLimit            `json:"rpc"`
+	RPCs       map[string]RateLimit `json:"rpcs,omitempty"`
+	// MaxConcurrentUploads caps WriteObject streams in flight. Zero is
+	// unlimited.
+	MaxConcurrentUploads int `json:"maxConcurrentUploads,omitempty"`
+}
+
+// LoadRateLimitConfig reads a RateLimitConfig JSON file.
+func LoadRateLimitConfig(path string) (RateLimitConfig, error) {
+	var cfg RateLimitConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read rate limit file: %v", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse rate limit file: %v", err)
+	}
+	return cfg, nil
+}
+
+func (c RateLimitConfig) limit(scope, key string) RateLimit {
+	switch scope {
+	case "principal":
+		if l, ok := c.Principals[key]; ok {
+			return l
+		}
+		return c.Principal
+	case "bucket":
+		if l, ok := c.Buckets[key]; ok {
+			return l
+		}
+		return c.Bucket
+	default:
+		if l, ok := c.RPCs[key]; ok {
+			return l
+		}
+		return c.RPC
+	}
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	// full is when the bucket has refilled, and so may be dropped.
+	full time.Time
+}
+
+// rateSweepInterval is how often a RateLimiter drops refilled buckets.
+const rateSweepInterval = time.Minute
+
+// RateLimiter is a Connect interceptor that throttles StorageService calls
+// per principal, per bucket and per RPC. Install it after the
+// AuthInterceptor: only authenticated principals get buckets of their own,
+// and other callers are limited by peer address, as PrincipalHeader is
+// theirs to choose.
+type RateLimiter struct {
+	mu      sync.Mutex
+	cfg     RateLimitConfig
+	buckets map[string]*tokenBucket
+	swept   time.Time
+	uploads int
+	now     func() time.Time
+}
+
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	return &RateLimiter{cfg: cfg, buckets: map[string]*tokenBucket{}, now: time.Now}
+}
+
+// Reload replaces the configuration. Token buckets restart full; uploads in
+// flight keep their slots.
+func (l *RateLimiter) Reload(cfg RateLimitConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cfg = cfg
+	l.buckets = map[string]*tokenBucket{}
+	slog.Info("Rate limits reloaded", "maxConcurrentUploads", cfg.MaxConcurrentUploads)
+}
+
+type rateKey struct{ scope, key string }
+
+// take admits a request when every key has a token, consuming one from
+// each, and otherwise reports how long until it would be admitted.
+func (l *RateLimiter) take(keys ...rateKey) (string, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.swept) >= rateSweepInterval {
+		l.sweep(now)
+	}
+	var denied string
+	var wait time.Duration
+	var admitted []*tokenBucket
+	for _, k := range keys {
+		limit := l.cfg.limit(k.scope, k.key)
+		if limit.Rate <= 0 {
+			continue
+		}
+		burst := float64(max(limit.Burst, 1))
+		name := k.scope + "/" + k.key
+		b, ok := l.buckets[name]
+		if !ok {
+			b = &tokenBucket{tokens: burst, last: now}
+			l.buckets[name] = b
+		}
+		b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+		b.last = now
+		// Counting the token this request may take.
+		b.full = now.Add(time.Duration((burst - b.tokens + 1) / limit.Rate * float64(time.Second)))
+		if b.tokens < 1 {
+			if d := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second)); d > wait {
+				denied, wait = name, d
+			}
+			continue
+		}
+		admitted = append(admitted, b)
+	}
+	if wait > 0 {
+		return denied, wait
+	}
+	for _, b := range admitted {
+		b.tokens--
+	}
+	return "", 0
+}
+
+// sweep drops the buckets refilled by now, which a later request would
+// recreate as they are, so that callers no longer seen take no memory.
+func (l *RateLimiter) sweep(now time.Time) {
+	for name, b := range l.buckets {
+		if !now.Before(b.full) {
+			delete(l.buckets, name)
+		}
+	}
+	l.swept = now
+}
+
+func (l *RateLimiter) acquireUpload() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cfg.MaxConcurrentUploads > 0 && l.uploads >= l.cfg.MaxConcurrentUploads {
+		return false
+	}
+	l.uploads++
+	return true
+}
+
+func (l *RateLimiter) releaseUpload() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.uploads--
+}
+
+// rateLimitError is ResourceExhausted carrying a Retry-After header and a
+// RetryInfo detail.
+func rateLimitError(procedure, what string, wait time.Duration) error {
+	slog.Warn("Request throttled", "procedure", procedure, "limit", what, "retryAfter", wait)
+	err := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("rate limit exceeded for %s", what))
+	err.Meta().Set("Retry-After", strconv.Itoa(max(1, int(math.Ceil(wait.Seconds())))))
+	if detail, derr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); derr == nil {
+		err.AddDetail(detail)
+	}
+	return err
+}
+
+// requestBucket names the bucket a request addresses, if any.
+func requestBucket(msg any) string {
+	switch m := msg.(type) {
+	case *storagev1.WriteObjectRequest:
+		return m.GetSpec().GetBucket()
+	case *storagev1.CreateBucketRequest:
+		return m.Name
+	case *storagev1.GetBucketRequest:
+		return m.Name
+	case interface{ GetBucket() string }:
+		return m.GetBucket()
+	case interface{ GetDestinationBucket() string }:
+		return m.GetDestinationBucket()
+	}
+	return ""
+}
+
+// rateMember is the principal scope key of a caller: its authenticated
+// principal, or else its peer address.
+func rateMember(ctx context.Context, peer connect.Peer) string {
+	if member, ok := PrincipalFromContext(ctx); ok {
+		return member
+	}
+	host, _, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		host = peer.Addr
+	}
+	return "peer:" + host
+}
+
+func (l *RateLimiter) check(ctx context.Context, procedure string, peer connect.Peer, msg any) error {
+	member := rateMember(ctx, peer)
+	keys := []rateKey{
+		{"principal", member},
+		{"rpc", path.Base(procedure)},
+	}
+	if bucket := requestBucket(msg); bucket != "" {
+		keys = append(keys, rateKey{"bucket", bucket})
+	}
+	if denied, wait := l.take(keys...); wait > 0 {
+		return rateLimitError(procedure, denied, wait)
+	}
+	return nil
+}
+
+func (l *RateLimiter) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		if err := l.check(ctx, req.Spec().Procedure, req.Peer(), req.Any()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (l *RateLimiter) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler applies the limits once the first request message,
+// which names the bucket, arrives.
+func (l *RateLimiter) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		procedure := conn.Spec().Procedure
+		if procedure == storagev1connect.StorageServiceWriteObjectProcedure {
+			if !l.acquireUpload() {
+				return rateLimitError(procedure, "concurrent uploads", time.Second)
+			}
+			defer l.releaseUpload()
+		}
+		return next(ctx, &rateLimitedConn{StreamingHandlerConn: conn, check: func(msg any) error {
+			return l.check(ctx, procedure, conn.Peer(), msg)
+		}})
+	}
+}
+
+type rateLimitedConn struct {
+	connect.StreamingHandlerConn
+	check   func(msg any) error
+	checked bool
+}
+
+func (c *rateLimitedConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	if !c.checked {
+		c.checked = true
+		return c.check(msg)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package inference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestRateLimiter(t *testing.T) {
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	limiter := NewRateLimiter(RateLimitConfig{
+		Principal: RateLimit{Rate: 1, Burst: 3},
+		Buckets:   map[string]RateLimit{"hot": {Rate: 1, Burst: 1}},
+
+		MaxConcurrentUploads: 1,
+	})
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	auth, err := NewAuthInterceptor(AuthConfig{Dev: true})
+	if err != nil {
+		t.Fatalf("NewAuthInterceptor failed: %v", err)
+	}
+	auth.tokens = map[string]string{"ci": "user:ci", "alice": alice, "bob": "user:bob@example.com"}
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(auth, limiter)))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	ctx := context.Background()
+
+	getBucket := func(principal, bucket string) error {
+		req := connect.NewRequest(&storagev1.GetBucketRequest{Name: bucket})
+		req.Header().Set("Authorization", "Bearer "+principal)
+		_, err := client.GetBucket(ctx, req)
+		return err
+	}
+	throttled := func(err error) {
+		t.Helper()
+		var cerr *connect.Error
+		if !errors.As(err, &cerr) || cerr.Code() != connect.CodeResourceExhausted {
+			t.Fatalf("expected ResourceExhausted, got %v", err)
+		}
+		if cerr.Meta().Get("Retry-After") != "1" {
+			t.Errorf("unexpected Retry-After %q", cerr.Meta().Get("Retry-After"))
+		}
+		for _, d := range cerr.Details() {
+			if v, err := d.Value(); err == nil {
+				if info, ok := v.(*errdetails.RetryInfo); ok && info.RetryDelay.AsDuration() > 0 {
+					return
+				}
+			}
+		}
+		t.Errorf("no RetryInfo detail on %v", err)
+	}
+
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "cold"}))
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "hot"}))
+	for i := 0; i < 3; i++ {
+		if err := getBucket("ci", "cold"); err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+	throttled(getBucket("ci", "cold"))
+	if err := getBucket("alice", "hot"); err != nil {
+		t.Errorf("other principals must not be throttled: %v", err)
+	}
+	throttled(getBucket("bob", "hot"))
+	now = now.Add(time.Second)
+	if err := getBucket("ci", "cold"); err != nil {
+		t.Errorf("expected a refilled token: %v", err)
+	}
+
+	// A second upload stream is refused while the first is open.
+	first := client.WriteObject(ctx)
+	first.RequestHeader().Set("Authorization", "Bearer alice")
+	if err := first.Send(&storagev1.WriteObjectRequest{Spec: &storagev1.UploadObjectRequest{Bucket: "cold", Name: "big"}, Data: []byte("part 1,")}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		limiter.mu.Lock()
+		uploads := limiter.uploads
+		limiter.mu.Unlock()
+		if uploads == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("upload stream never started")
+		}
+	}
+	second := client.WriteObject(ctx)
+	second.RequestHeader().Set("Authorization", "Bearer bob")
+	second.Send(&storagev1.WriteObjectRequest{Spec: &storagev1.UploadObjectRequest{Bucket: "cold", Name: "small"}})
+	_, err = second.CloseAndReceive()
+	throttled(err)
+	first.Send(&storagev1.WriteObjectRequest{Data: []byte("part 2")})
+	resp, err := first.CloseAndReceive()
+	if err != nil || resp.Msg.PersistedSize != 13 {
+		t.Fatalf("unexpected WriteObject result: %v (%v)", resp, err)
+	}
+
+	// Unauthenticated callers are limited by address, whatever principal
+	// they claim.
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		req := connect.NewRequest(&storagev1.GetBucketRequest{Name: "cold"})
+		req.Header().Set(PrincipalHeader, fmt.Sprintf("user%d@example.com", i))
+		if _, err := client.GetBucket(ctx, req); err != nil {
+			t.Fatalf("anonymous request %d failed: %v", i, err)
+		}
+	}
+	req := connect.NewRequest(&storagev1.GetBucketRequest{Name: "cold"})
+	req.Header().Set(PrincipalHeader, "someone-else@example.com")
+	_, err = client.GetBucket(ctx, req)
+	throttled(err)
+	limiter.mu.Lock()
+	_, ok := limiter.buckets["principal/peer:127.0.0.1"]
+	limiter.mu.Unlock()
+	if !ok {
+		t.Error("expected a bucket for the peer address")
+	}
+
+	// Buckets are dropped once refilled.
+	now = now.Add(time.Minute)
+	if err := getBucket("ci", "cold"); err != nil {
+		t.Fatalf("request after a minute failed: %v", err)
+	}
+	limiter.mu.Lock()
+	remaining := len(limiter.buckets)
+	limiter.mu.Unlock()
+	if remaining != 1 {
+		t.Errorf("expected only the bucket just used to remain, got %d", remaining)
+	}
+
+	limiter.Reload(RateLimitConfig{})
+	for i := 0; i < 5; i++ {
+		if err := getBucket("bob", "hot"); err != nil {
+			t.Fatalf("request after reload failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+
+	"go.etcd.io/bbolt"
+)
+
+// sealedRecordPrefix marks record values encrypted with the metadata key and
+// bound to the bucket path and key they are stored under. JSON values never
+// start with a NUL byte, so plaintext and sealed values can be told apart
+// while a database is being migrated.
+var sealedRecordPrefix = []byte("\x00sealed2")
+
+// unboundRecordPrefix marks values sealed before records were bound to their
+// location. They are still read, and sealPlaintextRecords reseals them.
+var unboundRecordPrefix = []byte("\x00sealed1")
+
+// recordAD is the associated data binding a sealed value to path and key, so
+// a value copied elsewhere in the database fails to open.
+func recordAD(path, key string) []byte {
+	return []byte(path + "\x00" + key)
+}
+
+// getRecord decodes the JSON value stored under key in b, found at path,
+// into v. It reports false when the key is absent.
+func (s *StorageServer) getRecord(b *bbolt.Bucket, path, key string, v any) (bool, error) {
+	data := b.Get([]byte(key))
+	if data == nil {
+		return false, nil
+	}
+	return true, s.decodeRecord(path, key, data, v)
+}
+
+// decodeRecord decodes the record value stored under key at path into v,
+// decrypting it when sealed.
+func (s *StorageServer) decodeRecord(path, key string, data []byte, v any) error {
+	data, err := s.openRecord(path, key, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// openRecord returns the plaintext of a record value.
+func (s *StorageServer) openRecord(path, key string, data []byte) ([]byte, error) {
+	var ad []byte
+	sealed, ok := bytes.CutPrefix(data, sealedRecordPrefix)
+	if ok {
+		ad = recordAD(path, key)
+	} else if sealed, ok = bytes.CutPrefix(data, unboundRecordPrefix); !ok {
+		return data, nil
+	}
+	aead := s.recordSealer
+	if aead == nil {
+		return nil, errors.New("record is encrypted but no metadata key is configured")
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("sealed record is truncated")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], ad)
+}
+
+// putRecord stores v as JSON under key in b, found at path, sealed when the
+// server has a metadata key.
+func (s *StorageServer) putRecord(b *bbolt.Bucket, path, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if s.recordSealer != nil {
+		if data, err = sealRecord(s.recordSealer, path, key, data); err != nil {
+			return err
+		}
+	}
+	return b.Put([]byte(key), data)
+}
+
+func sealRecord(aead cipher.AEAD, path, key string, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out := append(append([]byte(nil), sealedRecordPrefix...), nonce...)
+	return aead.Seal(out, nonce, data, recordAD(path, key)), nil
+}
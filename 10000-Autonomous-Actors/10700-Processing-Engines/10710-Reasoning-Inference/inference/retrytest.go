@@ -0,0 +1,262 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+
+	"connectrpc.com/connect"
+)
+
+// RetryTestHeader names the retry test whose instructions a request
+// consumes, as in Google's storage testbench.
+const RetryTestHeader = "X-Retry-Test-Id"
+
+// retryMethods maps the JSON API methods retry tests give instructions for
+// to the StorageService RPCs that stand in for them. Bucket and object
+// ACLs share one set of RPCs.
+var retryMethods = map[string][]string{
+	"storage.buckets.get":                {"GetBucket"},
+	"storage.buckets.getIamPolicy":       {"GetIamPolicy"},
+	"storage.buckets.insert":             {"CreateBucket"},
+	"storage.buckets.list":               {"ListBuckets"},
+	"storage.buckets.patch":              {"UpdateBucket"},
+	"storage.buckets.setIamPolicy":       {"SetIamPolicy"},
+	"storage.buckets.testIamPermissions": {"TestIamPermissions"},
+	"storage.buckets.update":             {"UpdateBucket"},
+	"storage.bucket_acl.delete":          {"DeleteAcl"},
+	"storage.bucket_acl.insert":          {"InsertAcl"},
+	"storage.bucket_acl.list":            {"ListAcl"},
+	"storage.bucket_acl.patch":           {"PatchAcl"},
+	"storage.notifications.delete":       {"DeleteNotificationConfig"},
+	"storage.notifications.get":          {"GetNotificationConfig"},
+	"storage.notifications.insert":       {"CreateNotificationConfig"},
+	"storage.notifications.list":         {"ListNotificationConfigs"},
+	"storage.object_acl.delete":          {"DeleteAcl"},
+	"storage.object_acl.insert":          {"InsertAcl"},
+	"storage.object_acl.list":            {"ListAcl"},
+	"storage.object_acl.patch":           {"PatchAcl"},
+	"storage.objects.compose":            {"ComposeObject"},
+	"storage.objects.copy":               {"RewriteObject"},
+	"storage.objects.delete":             {"DeleteObject"},
+	"storage.objects.get":                {"GetObjectMetadata", "ReadObject"},
+	"storage.objects.insert":             {"UploadObject", "WriteObject"},
+	"storage.objects.list":               {"ListObjects"},
+	"storage.objects.patch":              {"UpdateObject"},
+	"storage.objects.rewrite":            {"RewriteObject"},
+	"storage.objects.update":             {"UpdateObject"},
+}
+
+// retryStatusCodes are the Connect codes for the HTTP statuses retry test
+// instructions return.
+var retryStatusCodes = map[int]connect.Code{
+	400: connect.CodeInvalidArgument,
+	401: connect.CodeUnauthenticated,
+	403: connect.CodePermissionDenied,
+	404: connect.CodeNotFound,
+	408: connect.CodeDeadlineExceeded,
+	409: connect.CodeAborted,
+	412: connect.CodeFailedPrecondition,
+	429: connect.CodeResourceExhausted,
+	500: connect.CodeInternal,
+	501: connect.CodeUnimplemented,
+	502: connect.CodeUnavailable,
+	503: connect.CodeUnavailable,
+	504: connect.CodeDeadlineExceeded,
+}
+
+var retryInstruction = regexp.MustCompile(`^return-(\d{3}|reset-connection|broken-stream)(?:-after-(\d+)K)?$`)
+
+// retryFault is the fault an instruction such as "return-503",
+// "return-reset-connection" or "return-broken-stream-after-256K" injects.
+func retryFault(instruction string) (*FaultRule, error) {
+	m := retryInstruction.FindStringSubmatch(instruction)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported instruction %q", instruction)
+	}
+	rule := &FaultRule{Message: "retry test instruction " + instruction}
+	switch m[1] {
+	case "reset-connection", "broken-stream":
+		rule.reset = true
+	default:
+		status, _ := strconv.Atoi(m[1])
+		code, ok := retryStatusCodes[status]
+		if !ok {
+			return nil, fmt.Errorf("unsupported status in instruction %q", instruction)
+		}
+		rule.code = code
+	}
+	if m[2] != "" {
+		k, err := strconv.ParseInt(m[2], 10, 64)
+		if err != nil || k == 0 {
+			return nil, fmt.Errorf("bad size in instruction %q", instruction)
+		}
+		rule.TruncateAfterBytes = k << 10
+	}
+	return rule, nil
+}
+
+// retryTest is a /retry_test resource. Instructions holds, per JSON API
+// method, the instructions its requests have yet to consume.
+type retryTest struct {
+	ID           string              `json:"id"`
+	Instructions map[string][]string `json:"instructions"`
+	Completed    bool                `json:"completed"`
+	Transport    string              `json:"transport,omitempty"`
+}
+
+// RetryTests implements the retry conformance API of Google's storage
+// testbench, which the client libraries' conformance suites drive. POST
+// /retry_test registers failure instructions per JSON API method; each
+// request naming the test in RetryTestHeader then consumes the first
+// instruction left for its method. GET and DELETE /retry_test/{id} read and
+// remove a test, GET /retry_tests lists them.
+//
+// RetryTests is both the HTTP handler for those paths and the Connect
+// interceptor applying the instructions, which like a FaultInjector belongs
+// last in the chain.
+type RetryTests struct {
+	mu    sync.Mutex
+	tests map[string]*retryTest
+	mux   *http.ServeMux
+}
+
+func NewRetryTests() *RetryTests {
+	t := &RetryTests{tests: make(map[string]*retryTest), mux: http.NewServeMux()}
+	t.mux.HandleFunc("POST /retry_test", t.create)
+	t.mux.HandleFunc("GET /retry_test/{id}", t.get)
+	t.mux.HandleFunc("DELETE /retry_test/{id}", t.delete)
+	t.mux.HandleFunc("GET /retry_tests", t.list)
+	return t
+}
+
+func (t *RetryTests) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.mux.ServeHTTP(w, r)
+}
+
+func writeRetryJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (t *RetryTests) create(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Instructions map[string][]string `json:"instructions"`
+		Transport    string              `json:"transport"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid retry test: %v", err), http.StatusBadRequest)
+		return
+	}
+	test := &retryTest{Instructions: make(map[string][]string), Transport: body.Transport, Completed: true}
+	for method, instructions := range body.Instructions {
+		if retryMethods[method] == nil {
+			http.Error(w, fmt.Sprintf("unsupported method %q", method), http.StatusBadRequest)
+			return
+		}
+		for _, instruction := range instructions {
+			if _, err := retryFault(instruction); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		test.Instructions[method] = append([]string{}, instructions...)
+		test.Completed = test.Completed && len(instructions) == 0
+	}
+	b := make([]byte, 16)
+	rand.Read(b)
+	test.ID = hex.EncodeToString(b)
+	slog.Info("Retry test created", "id", test.ID, "instructions", body.Instructions)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tests[test.ID] = test
+	writeRetryJSON(w, test)
+}
+
+func (t *RetryTests) get(w http.ResponseWriter, r *http.Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	test, ok := t.tests[r.PathValue("id")]
+	if !ok {
+		http.Error(w, "retry test not found", http.StatusNotFound)
+		return
+	}
+	writeRetryJSON(w, test)
+}
+
+func (t *RetryTests) delete(w http.ResponseWriter, r *http.Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	id := r.PathValue("id")
+	if _, ok := t.tests[id]; !ok {
+		http.Error(w, "retry test not found", http.StatusNotFound)
+		return
+	}
+	delete(t.tests, id)
+}
+
+func (t *RetryTests) list(w http.ResponseWriter, r *http.Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tests := []*retryTest{}
+	for _, id := range slices.Sorted(maps.Keys(t.tests)) {
+		tests = append(tests, t.tests[id])
+	}
+	writeRetryJSON(w, map[string]any{"retry_test": tests})
+}
+
+// pick consumes the next instruction of the request's retry test for its
+// procedure, if any. Naming an unknown test fails the request.
+func (t *RetryTests) pick(header http.Header, procedure, _, _ string) *FaultRule {
+	id := header.Get(RetryTestHeader)
+	if id == "" {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	test, ok := t.tests[id]
+	if !ok {
+		return &FaultRule{code: connect.CodeNotFound, Message: fmt.Sprintf("retry test %s not found", id)}
+	}
+	name := path.Base(procedure)
+	for _, method := range slices.Sorted(maps.Keys(test.Instructions)) {
+		instructions := test.Instructions[method]
+		if len(instructions) == 0 || !slices.Contains(retryMethods[method], name) {
+			continue
+		}
+		test.Instructions[method] = instructions[1:]
+		test.Completed = true
+		for _, left := range test.Instructions {
+			test.Completed = test.Completed && len(left) == 0
+		}
+		slog.Warn("Applying retry test instruction", "id", id, "method", method, "instruction", instructions[0])
+		// Instructions were checked when the test was created.
+		rule, _ := retryFault(instructions[0])
+		return rule
+	}
+	return nil
+}
+
+func (t *RetryTests) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return injectUnary(t.pick, next)
+}
+
+func (t *RetryTests) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (t *RetryTests) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return injectStreaming(t.pick, next)
+}
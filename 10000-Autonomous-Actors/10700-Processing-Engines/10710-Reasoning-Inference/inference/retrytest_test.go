@@ -0,0 +1,133 @@
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+func TestRetryTests(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	retry := NewRetryTests()
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(retry)))
+	for _, pattern := range []string{"/retry_test", "/retry_test/", "/retry_tests"} {
+		mux.Handle(pattern, retry)
+	}
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	ctx := context.Background()
+
+	post := func(body string) (*http.Response, retryTest) {
+		t.Helper()
+		resp, err := http.Post(httpServer.URL+"/retry_test", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var test retryTest
+		json.NewDecoder(resp.Body).Decode(&test)
+		return resp, test
+	}
+	for _, bad := range []string{
+		`{"instructions": {"storage.hmacKey.get": ["return-503"]}}`,
+		`{"instructions": {"storage.objects.get": ["return-999"]}}`,
+		`{"instructions": {"storage.objects.get": ["stall-for-10s-after-0K"]}}`,
+		`{"instructions": `,
+	} {
+		if resp, _ := post(bad); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected 400 Bad Request, got %s", bad, resp.Status)
+		}
+	}
+	resp, test := post(`{"instructions": {
+		"storage.buckets.insert": ["return-429"],
+		"storage.objects.insert": ["return-reset-connection"],
+		"storage.objects.get": ["return-503", "return-broken-stream-after-1K"]
+	}, "transport": "GRPC"}`)
+	if resp.StatusCode != http.StatusOK || test.ID == "" || test.Completed || test.Transport != "GRPC" {
+		t.Fatalf("unexpected retry test %s %+v", resp.Status, test)
+	}
+	with := func(req interface{ Header() http.Header }) {
+		req.Header().Set(RetryTestHeader, test.ID)
+	}
+
+	// Instructions are consumed in order by the requests naming the test,
+	// and only by those.
+	create := connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"})
+	with(create)
+	if _, err := client.CreateBucket(ctx, create); connect.CodeOf(err) != connect.CodeResourceExhausted {
+		t.Errorf("expected return-429 as ResourceExhausted, got %v", err)
+	}
+	if _, err := client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"})); err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	data := bytes.Repeat([]byte("x"), 4096)
+	upload := connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: data})
+	with(upload)
+	if _, err := client.UploadObject(ctx, upload); err == nil {
+		t.Errorf("expected a reset connection, got %v", err)
+	}
+	upload = connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: data})
+	with(upload)
+	if _, err := client.UploadObject(ctx, upload); err != nil {
+		t.Fatalf("expected the upload retry to succeed, got %v", err)
+	}
+	metadata := connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "b", Name: "o"})
+	with(metadata)
+	if _, err := client.GetObjectMetadata(ctx, metadata); connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Errorf("expected return-503 as Unavailable, got %v", err)
+	}
+	read := connect.NewRequest(&storagev1.ReadObjectRequest{Bucket: "b", Name: "o"})
+	with(read)
+	stream, err := client.ReadObject(ctx, read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := receiveAll(stream); len(got) != 1024 || err == nil {
+		t.Errorf("expected a stream broken after 1K, got %d bytes (%v)", len(got), err)
+	}
+
+	get := func(path string, v any) int {
+		t.Helper()
+		resp, err := http.Get(httpServer.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		json.NewDecoder(resp.Body).Decode(v)
+		return resp.StatusCode
+	}
+	var done retryTest
+	if code := get("/retry_test/"+test.ID, &done); code != http.StatusOK || !done.Completed || len(done.Instructions["storage.objects.get"]) != 0 {
+		t.Errorf("expected a completed test, got %d %+v", code, done)
+	}
+	var list struct {
+		RetryTest []retryTest `json:"retry_test"`
+	}
+	if get("/retry_tests", &list); len(list.RetryTest) != 1 || list.RetryTest[0].ID != test.ID {
+		t.Errorf("unexpected retry tests %+v", list)
+	}
+	del, _ := http.NewRequest(http.MethodDelete, httpServer.URL+"/retry_test/"+test.ID, nil)
+	if resp, err := http.DefaultClient.Do(del); err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("DELETE failed: %v %v", resp, err)
+	}
+	if code := get("/retry_test/"+test.ID, &done); code != http.StatusNotFound {
+		t.Errorf("expected a deleted test to be gone, got %d", code)
+	}
+	metadata = connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "b", Name: "o"})
+	with(metadata)
+	if _, err := client.GetObjectMetadata(ctx, metadata); connect.CodeOf(err) != connect.CodeNotFound || !strings.Contains(err.Error(), "retry test") {
+		t.Errorf("expected an unknown retry test to fail the request, got %v", err)
+	}
+}
@@ -0,0 +1,417 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gopkg.in/yaml.v3"
+)
+
+// Seed pattern fills.
+const (
+	SeedFillZeros  = "zeros"
+	SeedFillRandom = "random"
+	SeedFillText   = "text"
+)
+
+// SeedManifest declares buckets and their objects. Seeding creates what is
+// missing and brings what differs in line with the manifest, so applying a
+// manifest twice changes nothing the second time. Buckets and objects the
+// manifest does not mention are left alone.
+type SeedManifest struct {
+	Buckets []SeedBucket `json:"buckets" yaml:"buckets"`
+
+	// dir resolves relative object file paths; empty means the working
+	// directory.
+	dir string
+}
+
+// SeedBucket declares a bucket. Fields left empty keep their current value,
+// or the CreateBucket default for a new bucket. Project and the predefined
+// ACLs only apply when the bucket is created.
+type SeedBucket struct {
+	Name                       string              `json:"name" yaml:"name"`
+	Location                   string              `json:"location,omitempty" yaml:"location,omitempty"`
+	StorageClass               string              `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
+	Labels                     map[string]string   `json:"labels,omitempty" yaml:"labels,omitempty"`
+	DefaultObjectMetadata      map[string]string   `json:"defaultObjectMetadata,omitempty" yaml:"defaultObjectMetadata,omitempty"`
+	LifecycleRules             []SeedLifecycleRule `json:"lifecycleRules,omitempty" yaml:"lifecycleRules,omitempty"`
+	UniformBucketLevelAccess   *bool               `json:"uniformBucketLevelAccess,omitempty" yaml:"uniformBucketLevelAccess,omitempty"`
+	DefaultKMSKeyName          string              `json:"defaultKmsKeyName,omitempty" yaml:"defaultKmsKeyName,omitempty"`
+	Project                    string              `json:"project,omitempty" yaml:"project,omitempty"`
+	PredefinedAcl              string              `json:"predefinedAcl,omitempty" yaml:"predefinedAcl,omitempty"`
+	PredefinedDefaultObjectAcl string              `json:"predefinedDefaultObjectAcl,omitempty" yaml:"predefinedDefaultObjectAcl,omitempty"`
+	Objects                    []SeedObject        `json:"objects,omitempty" yaml:"objects,omitempty"`
+}
+
+// SeedLifecycleRule is a bucket lifecycle rule: Action is "Delete" or
+// "SetStorageClass", applied to objects matching every condition given.
+type SeedLifecycleRule struct {
+	Action              string   `json:"action" yaml:"action"`
+	StorageClass        string   `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
+	AgeDays             int32    `json:"ageDays,omitempty" yaml:"ageDays,omitempty"`
+	MatchesStorageClass []string `json:"matchesStorageClass,omitempty" yaml:"matchesStorageClass,omitempty"`
+	MatchesPrefix       []string `json:"matchesPrefix,omitempty" yaml:"matchesPrefix,omitempty"`
+}
+
+// SeedObject declares an object. Its data comes from at most one of
+// Content, File and Pattern; with none it is empty. An object is rewritten
+// when its data, custom metadata or storage class differ from the
+// declaration.
+type SeedObject struct {
+	Name          string            `json:"name" yaml:"name"`
+	Metadata      map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	StorageClass  string            `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
+	PredefinedAcl string            `json:"predefinedAcl,omitempty" yaml:"predefinedAcl,omitempty"`
+	Content       string            `json:"content,omitempty" yaml:"content,omitempty"`
+	// File is read by the server, relative to the manifest's directory.
+	File    string       `json:"file,omitempty" yaml:"file,omitempty"`
+	Pattern *SeedPattern `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+}
+
+// SeedPattern generates Size bytes: zeros, random bytes that Seed makes
+// reproducible, or Text repeated.
+type SeedPattern struct {
+	Size int64  `json:"size" yaml:"size"`
+	Fill string `json:"fill,omitempty" yaml:"fill,omitempty"`
+	Seed uint64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+	Text string `json:"text,omitempty" yaml:"text,omitempty"`
+}
+
+// SeedResult counts what seeding changed.
+type SeedResult struct {
+	BucketsCreated   int `json:"bucketsCreated"`
+	BucketsUpdated   int `json:"bucketsUpdated"`
+	ObjectsWritten   int `json:"objectsWritten"`
+	ObjectsUnchanged int `json:"objectsUnchanged"`
+}
+
+// LoadSeedManifest reads a seed manifest file, YAML or JSON.
+func LoadSeedManifest(path string) (*SeedManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed manifest: %v", err)
+	}
+	m, err := ParseSeedManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	m.dir = filepath.Dir(path)
+	return m, nil
+}
+
+// ParseSeedManifest parses a YAML or JSON seed manifest and checks that it
+// is well formed. Unknown fields are errors, to catch misspellings.
+func ParseSeedManifest(data []byte) (*SeedManifest, error) {
+	m := &SeedManifest{}
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("{")) {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(m); err != nil {
+			return nil, fmt.Errorf("invalid seed manifest: %v", err)
+		}
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(m); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid seed manifest: %v", err)
+		}
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid seed manifest: %v", err)
+	}
+	return m, nil
+}
+
+func (m *SeedManifest) validate() error {
+	buckets := map[string]bool{}
+	for _, b := range m.Buckets {
+		if err := validateBucketName(b.Name); err != nil {
+			return err
+		}
+		if buckets[b.Name] {
+			return fmt.Errorf("bucket %s is declared twice", b.Name)
+		}
+		buckets[b.Name] = true
+		objects := map[string]bool{}
+		for _, o := range b.Objects {
+			if err := validateObjectName(o.Name); err != nil {
+				return fmt.Errorf("bucket %s: %v", b.Name, err)
+			}
+			if objects[o.Name] {
+				return fmt.Errorf("object %s is declared twice", objectKey(b.Name, o.Name))
+			}
+			objects[o.Name] = true
+			sources := 0
+			for _, set := range []bool{o.Content != "", o.File != "", o.Pattern != nil} {
+				if set {
+					sources++
+				}
+			}
+			if sources > 1 {
+				return fmt.Errorf("object %s: content, file and pattern are exclusive", objectKey(b.Name, o.Name))
+			}
+			if p := o.Pattern; p != nil {
+				if p.Size < 0 {
+					return fmt.Errorf("object %s: negative pattern size", objectKey(b.Name, o.Name))
+				}
+				switch p.Fill {
+				case "", SeedFillZeros, SeedFillRandom:
+				case SeedFillText:
+					if p.Text == "" && p.Size > 0 {
+						return fmt.Errorf("object %s: text fill needs text", objectKey(b.Name, o.Name))
+					}
+				default:
+					return fmt.Errorf("object %s: unknown fill %q", objectKey(b.Name, o.Name), p.Fill)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// data returns the bytes o declares.
+func (o *SeedObject) data(dir string) ([]byte, error) {
+	switch {
+	case o.File != "":
+		path := o.File
+		if !filepath.IsAbs(path) && dir != "" {
+			path = filepath.Join(dir, path)
+		}
+		return os.ReadFile(path)
+	case o.Pattern != nil:
+		return o.Pattern.generate(), nil
+	default:
+		return []byte(o.Content), nil
+	}
+}
+
+func (p *SeedPattern) generate() []byte {
+	data := make([]byte, p.Size)
+	switch p.Fill {
+	case SeedFillRandom:
+		rng := rand.New(rand.NewPCG(p.Seed, p.Seed))
+		for i := range data {
+			data[i] = byte(rng.Uint32())
+		}
+	case SeedFillText:
+		for i := 0; i < len(data); i += copy(data[i:], p.Text) {
+		}
+	}
+	return data
+}
+
+// Seed applies m as the principal in ctx, if any, through the same handlers
+// as the equivalent RPCs, so validation, quotas and notifications apply.
+func (s *StorageServer) Seed(ctx context.Context, m *SeedManifest) (*SeedResult, error) {
+	return s.seed(ctx, http.Header{}, m)
+}
+
+func (s *StorageServer) seed(ctx context.Context, header http.Header, m *SeedManifest) (*SeedResult, error) {
+	res := &SeedResult{}
+	for i := range m.Buckets {
+		b := &m.Buckets[i]
+		if err := s.seedBucket(ctx, header, b, res); err != nil {
+			return nil, fmt.Errorf("bucket %s: %w", b.Name, err)
+		}
+		for j := range b.Objects {
+			o := &b.Objects[j]
+			if err := s.seedObject(ctx, header, b.Name, o, m.dir, res); err != nil {
+				return nil, fmt.Errorf("object %s: %w", objectKey(b.Name, o.Name), err)
+			}
+		}
+	}
+	slog.Info("Seeded storage", "bucketsCreated", res.BucketsCreated, "bucketsUpdated", res.BucketsUpdated,
+		"objectsWritten", res.ObjectsWritten, "objectsUnchanged", res.ObjectsUnchanged)
+	return res, nil
+}
+
+// withHeader returns a request for msg carrying header, which identifies
+// the caller when no interceptor has.
+func withHeader[T any](msg *T, header http.Header) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	for k, v := range header {
+		req.Header()[k] = v
+	}
+	return req
+}
+
+func (s *StorageServer) seedBucket(ctx context.Context, header http.Header, b *SeedBucket, res *SeedResult) error {
+	patch := &storagev1.Bucket{
+		Name:                     b.Name,
+		Location:                 b.Location,
+		StorageClass:             b.StorageClass,
+		Labels:                   b.Labels,
+		DefaultObjectMetadata:    b.DefaultObjectMetadata,
+		UniformBucketLevelAccess: b.UniformBucketLevelAccess != nil && *b.UniformBucketLevelAccess,
+		DefaultKmsKeyName:        b.DefaultKMSKeyName,
+	}
+	for _, rule := range b.LifecycleRules {
+		patch.LifecycleRules = append(patch.LifecycleRules, lifecycleRulesToProto([]lifecycleRule{lifecycleRule(rule)})...)
+	}
+
+	var rec *bucketRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		rec, err = s.getBucketRecord(tx, b.Name)
+		return err
+	})
+	if errors.Is(err, errBucketNotFound) {
+		_, err := s.CreateBucket(ctx, withHeader(&storagev1.CreateBucketRequest{
+			Name:                       patch.Name,
+			Location:                   patch.Location,
+			StorageClass:               patch.StorageClass,
+			Labels:                     patch.Labels,
+			DefaultObjectMetadata:      patch.DefaultObjectMetadata,
+			LifecycleRules:             patch.LifecycleRules,
+			PredefinedAcl:              b.PredefinedAcl,
+			PredefinedDefaultObjectAcl: b.PredefinedDefaultObjectAcl,
+			UniformBucketLevelAccess:   patch.UniformBucketLevelAccess,
+			DefaultKmsKeyName:          patch.DefaultKmsKeyName,
+			Project:                    b.Project,
+		}, header))
+		if err != nil {
+			return err
+		}
+		res.BucketsCreated++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Update only the declared fields that differ.
+	var paths []string
+	declared := map[string]bool{
+		"location":                    b.Location != "",
+		"storage_class":               b.StorageClass != "",
+		"labels":                      b.Labels != nil,
+		"default_object_metadata":     b.DefaultObjectMetadata != nil,
+		"lifecycle_rules":             b.LifecycleRules != nil,
+		"uniform_bucket_level_access": b.UniformBucketLevelAccess != nil,
+		"default_kms_key_name":        b.DefaultKMSKeyName != "",
+	}
+	for _, path := range []string{"location", "storage_class", "labels", "default_object_metadata", "lifecycle_rules", "uniform_bucket_level_access", "default_kms_key_name"} {
+		if !declared[path] {
+			continue
+		}
+		want := *rec
+		if err := applyBucketMask(&want, patch, []string{path}); err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(&want, rec) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	_, err = s.UpdateBucket(ctx, withHeader(&storagev1.UpdateBucketRequest{Bucket: patch, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}}, header))
+	if err != nil {
+		return err
+	}
+	res.BucketsUpdated++
+	return nil
+}
+
+func (s *StorageServer) seedObject(ctx context.Context, header http.Header, bucket string, o *SeedObject, dir string, res *SeedResult) error {
+	data, err := o.data(dir)
+	if err != nil {
+		return err
+	}
+	current, err := s.seedObjectCurrent(ctx, bucket, o, data)
+	if err != nil {
+		return err
+	}
+	if current {
+		res.ObjectsUnchanged++
+		return nil
+	}
+	_, err = s.UploadObject(ctx, withHeader(&storagev1.UploadObjectRequest{
+		Bucket:        bucket,
+		Name:          o.Name,
+		Data:          data,
+		Metadata:      o.Metadata,
+		StorageClass:  o.StorageClass,
+		PredefinedAcl: o.PredefinedAcl,
+	}, header))
+	if err != nil {
+		return err
+	}
+	res.ObjectsWritten++
+	return nil
+}
+
+// seedObjectCurrent reports whether the stored object already matches o
+// with data, as UploadObject would have stored it.
+func (s *StorageServer) seedObjectCurrent(ctx context.Context, bucket string, o *SeedObject, data []byte) (bool, error) {
+	var rec *bucketRecord
+	var obj *objectRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		if rec, err = s.getBucketRecord(tx, bucket); err != nil {
+			return err
+		}
+		obj, _, err = s.getObjectRecord(tx, bucket, o.Name)
+		return err
+	})
+	if err != nil || obj == nil || obj.CustomerEncryption != nil || obj.Size != int64(len(data)) {
+		return false, err
+	}
+	class, err := normalizeStorageClass(o.StorageClass, rec.StorageClass)
+	if err != nil || class != obj.StorageClass {
+		return false, nil
+	}
+	if !maps.Equal(mergeStrings(copyStrings(rec.DefaultObjectMetadata), o.Metadata), obj.Metadata) {
+		return false, nil
+	}
+	stored, err := s.readObjectData(ctx, obj, nil)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(stored, data), nil
+}
+
+// SeedStorage applies a seed manifest. The caller must be a server
+// administrator, and the seed then acts with the caller's bucket access.
+func (s *StorageServer) SeedStorage(ctx context.Context, req *connect.Request[storagev1.SeedStorageRequest]) (*connect.Response[storagev1.SeedStorageResponse], error) {
+	slog.Info("SeedStorage", "size", len(req.Msg.Manifest), "principal", principalOf(ctx, req.Header()))
+	if err := s.authorizeAdmin(ctx, req.Header(), "seed storage"); err != nil {
+		return nil, err
+	}
+	m, err := ParseSeedManifest(req.Msg.Manifest)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	res, err := s.seed(ctx, req.Header(), m)
+	if err != nil {
+		var cerr *connect.Error
+		if errors.As(err, &cerr) {
+			return nil, connect.NewError(cerr.Code(), err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(&storagev1.SeedStorageResponse{
+		BucketsCreated:   int64(res.BucketsCreated),
+		BucketsUpdated:   int64(res.BucketsUpdated),
+		ObjectsWritten:   int64(res.ObjectsWritten),
+		ObjectsUnchanged: int64(res.ObjectsUnchanged),
+	}), nil
+}
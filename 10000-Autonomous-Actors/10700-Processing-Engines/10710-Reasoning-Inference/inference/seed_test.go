@@ -0,0 +1,150 @@
+package inference
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+)
+
+const testSeedManifest = `
+buckets:
+  - name: fixtures
+    storageClass: nearline
+    labels: {env: test}
+    defaultObjectMetadata: {owner: ci}
+    lifecycleRules:
+      - action: Delete
+        ageDays: 30
+    objects:
+      - name: hello.txt
+        content: hello
+        metadata: {lang: en}
+      - name: data/logo.png
+        file: logo.png
+      - name: big.bin
+        pattern: {size: 100000, fill: random, seed: 7}
+      - name: lorem.txt
+        pattern: {size: 11, fill: text, text: "lorem "}
+  - name: empty
+`
+
+func TestSeed(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "logo.png"), []byte("\x89PNG"), 0644)
+	path := filepath.Join(dir, "seed.yaml")
+	os.WriteFile(path, []byte(testSeedManifest), 0644)
+	m, err := LoadSeedManifest(path)
+	if err != nil {
+		t.Fatalf("LoadSeedManifest failed: %v", err)
+	}
+
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	ctx := context.Background()
+	res, err := server.Seed(ctx, m)
+	if err != nil || *res != (SeedResult{BucketsCreated: 2, ObjectsWritten: 4}) {
+		t.Fatalf("first seed: %+v (%v)", res, err)
+	}
+	read := func(name string) []byte {
+		t.Helper()
+		obj, err := server.lookupObject(ctx, "fixtures", name)
+		if err != nil {
+			t.Fatalf("lookup %s: %v", name, err)
+		}
+		data, err := server.readObjectData(ctx, obj, nil)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		return data
+	}
+	if got := string(read("lorem.txt")); got != "lorem lorem" {
+		t.Errorf("unexpected text pattern %q", got)
+	}
+	if got := string(read("data/logo.png")); got != "\x89PNG" {
+		t.Errorf("unexpected file content %q", got)
+	}
+	big := read("big.bin")
+	if len(big) != 100000 || bytes.Count(big, []byte{0}) > 1000 {
+		t.Errorf("unexpected random pattern of %d bytes", len(big))
+	}
+	meta, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "fixtures", Name: "hello.txt"}))
+	if err != nil || meta.Msg.StorageClass != StorageClassNearline || meta.Msg.Metadata["owner"] != "ci" || meta.Msg.Metadata["lang"] != "en" {
+		t.Errorf("unexpected seeded object: %v (%v)", meta, err)
+	}
+
+	// Seeding again changes nothing, random data included.
+	res, err = server.Seed(ctx, m)
+	if err != nil || *res != (SeedResult{ObjectsUnchanged: 4}) {
+		t.Errorf("second seed must be a no-op: %+v (%v)", res, err)
+	}
+
+	// Drift is brought back in line with the manifest.
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "fixtures", Name: "hello.txt", Data: []byte("changed")}))
+	server.UpdateBucket(ctx, connect.NewRequest(&storagev1.UpdateBucketRequest{Bucket: &storagev1.Bucket{Name: "fixtures", Labels: map[string]string{"env": "prod"}}}))
+	res, err = server.Seed(ctx, m)
+	if err != nil || *res != (SeedResult{BucketsUpdated: 1, ObjectsWritten: 1, ObjectsUnchanged: 3}) {
+		t.Errorf("drifted seed: %+v (%v)", res, err)
+	}
+	if got := string(read("hello.txt")); got != "hello" {
+		t.Errorf("object not restored: %q", got)
+	}
+	bucket, err := server.GetBucket(ctx, connect.NewRequest(&storagev1.GetBucketRequest{Name: "fixtures"}))
+	if err != nil || bucket.Msg.Bucket.Labels["env"] != "test" || len(bucket.Msg.Bucket.LifecycleRules) != 1 {
+		t.Errorf("bucket not restored: %v (%v)", bucket, err)
+	}
+}
+
+func TestSeedStorageRPC(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory(), WithAdmins(alice, "user:bob@example.com"))
+	defer server.Close()
+	ctx := context.Background()
+	seed := func(manifest string) (*storagev1.SeedStorageResponse, error) {
+		resp, err := server.SeedStorage(WithPrincipal(ctx, alice), connect.NewRequest(&storagev1.SeedStorageRequest{Manifest: []byte(manifest)}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	resp, err := seed(`{"buckets": [{"name": "json", "objects": [{"name": "a", "content": "x"}]}]}`)
+	if err != nil || resp.BucketsCreated != 1 || resp.ObjectsWritten != 1 {
+		t.Errorf("JSON seed: %v (%v)", resp, err)
+	}
+	for _, bad := range []string{
+		`buckets: [{name: b, objects: [{name: o, content: x, file: y}]}]`,
+		`buckets: [{name: b, objects: [{name: o, pattern: {size: 1, fill: stripes}}]}]`,
+		`buckets: [{name: b, colour: red}]`,
+		`buckets: [{name: b}, {name: b}]`,
+		`buckets: [{name: .blobs}]`,
+	} {
+		if _, err := seed(bad); connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("expected InvalidArgument for %s, got %v", bad, err)
+		}
+	}
+
+	// Only authenticated administrators seed.
+	manifest := []byte(`buckets: [{name: private, objects: [{name: o}]}]`)
+	if _, err := server.SeedStorage(ctx, connect.NewRequest(&storagev1.SeedStorageRequest{Manifest: manifest})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated for an anonymous seed, got %v", err)
+	}
+	if _, err := server.SeedStorage(ctx, as(alice, connect.NewRequest(&storagev1.SeedStorageRequest{Manifest: manifest}))); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated for an administrator named only by header, got %v", err)
+	}
+	if _, err := server.SeedStorage(WithPrincipal(ctx, "user:carol@example.com"), connect.NewRequest(&storagev1.SeedStorageRequest{Manifest: manifest})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied for a caller who is not an administrator, got %v", err)
+	}
+
+	// Seeds act as the caller.
+	server.CreateBucket(ctx, as(alice, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "private"})))
+	req := connect.NewRequest(&storagev1.SeedStorageRequest{Manifest: manifest})
+	if _, err := server.SeedStorage(WithPrincipal(ctx, "user:bob@example.com"), req); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected PermissionDenied seeding alice's bucket, got %v", err)
+	}
+}
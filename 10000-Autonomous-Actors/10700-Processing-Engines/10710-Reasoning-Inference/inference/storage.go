@@ -1,153 +1,392 @@
-//go:build !wasm
-
-package inference
-
-import (
-	"context"
-	"encoding/json"
-	"fmt"
-	"log/slog"
-	"os"
-	"path/filepath"
-	"strings"
-
-	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
-	"connectrpc.com/connect"
-	"go.etcd.io/bbolt"
-)
-
-type StorageServer struct {
-	db      *bbolt.DB
-	baseDir string
-}
-
-const (
-	bucketMetadata = "metadata"
-)
-
-func NewStorageServer(storageDir string) *StorageServer {
-	os.MkdirAll(storageDir, 0755)
-	dbPath := filepath.Join(storageDir, "storage.db")
-	
-	db, err := bbolt.Open(dbPath, 0600, nil)
-	if err != nil {
-		slog.Error("Failed to open BoltDB", "path", dbPath, "error", err)
-		panic(err)
-	}
-
-	err = db.Update(func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte(bucketMetadata))
-		return err
-	})
-	if err != nil { panic(err) }
-
-	return &StorageServer{
-		db:      db,
-		baseDir: storageDir,
-	}
-}
-
-func (s *StorageServer) CreateBucket(ctx context.Context, req *connect.Request[storagev1.CreateBucketRequest]) (*connect.Response[storagev1.CreateBucketResponse], error) {
-	slog.Info("CreateBucket", "name", req.Msg.Name)
-	path := filepath.Join(s.baseDir, req.Msg.Name)
-	if err := os.MkdirAll(path, 0755); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create bucket: %v", err))
-	}
-	return connect.NewResponse(&storagev1.CreateBucketResponse{}), nil
-}
-
-func (s *StorageServer) UploadObject(ctx context.Context, req *connect.Request[storagev1.UploadObjectRequest]) (*connect.Response[storagev1.UploadObjectResponse], error) {
-	slog.Info("UploadObject", "bucket", req.Msg.Bucket, "name", req.Msg.Name)
-	
-	bucketPath := filepath.Join(s.baseDir, req.Msg.Bucket)
-	if _, err := os.Stat(bucketPath); os.IsNotExist(err) {
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("bucket not found: %s", req.Msg.Bucket))
-	}
-
-	objectPath := filepath.Join(bucketPath, req.Msg.Name)
-	if err := os.MkdirAll(filepath.Dir(objectPath), 0755); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create object path: %v", err))
-	}
-
-	if err := os.WriteFile(objectPath, req.Msg.Data, 0644); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to write object: %v", err))
-	}
-
-	// Save metadata in BoltDB
-	err := s.db.Update(func(tx *bbolt.Tx) error {
-		b := tx.Bucket([]byte(bucketMetadata))
-		metaKey := req.Msg.Bucket + "/" + req.Msg.Name
-		
-		meta := make(map[string]string)
-		for k, v := range req.Msg.Metadata { meta[k] = v }
-		
-		data, _ := json.Marshal(meta)
-		return b.Put([]byte(metaKey), data)
-	})
-
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
-
-	return connect.NewResponse(&storagev1.UploadObjectResponse{}), nil
-}
-
-func (s *StorageServer) GetObjectMetadata(ctx context.Context, req *connect.Request[storagev1.GetObjectMetadataRequest]) (*connect.Response[storagev1.GetObjectMetadataResponse], error) {
-	slog.Info("GetObjectMetadata", "bucket", req.Msg.Bucket, "name", req.Msg.Name)
-	
-	path := filepath.Join(s.baseDir, req.Msg.Bucket, req.Msg.Name)
-	info, err := os.Stat(path)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("object not found: %v", err))
-	}
-
-	metadata := make(map[string]string)
-	err = s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket([]byte(bucketMetadata))
-		metaKey := req.Msg.Bucket + "/" + req.Msg.Name
-		data := b.Get([]byte(metaKey))
-		if data != nil {
-			return json.Unmarshal(data, &metadata)
-		}
-		return nil
-	})
-
-	return connect.NewResponse(&storagev1.GetObjectMetadataResponse{
-		Bucket:   req.Msg.Bucket,
-		Name:     req.Msg.Name,
-		Size:     info.Size(),
-		Metadata: metadata,
-	}), nil
-}
-
-func (s *StorageServer) ListObjects(ctx context.Context, req *connect.Request[storagev1.ListObjectsRequest]) (*connect.Response[storagev1.ListObjectsResponse], error) {
-	slog.Info("ListObjects", "bucket", req.Msg.Bucket, "prefix", req.Msg.Prefix)
-	
-	bucketPath := filepath.Join(s.baseDir, req.Msg.Bucket)
-	var names []string
-	filepath.Walk(bucketPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() { return nil }
-		rel, _ := filepath.Rel(bucketPath, path)
-		if req.Msg.Prefix == "" || strings.HasPrefix(rel, req.Msg.Prefix) {
-			names = append(names, rel)
-		}
-		return nil
-	})
-
-	return connect.NewResponse(&storagev1.ListObjectsResponse{ObjectNames: names}), nil
-}
-
-func (s *StorageServer) GetDownloadURL(ctx context.Context, req *connect.Request[storagev1.GetDownloadURLRequest]) (*connect.Response[storagev1.GetDownloadURLResponse], error) {
-	slog.Info("GetDownloadURL", "bucket", req.Msg.Bucket, "name", req.Msg.Name)
-	path := filepath.Join(s.baseDir, req.Msg.Bucket, req.Msg.Name)
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("object not found: %s/%s", req.Msg.Bucket, req.Msg.Name))
-	}
-
-	url := fmt.Sprintf("file://%s", path)
-	return connect.NewResponse(&storagev1.GetDownloadURLResponse{Url: url}), nil
-}
-
-func (s *StorageServer) Close() error {
-	return s.db.Close()
-}
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+type StorageServer struct {
+	db      *bbolt.DB
+	backend Backend
+	// dir is the data directory, empty in memory.
+	dir string
+
+	// inMemory keeps the database off disk; removeDB, when set, discards
+	// its backing file on Close.
+	inMemory bool
+	removeDB func()
+
+	firstByteLatency map[string]time.Duration
+	lastGeneration   atomic.Int64
+	notifier         *notifier
+	quotas           Quotas
+	contentAddressed bool
+	faults           *FaultInjector
+	admins           []string
+
+	// blobPins counts the writes of each blob awaiting their transaction.
+	blobsMu  sync.Mutex
+	blobPins map[string]int
+	// dataLocks are striped by object name; see lockObjectData.
+	dataLocks [64]sync.Mutex
+
+	kms             KMS
+	metadataKeyName string
+	metadataKey     *customerKey
+	// recordSealer seals record values when encryption at rest is enabled.
+	recordSealer cipher.AEAD
+	// background tracks re-encryption started by key rotation.
+	background sync.WaitGroup
+}
+
+// Option configures optional StorageServer behaviour.
+type Option func(*StorageServer)
+
+const (
+	// bucketMetadata is the flat object index, keyed "bucket/name", that
+	// bucketObjects replaced. It only remains in databases awaiting
+	// migrateObjectIndex.
+	bucketMetadata = "metadata"
+)
+
+func NewStorageServer(storageDir string, opts ...Option) *StorageServer {
+	s := &StorageServer{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	var db *bbolt.DB
+	var err error
+	if s.inMemory {
+		db, s.removeDB, err = openMemoryDB()
+		if err != nil {
+			slog.Error("Failed to open in-memory BoltDB", "error", err)
+			panic(err)
+		}
+	} else {
+		s.dir = storageDir
+		os.MkdirAll(storageDir, 0755)
+		dbPath := filepath.Join(storageDir, "storage.db")
+
+		// A database held by another process, such as a running
+		// StorageManager under storage-admin, fails the open rather than
+		// blocking it.
+		db, err = bbolt.Open(dbPath, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+		if err != nil {
+			slog.Error("Failed to open BoltDB", "path", dbPath, "error", err)
+			panic(err)
+		}
+	}
+	s.db = db
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range []string{bucketBuckets, bucketEarlyDeletions, bucketNotifications, bucketIAM, bucketKeys, bucketBlobs, bucketAudit} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	switch {
+	case s.backend != nil:
+	case s.inMemory:
+		s.backend = NewMemoryBackend()
+	default:
+		s.backend = NewFSBackend(storageDir)
+	}
+	if s.kms != nil && s.metadataKeyName != "" {
+		if err := s.openMetadataKey(context.Background()); err != nil {
+			slog.Error("Failed to open metadata encryption key", "kmsKey", s.metadataKeyName, "error", err)
+			panic(err)
+		}
+	}
+	if err := s.adoptLegacyBuckets(); err != nil {
+		slog.Error("Failed to adopt legacy buckets", "path", storageDir, "error", err)
+		panic(err)
+	}
+	if err := s.ownPolicylessBuckets(); err != nil {
+		slog.Error("Failed to own buckets without a policy", "path", storageDir, "error", err)
+		panic(err)
+	}
+	if err := s.migrateObjectIndex(); err != nil {
+		slog.Error("Failed to build object index", "path", storageDir, "error", err)
+		panic(err)
+	}
+	if err := s.initUsage(); err != nil {
+		slog.Error("Failed to compute storage usage", "path", storageDir, "error", err)
+		panic(err)
+	}
+	return s
+}
+
+func (s *StorageServer) CreateBucket(ctx context.Context, req *connect.Request[storagev1.CreateBucketRequest]) (*connect.Response[storagev1.CreateBucketResponse], error) {
+	slog.Info("CreateBucket", "name", req.Msg.Name)
+	if err := validateBucketName(req.Msg.Name); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	rec := newBucketRecord(req.Msg.Name, time.Now().UTC())
+	if req.Msg.Location != "" {
+		rec.Location = req.Msg.Location
+	}
+	class, err := normalizeStorageClass(req.Msg.StorageClass, defaultStorageClass)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	rec.StorageClass = class
+	rec.Labels = copyStrings(req.Msg.Labels)
+	rec.DefaultObjectMetadata = copyStrings(req.Msg.DefaultObjectMetadata)
+	if rec.Lifecycle, err = lifecycleRulesFromProto(req.Msg.LifecycleRules); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	rec.Project = req.Msg.Project
+	creator := principalOf(ctx, req.Header())
+	rec.UniformBucketLevelAccess = req.Msg.UniformBucketLevelAccess
+	if rec.DefaultKMSKeyName = req.Msg.DefaultKmsKeyName; rec.DefaultKMSKeyName != "" {
+		if err := s.checkKMSKeyName(rec.DefaultKMSKeyName); err != nil {
+			return nil, err
+		}
+	}
+	if rec.UniformBucketLevelAccess {
+		if req.Msg.PredefinedAcl != "" || req.Msg.PredefinedDefaultObjectAcl != "" {
+			return nil, uniformAccessError(rec.Name)
+		}
+	} else {
+		if rec.Acl, err = predefinedBucketAcl(req.Msg.PredefinedAcl, creator); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		// The default object ACL names no owner.
+		defaultAcl := req.Msg.PredefinedDefaultObjectAcl
+		if defaultAcl == "" {
+			defaultAcl = defaultPredefinedAcl
+		}
+		if rec.DefaultObjectAcl, err = predefinedObjectAcl(defaultAcl, "", rec); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	policy := defaultPolicy(creator, s.admins)
+	if policy.openToAllUsers() {
+		slog.Warn("Bucket created anonymously is open to allUsers", "bucket", rec.Name)
+	}
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte(bucketBuckets)).Get([]byte(rec.Name)) != nil {
+			return connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("bucket already exists: %s", rec.Name))
+		}
+		if err := s.putPolicyRecord(tx, rec.Name, policy); err != nil {
+			return err
+		}
+		return s.putBucketRecord(tx, rec)
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	return connect.NewResponse(&storagev1.CreateBucketResponse{Bucket: rec.toProto()}), nil
+}
+
+func (s *StorageServer) UploadObject(ctx context.Context, req *connect.Request[storagev1.UploadObjectRequest]) (*connect.Response[storagev1.UploadObjectResponse], error) {
+	slog.Info("UploadObject", "bucket", req.Msg.Bucket, "name", req.Msg.Name)
+	if err := validateObjectName(req.Msg.Name); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	member := principalOf(ctx, req.Header())
+	if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permObjectsCreate); err != nil {
+		return nil, err
+	}
+
+	var bucket *bucketRecord
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		var err error
+		bucket, err = s.getBucketRecord(tx, req.Msg.Bucket)
+		return err
+	})
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	class, err := normalizeStorageClass(req.Msg.StorageClass, bucket.StorageClass)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	acl, err := newObjectAcl(bucket, req.Msg.PredefinedAcl, member)
+	if err != nil {
+		return nil, err
+	}
+	key, err := customerKeyFromProto(req.Msg.CommonObjectRequestParams)
+	if err != nil {
+		return nil, err
+	}
+	kmsKey, err := s.objectKMSKey(bucket, req.Msg.KmsKeyName, key)
+	if err != nil {
+		return nil, err
+	}
+	data, env, err := s.sealObjectData(ctx, req.Msg.Data, key, kmsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save metadata in BoltDB
+	now := time.Now().UTC()
+	rec := &objectRecord{
+		Bucket:                  req.Msg.Bucket,
+		Name:                    req.Msg.Name,
+		Size:                    int64(len(req.Msg.Data)),
+		Metadata:                mergeStrings(copyStrings(bucket.DefaultObjectMetadata), req.Msg.Metadata),
+		StorageClass:            class,
+		Generation:              s.nextGeneration(now),
+		Metageneration:          1,
+		TimeCreated:             now,
+		Updated:                 now,
+		TimeStorageClassUpdated: now,
+		Acl:                     acl,
+		CustomerEncryption:      key.encryption(),
+		KMS:                     env,
+	}
+	w := s.newObjectWrite(rec.Bucket, rec.Name)
+	defer w.close()
+	if err := s.precheckQuota(ctx, rec); err != nil {
+		return nil, bucketError(err)
+	}
+	if err := w.stage(ctx, data); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to store object data: %v", err))
+	}
+	var old *objectRecord
+	err = s.update(ctx, func(tx *bbolt.Tx) error {
+		var found bool
+		var err error
+		old, found, err = s.getObjectRecord(tx, rec.Bucket, rec.Name)
+		if err != nil {
+			return err
+		}
+		if found {
+			// Replacing an object also needs permission to delete it.
+			if err := s.authorizeTx(tx, member, rec.Bucket, permObjectsDelete); err != nil {
+				return err
+			}
+			if err := s.recordEarlyDeletion(tx, old, now, "overwrite"); err != nil {
+				return err
+			}
+		}
+		if err := s.checkQuota(tx, old, rec); err != nil {
+			return err
+		}
+		return w.put(ctx, tx, rec)
+	})
+
+	if err != nil {
+		return nil, bucketError(err)
+	}
+	w.finish(ctx)
+	s.emit(overwriteEvents(old, rec)...)
+
+	return connect.NewResponse(&storagev1.UploadObjectResponse{Generation: rec.Generation}), nil
+}
+
+func (s *StorageServer) GetObjectMetadata(ctx context.Context, req *connect.Request[storagev1.GetObjectMetadataRequest]) (*connect.Response[storagev1.GetObjectMetadataResponse], error) {
+	slog.Info("GetObjectMetadata", "bucket", req.Msg.Bucket, "name", req.Msg.Name)
+	if err := s.authorizeObject(ctx, req.Header(), req.Msg.Bucket, req.Msg.Name, permObjectsGet); err != nil {
+		return nil, err
+	}
+
+	obj, err := s.lookupObject(ctx, req.Msg.Bucket, req.Msg.Name)
+	if err != nil {
+		return nil, objectError(err)
+	}
+
+	return connect.NewResponse(obj.toProto()), nil
+}
+
+func (s *StorageServer) ListObjects(ctx context.Context, req *connect.Request[storagev1.ListObjectsRequest]) (*connect.Response[storagev1.ListObjectsResponse], error) {
+	slog.Info("ListObjects", "bucket", req.Msg.Bucket, "prefix", req.Msg.Prefix)
+	if err := s.authorize(ctx, req.Header(), req.Msg.Bucket, permObjectsList); err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.Msg.PageSize)
+	if pageSize < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page size: %d", pageSize))
+	}
+	if pageSize == 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	startAfter, err := decodePageToken(req.Msg.PageToken)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	resp := &storagev1.ListObjectsResponse{}
+	err = s.view(ctx, func(tx *bbolt.Tx) error {
+		return s.scanObjects(tx, req.Msg.Bucket, req.Msg.Prefix, startAfter, func(rec *objectRecord) (bool, error) {
+			if len(resp.ObjectNames) == pageSize {
+				resp.NextPageToken = encodePageToken(resp.ObjectNames[pageSize-1])
+				return false, nil
+			}
+			resp.ObjectNames = append(resp.ObjectNames, rec.Name)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list objects: %v", err))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *StorageServer) GetDownloadURL(ctx context.Context, req *connect.Request[storagev1.GetDownloadURLRequest]) (*connect.Response[storagev1.GetDownloadURLResponse], error) {
+	slog.Info("GetDownloadURL", "bucket", req.Msg.Bucket, "name", req.Msg.Name)
+	if err := s.authorizeObject(ctx, req.Header(), req.Msg.Bucket, req.Msg.Name, permObjectsGet); err != nil {
+		return nil, err
+	}
+	backend, ok := s.backend.(urlBackend)
+	if !ok {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("the storage backend does not serve download URLs; use ReadObject"))
+	}
+	obj, err := s.lookupObject(ctx, req.Msg.Bucket, req.Msg.Name)
+	if err != nil {
+		return nil, objectError(err)
+	}
+	// A direct URL exposes the stored bytes, which are ciphertext for
+	// encrypted objects.
+	if obj.encrypted() {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("object %s/%s is encrypted at rest; use ReadObject", req.Msg.Bucket, req.Msg.Name))
+	}
+	// A content-addressed object is served from its blob, which only
+	// holds the whole object when there is just one.
+	switch len(obj.Blobs) {
+	case 0:
+		return connect.NewResponse(&storagev1.GetDownloadURLResponse{Url: backend.URL(req.Msg.Bucket, req.Msg.Name)}), nil
+	case 1:
+		return connect.NewResponse(&storagev1.GetDownloadURLResponse{Url: backend.URL(blobBucket, blobName(obj.Blobs[0].Hash))}), nil
+	default:
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("object %s/%s is composed of %d blobs; use ReadObject", req.Msg.Bucket, req.Msg.Name, len(obj.Blobs)))
+	}
+}
+
+func (s *StorageServer) Close() error {
+	s.background.Wait()
+	if s.notifier != nil {
+		s.notifier.close()
+	}
+	err := s.db.Close()
+	if s.removeDB != nil {
+		s.removeDB()
+	}
+	return err
+}
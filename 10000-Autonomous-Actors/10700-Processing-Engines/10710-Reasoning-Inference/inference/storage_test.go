@@ -1,60 +1,110 @@
-package inference
-
-import (
-	"context"
-	"testing"
-
-	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
-	"connectrpc.com/connect"
-)
-
-func TestStorageServer_CoverageExpansion(t *testing.T) {
-	tempDir := t.TempDir()
-	server := NewStorageServer(tempDir)
-	defer server.Close()
-	ctx := context.Background()
-
-	// 1. Test CreateBucket
-	_, err := server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{
-		Name: "b1",
-	}))
-	if err != nil {
-		t.Fatalf("CreateBucket failed: %v", err)
-	}
-
-	// 2. Test UploadObject
-	_, err = server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{
-		Bucket: "b1",
-		Name: "o1",
-		Data: []byte("content"),
-	}))
-	if err != nil {
-		t.Fatalf("UploadObject failed: %v", err)
-	}
-
-	// 3. Test GetObjectMetadata
-	res, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{
-		Bucket: "b1",
-		Name: "o1",
-	}))
-	if err != nil || res.Msg.Name != "o1" {
-		t.Error("GetObjectMetadata failed")
-	}
-
-	// 4. Test ListObjects
-	listRes, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{
-		Bucket: "b1",
-	}))
-	if err != nil || len(listRes.Msg.ObjectNames) != 1 {
-		t.Error("ListObjects failed")
-	}
-
-	// 5. Test GetDownloadURL
-	_, err = server.GetDownloadURL(ctx, connect.NewRequest(&storagev1.GetDownloadURLRequest{
-		Bucket: "b1",
-		Name: "o1",
-	}))
-	if err != nil {
-		t.Error("GetDownloadURL failed")
-	}
-}
+package inference
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+)
+
+func TestStorageServer_CoverageExpansion(t *testing.T) {
+	tempDir := t.TempDir()
+	server := NewStorageServer(tempDir)
+	defer server.Close()
+	ctx := context.Background()
+
+	// 1. Test CreateBucket
+	_, err := server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{
+		Name: "b1",
+	}))
+	if err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+
+	// 2. Test UploadObject
+	_, err = server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{
+		Bucket: "b1",
+		Name: "o1",
+		Data: []byte("content"),
+	}))
+	if err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+
+	// 3. Test GetObjectMetadata
+	res, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{
+		Bucket: "b1",
+		Name: "o1",
+	}))
+	if err != nil || res.Msg.Name != "o1" {
+		t.Error("GetObjectMetadata failed")
+	}
+
+	// 4. Test ListObjects
+	listRes, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{
+		Bucket: "b1",
+	}))
+	if err != nil || len(listRes.Msg.ObjectNames) != 1 {
+		t.Error("ListObjects failed")
+	}
+
+	// 5. Test GetDownloadURL
+	_, err = server.GetDownloadURL(ctx, connect.NewRequest(&storagev1.GetDownloadURLRequest{
+		Bucket: "b1",
+		Name: "o1",
+	}))
+	if err != nil {
+		t.Error("GetDownloadURL failed")
+	}
+}
+
+
+// TestUpdateObjectStaleRecord checks that a metadata update applies to the
+// record as committed, not to the one its caller read earlier.
+func TestUpdateObjectStaleRecord(t *testing.T) {
+	t.Parallel()
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	ctx := context.Background()
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "race"}))
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "race", Name: "o", Data: []byte("old")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	stale, err := server.lookupObject(ctx, "race", "o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	updateStale := func(metadata map[string]string) (*objectRecord, error) {
+		var updated *objectRecord
+		err := server.update(ctx, func(tx *bbolt.Tx) error {
+			var err error
+			updated, err = server.updateObjectTx(tx, stale, &storagev1.UpdateObjectRequest{Bucket: "race", Name: "o", Metadata: metadata}, time.Now())
+			return err
+		})
+		return updated, err
+	}
+
+	// A concurrent update of the same generation is kept.
+	if _, err := server.UpdateObject(ctx, connect.NewRequest(&storagev1.UpdateObjectRequest{Bucket: "race", Name: "o", Metadata: map[string]string{"a": "1"}})); err != nil {
+		t.Fatalf("UpdateObject failed: %v", err)
+	}
+	updated, err := updateStale(map[string]string{"b": "2"})
+	if err != nil || updated.Metageneration != 3 || updated.Metadata["a"] != "1" || updated.Metadata["b"] != "2" {
+		t.Errorf("expected both updates applied, got %+v (%v)", updated, err)
+	}
+
+	// A replaced object is not written back.
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "race", Name: "o", Data: []byte("newer")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	if _, err := updateStale(map[string]string{"c": "3"}); connect.CodeOf(objectError(err)) != connect.CodeAborted {
+		t.Errorf("expected Aborted for a replaced object, got %v", err)
+	}
+	meta, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "race", Name: "o"}))
+	if err != nil || meta.Msg.Size != 5 || meta.Msg.Metadata["c"] != "" {
+		t.Errorf("unexpected record after the stale update: %v (%v)", meta, err)
+	}
+}
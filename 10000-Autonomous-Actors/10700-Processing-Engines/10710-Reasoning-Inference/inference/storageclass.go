@@ -0,0 +1,200 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"connectrpc.com/connect"
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	bucketEarlyDeletions = "early_deletions"
+
+	StorageClassStandard = "STANDARD"
+	StorageClassNearline = "NEARLINE"
+	StorageClassColdline = "COLDLINE"
+	StorageClassArchive  = "ARCHIVE"
+)
+
+const day = 24 * time.Hour
+
+// storageClasses lists the supported classes from warmest to coldest along
+// with the minimum storage duration GCS bills for each.
+var storageClasses = []struct {
+	name            string
+	minimumDuration time.Duration
+}{
+	{StorageClassStandard, 0},
+	{StorageClassNearline, 30 * day},
+	{StorageClassColdline, 90 * day},
+	{StorageClassArchive, 365 * day},
+}
+
+// normalizeStorageClass upper-cases class and checks it is supported. An
+// empty class resolves to fallback.
+func normalizeStorageClass(class, fallback string) (string, error) {
+	if class == "" {
+		return fallback, nil
+	}
+	class = strings.ToUpper(class)
+	if storageClassRank(class) < 0 {
+		return "", fmt.Errorf("unsupported storage class: %q", class)
+	}
+	return class, nil
+}
+
+// storageClassRank orders classes from warmest (0) to coldest; -1 marks an
+// unknown class.
+func storageClassRank(class string) int {
+	for i, c := range storageClasses {
+		if c.name == class {
+			return i
+		}
+	}
+	return -1
+}
+
+func minimumStorageDuration(class string) time.Duration {
+	if i := storageClassRank(class); i >= 0 {
+		return storageClasses[i].minimumDuration
+	}
+	return 0
+}
+
+// WithFirstByteLatency delays the first byte of every ReadObject of objects
+// in class, emulating the slower retrieval of colder tiers.
+func WithFirstByteLatency(class string, d time.Duration) Option {
+	return func(s *StorageServer) {
+		if s.firstByteLatency == nil {
+			s.firstByteLatency = make(map[string]time.Duration)
+		}
+		s.firstByteLatency[strings.ToUpper(class)] = d
+	}
+}
+
+// earlyDeletionRecord is stored in the "early_deletions" bucket under a
+// monotonically increasing sequence key.
+type earlyDeletionRecord struct {
+	Bucket       string        `json:"bucket"`
+	Name         string        `json:"name"`
+	Generation   int64         `json:"generation"`
+	StorageClass string        `json:"storageClass"`
+	Stored       time.Duration `json:"stored"`
+	Charged      time.Duration `json:"charged"`
+	Reason       string        `json:"reason"`
+	DeleteTime   time.Time     `json:"deleteTime"`
+}
+
+func (r *earlyDeletionRecord) toProto() *storagev1.EarlyDeletion {
+	return &storagev1.EarlyDeletion{
+		Bucket:         r.Bucket,
+		Name:           r.Name,
+		Generation:     r.Generation,
+		StorageClass:   r.StorageClass,
+		StoredSeconds:  int64(r.Stored / time.Second),
+		ChargedSeconds: int64(r.Charged / time.Second),
+		Reason:         r.Reason,
+		DeleteTime:     timestamppb.New(r.DeleteTime),
+	}
+}
+
+// recordEarlyDeletion notes a charge when obj leaves storage before the
+// minimum duration of its class. The duration is measured from the object's
+// creation, so lifecycle class transitions do not restart it.
+func (s *StorageServer) recordEarlyDeletion(tx *bbolt.Tx, obj *objectRecord, now time.Time, reason string) error {
+	minimum := minimumStorageDuration(obj.StorageClass)
+	stored := now.Sub(obj.TimeCreated)
+	if minimum == 0 || stored >= minimum {
+		return nil
+	}
+	slog.Info("Early deletion", "bucket", obj.Bucket, "name", obj.Name, "class", obj.StorageClass, "stored", stored)
+	return s.putEarlyDeletion(tx, &earlyDeletionRecord{
+		Bucket:       obj.Bucket,
+		Name:         obj.Name,
+		Generation:   obj.Generation,
+		StorageClass: obj.StorageClass,
+		Stored:       stored,
+		Charged:      minimum - stored,
+		Reason:       reason,
+		DeleteTime:   now,
+	})
+}
+
+// putEarlyDeletion appends rec to the charges, which are kept in the order
+// they accrued.
+func (s *StorageServer) putEarlyDeletion(tx *bbolt.Tx, rec *earlyDeletionRecord) error {
+	b := tx.Bucket([]byte(bucketEarlyDeletions))
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return s.putRecord(b, bucketEarlyDeletions, string(key), rec)
+}
+
+// deleteEarlyDeletions removes the charges accrued in buckets.
+func (s *StorageServer) deleteEarlyDeletions(tx *bbolt.Tx, buckets map[string]bool) error {
+	b := tx.Bucket([]byte(bucketEarlyDeletions))
+	var keys [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		var rec earlyDeletionRecord
+		if err := s.decodeRecord(bucketEarlyDeletions, string(k), v, &rec); err != nil {
+			return err
+		}
+		if buckets[rec.Bucket] {
+			keys = append(keys, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StorageServer) ListEarlyDeletions(ctx context.Context, req *connect.Request[storagev1.ListEarlyDeletionsRequest]) (*connect.Response[storagev1.ListEarlyDeletionsResponse], error) {
+	slog.Info("ListEarlyDeletions", "bucket", req.Msg.Bucket)
+
+	// Charges are visible to callers who can read the bucket they accrued in.
+	member := principalOf(ctx, req.Header())
+	var out []*storagev1.EarlyDeletion
+	err := s.view(ctx, func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucketEarlyDeletions))
+		return b.ForEach(func(k, v []byte) error {
+			var rec earlyDeletionRecord
+			if err := s.decodeRecord(bucketEarlyDeletions, string(k), v, &rec); err != nil {
+				return err
+			}
+			if req.Msg.Bucket != "" && rec.Bucket != req.Msg.Bucket {
+				return nil
+			}
+			ok, err := s.canAccessTx(tx, member, rec.Bucket, "", permBucketsGet)
+			if err != nil {
+				return err
+			}
+			if ok {
+				out = append(out, rec.toProto())
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&storagev1.ListEarlyDeletionsResponse{EarlyDeletions: out}), nil
+}
@@ -0,0 +1,138 @@
+package inference
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+func TestStorageClassTiers(t *testing.T) {
+	server := NewStorageServer("", WithInMemory(), WithFirstByteLatency(StorageClassArchive, 50*time.Millisecond))
+	defer server.Close()
+	ctx := context.Background()
+
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "tiers", StorageClass: "nearline"}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "tiers", Name: "default", Data: []byte("d")}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "tiers", Name: "cold", Data: []byte("c"), StorageClass: StorageClassColdline}))
+
+	meta, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "tiers", Name: "default"}))
+	if err != nil || meta.Msg.StorageClass != StorageClassNearline {
+		t.Fatalf("expected bucket default class NEARLINE, got %v (%v)", meta, err)
+	}
+
+	_, err = server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "tiers", Name: "bad", StorageClass: "GLACIER"}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("expected InvalidArgument for unknown class, got %v", err)
+	}
+
+	// Rewriting in place changes the class and bills the replaced generation.
+	rw, err := server.RewriteObject(ctx, connect.NewRequest(&storagev1.RewriteObjectRequest{
+		SourceBucket: "tiers", SourceObject: "cold",
+		DestinationBucket: "tiers", DestinationObject: "cold",
+		DestinationStorageClass: StorageClassArchive,
+	}))
+	if err != nil || rw.Msg.Resource.StorageClass != StorageClassArchive {
+		t.Fatalf("RewriteObject failed: %v (%v)", rw, err)
+	}
+	if _, err := server.DeleteObject(ctx, connect.NewRequest(&storagev1.DeleteObjectRequest{Bucket: "tiers", Name: "default"})); err != nil {
+		t.Fatalf("DeleteObject failed: %v", err)
+	}
+	charges, err := server.ListEarlyDeletions(ctx, connect.NewRequest(&storagev1.ListEarlyDeletionsRequest{Bucket: "tiers"}))
+	if err != nil || len(charges.Msg.EarlyDeletions) != 2 {
+		t.Fatalf("expected 2 early deletions, got %v (%v)", charges, err)
+	}
+	if c := charges.Msg.EarlyDeletions[0]; c.StorageClass != StorageClassColdline || c.Reason != "rewrite" || c.ChargedSeconds < int64(89*day/time.Second) {
+		t.Errorf("unexpected rewrite charge: %v", c)
+	}
+
+	// Colder tiers pay a first-byte latency on reads.
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+
+	start := time.Now()
+	if data := readAll(t, client, "tiers", "cold"); data != "c" {
+		t.Fatalf("unexpected read %q", data)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected archive first-byte latency, read took %v", elapsed)
+	}
+}
+
+func TestApplyLifecycle(t *testing.T) {
+	server := NewStorageServer("", WithInMemory())
+	defer server.Close()
+	ctx := context.Background()
+
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{
+		Name: "aging",
+		LifecycleRules: []*storagev1.LifecycleRule{
+			{
+				Action:    &storagev1.LifecycleRule_Action{Type: "SetStorageClass", StorageClass: StorageClassNearline},
+				Condition: &storagev1.LifecycleRule_Condition{AgeDays: 30, MatchesStorageClass: []string{StorageClassStandard}},
+			},
+			{
+				Action:    &storagev1.LifecycleRule_Action{Type: "Delete"},
+				Condition: &storagev1.LifecycleRule_Condition{AgeDays: 60, MatchesPrefix: []string{"tmp/"}},
+			},
+		},
+	}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "aging", Name: "keep/a", Data: []byte("a")}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "aging", Name: "tmp/b", Data: []byte("b")}))
+
+	now := time.Now()
+	if err := server.ApplyLifecycle(ctx, now.Add(31*day)); err != nil {
+		t.Fatalf("ApplyLifecycle failed: %v", err)
+	}
+	meta, _ := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "aging", Name: "keep/a"}))
+	if meta.Msg.StorageClass != StorageClassNearline {
+		t.Errorf("expected lifecycle transition to NEARLINE, got %s", meta.Msg.StorageClass)
+	}
+
+	if err := server.ApplyLifecycle(ctx, now.Add(61*day)); err != nil {
+		t.Fatalf("ApplyLifecycle failed: %v", err)
+	}
+	if _, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "aging", Name: "tmp/b"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("expected tmp/b to be deleted, got %v", err)
+	}
+	charges, _ := server.ListEarlyDeletions(ctx, connect.NewRequest(&storagev1.ListEarlyDeletionsRequest{}))
+	if len(charges.Msg.EarlyDeletions) != 0 {
+		t.Errorf("lifecycle deletion after the minimum duration should not be charged: %v", charges.Msg.EarlyDeletions)
+	}
+}
+
+// receiveAll drains a stream of data chunks, such as ReadObject or
+// ExportState responses, returning the data received before any error.
+func receiveAll[T any, PT interface {
+	*T
+	GetData() []byte
+}](stream *connect.ServerStreamForClient[T]) ([]byte, error) {
+	defer stream.Close()
+	var data []byte
+	for stream.Receive() {
+		data = append(data, PT(stream.Msg()).GetData()...)
+	}
+	return data, stream.Err()
+}
+
+// readAll reads bucket/name through client, failing the test on error.
+func readAll(t *testing.T, client storagev1connect.StorageServiceClient, bucket, name string) string {
+	t.Helper()
+	stream, err := client.ReadObject(context.Background(), connect.NewRequest(&storagev1.ReadObjectRequest{Bucket: bucket, Name: name}))
+	if err != nil {
+		t.Fatalf("ReadObject %s failed: %v", objectKey(bucket, name), err)
+	}
+	data, err := receiveAll(stream)
+	if err != nil {
+		t.Fatalf("ReadObject %s failed: %v", objectKey(bucket, name), err)
+	}
+	return string(data)
+}
@@ -0,0 +1,210 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DevClientEmail identifies the client certificate GenerateDevCertificates
+// issues; it authenticates as user:dev@localhost.
+const DevClientEmail = "dev@localhost"
+
+// devCertLifetime bounds the throwaway certificates, which are issued anew
+// on every start.
+const devCertLifetime = 7 * 24 * time.Hour
+
+// TLSConfig selects how StorageManager serves TLS.
+type TLSConfig struct {
+	// CertFile and KeyFile hold the PEM server certificate chain and key.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile, when set, is a PEM bundle of CAs whose client
+	// certificates are verified and mapped to principals by
+	// CertificatePrincipal.
+	ClientCAFile string
+	// RequireClientCert refuses connections without a verified client
+	// certificate instead of leaving the caller to other credentials.
+	RequireClientCert bool
+}
+
+// certPrincipalKey carries the principal of a verified client certificate
+// from ClientCertAuth to the AuthInterceptor.
+type certPrincipalKey struct{}
+
+// NewServerTLSConfig loads the certificates named by cfg.
+func NewServerTLSConfig(cfg TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %v", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if cfg.ClientCAFile == "" {
+		if cfg.RequireClientCert {
+			return nil, errors.New("requiring client certificates needs a client CA bundle")
+		}
+		return tlsConfig, nil
+	}
+	data, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA bundle: %v", err)
+	}
+	tlsConfig.ClientCAs = x509.NewCertPool()
+	if !tlsConfig.ClientCAs.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates in client CA bundle %s", cfg.ClientCAFile)
+	}
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if cfg.RequireClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// CertificatePrincipal maps a client certificate to an IAM member: the
+// first email SAN, else a Subject CN holding an IAM member or an email.
+// Emails in an .iam domain, such as ci@olympus.iam or
+// x@demo.iam.gserviceaccount.com, name service accounts; others name users.
+func CertificatePrincipal(cert *x509.Certificate) (string, bool) {
+	if len(cert.EmailAddresses) > 0 {
+		return emailMember(cert.EmailAddresses[0]), true
+	}
+	cn := cert.Subject.CommonName
+	switch {
+	case strings.Contains(cn, ":") && validMember(cn):
+		return cn, true
+	case strings.Contains(cn, "@"):
+		return emailMember(cn), true
+	}
+	return "", false
+}
+
+func emailMember(email string) string {
+	_, domain, _ := strings.Cut(email, "@")
+	if strings.HasSuffix(domain, ".iam") || strings.HasSuffix(domain, ".iam.gserviceaccount.com") {
+		return "serviceAccount:" + email
+	}
+	return "user:" + email
+}
+
+// ClientCertAuth passes the principal of a verified client certificate to
+// the AuthInterceptor further down. Certificates that name no principal are
+// ignored, leaving the caller to other credentials.
+func ClientCertAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			if member, ok := CertificatePrincipal(r.TLS.VerifiedChains[0][0]); ok {
+				r = r.WithContext(context.WithValue(r.Context(), certPrincipalKey{}, member))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GenerateDevCertificates writes a throwaway CA to dir along with a server
+// certificate for hosts and localhost, and a client certificate for
+// DevClientEmail, each as <name>.pem and <name>-key.pem. It returns the
+// config serving them with client certificates verified against the CA.
+func GenerateDevCertificates(dir string, hosts ...string) (TLSConfig, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return TLSConfig{}, err
+	}
+	now := time.Now()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return TLSConfig{}, err
+	}
+	ca := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "OlympusGCP-Storage dev CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(devCertLifetime),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if err := writeDevCert(dir, "ca", ca, ca, caKey, caKey); err != nil {
+		return TLSConfig{}, err
+	}
+
+	server := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else if h != "" && h != "localhost" {
+			server.DNSNames = append(server.DNSNames, h)
+		}
+	}
+	client := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: DevClientEmail},
+		EmailAddresses: []string{DevClientEmail},
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for name, tmpl := range map[string]*x509.Certificate{"server": server, "client": client} {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return TLSConfig{}, err
+		}
+		tmpl.NotBefore, tmpl.NotAfter = ca.NotBefore, ca.NotAfter
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+		if err := writeDevCert(dir, name, tmpl, ca, key, caKey); err != nil {
+			return TLSConfig{}, err
+		}
+	}
+	slog.Info("Generated throwaway TLS certificates", "dir", dir, "client", DevClientEmail)
+	return TLSConfig{
+		CertFile:     filepath.Join(dir, "server.pem"),
+		KeyFile:      filepath.Join(dir, "server-key.pem"),
+		ClientCAFile: filepath.Join(dir, "ca.pem"),
+	}, nil
+}
+
+// writeDevCert signs tmpl with parent's key and writes the certificate and
+// the key of the new certificate.
+func writeDevCert(dir, name string, tmpl, parent *x509.Certificate, key, parentKey *ecdsa.PrivateKey) error {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+	tmpl.SerialNumber = serial
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return fmt.Errorf("failed to issue %s certificate: %v", name, err)
+	}
+	// The CA template signs the others, so it must carry what was issued.
+	if tmpl == parent {
+		issued, err := x509.ParseCertificate(der)
+		if err != nil {
+			return err
+		}
+		*tmpl = *issued
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name+"-key.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600)
+}
@@ -0,0 +1,101 @@
+package inference
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+func TestCertificatePrincipal(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		cert *x509.Certificate
+		want string
+	}{
+		{&x509.Certificate{EmailAddresses: []string{"alice@example.com"}, Subject: pkix.Name{CommonName: "bob@example.com"}}, "user:alice@example.com"},
+		{&x509.Certificate{EmailAddresses: []string{"ci@olympus.iam"}}, "serviceAccount:ci@olympus.iam"},
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "up@demo.iam.gserviceaccount.com"}}, "serviceAccount:up@demo.iam.gserviceaccount.com"},
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "group:qa@example.com"}}, "group:qa@example.com"},
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "storage.internal"}}, ""},
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "robot:x"}}, ""},
+	} {
+		if got, _ := CertificatePrincipal(tc.cert); got != tc.want {
+			t.Errorf("CertificatePrincipal(%v %q) = %q, want %q", tc.cert.EmailAddresses, tc.cert.Subject.CommonName, got, tc.want)
+		}
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfg, err := GenerateDevCertificates(dir, "storage.test")
+	if err != nil {
+		t.Fatalf("GenerateDevCertificates failed: %v", err)
+	}
+	caPEM, _ := os.ReadFile(filepath.Join(dir, "ca.pem"))
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(caPEM)
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem"))
+	if err != nil {
+		t.Fatalf("failed to load client certificate: %v", err)
+	}
+
+	serve := func(cfg TLSConfig) string {
+		tlsConfig, err := NewServerTLSConfig(cfg)
+		if err != nil {
+			t.Fatalf("NewServerTLSConfig failed: %v", err)
+		}
+		server := NewStorageServer("", WithInMemory())
+		t.Cleanup(func() { server.Close() })
+		auth, _ := NewAuthInterceptor(AuthConfig{})
+		mux := http.NewServeMux()
+		mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(auth)))
+		httpServer := httptest.NewUnstartedServer(ClientCertAuth(mux))
+		httpServer.TLS = tlsConfig
+		httpServer.EnableHTTP2 = true
+		httpServer.StartTLS()
+		t.Cleanup(httpServer.Close)
+		return httpServer.URL
+	}
+	client := func(url string, certs ...tls.Certificate) storagev1connect.StorageServiceClient {
+		transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs}, ForceAttemptHTTP2: true}
+		return storagev1connect.NewStorageServiceClient(&http.Client{Transport: transport}, url)
+	}
+	ctx := context.Background()
+
+	url := serve(cfg)
+	withCert := client(url, clientCert)
+	if _, err := withCert.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "mtls"})); err != nil {
+		t.Fatalf("CreateBucket with a client certificate failed: %v", err)
+	}
+	policy, err := withCert.GetIamPolicy(ctx, connect.NewRequest(&storagev1.GetIamPolicyRequest{Bucket: "mtls"}))
+	if err != nil || len(policy.Msg.Policy.Bindings) == 0 || policy.Msg.Policy.Bindings[0].Members[0] != "user:"+DevClientEmail {
+		t.Errorf("bucket not owned by the certificate principal: %v (%v)", policy, err)
+	}
+	if _, err := client(url).ListBuckets(ctx, connect.NewRequest(&storagev1.ListBucketsRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected Unauthenticated without a certificate, got %v", err)
+	}
+
+	cfg.RequireClientCert = true
+	url = serve(cfg)
+	if _, err := client(url).ListBuckets(ctx, connect.NewRequest(&storagev1.ListBucketsRequest{})); err == nil {
+		t.Error("expected the handshake to fail without a certificate")
+	}
+	if _, err := client(url, clientCert).ListBuckets(ctx, connect.NewRequest(&storagev1.ListBucketsRequest{})); err != nil {
+		t.Errorf("ListBuckets with a client certificate failed: %v", err)
+	}
+
+	if _, err := NewServerTLSConfig(TLSConfig{CertFile: cfg.CertFile, KeyFile: cfg.KeyFile, RequireClientCert: true}); err == nil {
+		t.Error("expected requiring client certificates without a CA to fail")
+	}
+}
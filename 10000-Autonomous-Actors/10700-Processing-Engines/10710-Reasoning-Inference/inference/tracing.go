@@ -0,0 +1,48 @@
+//go:build !wasm
+
+package inference
+
+import (
+	"context"
+
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer starts the spans around BoltDB transactions and filesystem
+// operations. It follows whatever provider telemetry.SetupTracing installs.
+var tracer = otel.Tracer("olympus.fleet/00SDLC/OlympusGCP-Storage/inference")
+
+// startSpan starts a child span of the operation in ctx.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err, if any, on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// view runs fn in a read transaction traced under ctx.
+func (s *StorageServer) view(ctx context.Context, fn func(*bbolt.Tx) error) error {
+	_, span := startSpan(ctx, "boltdb.View")
+	err := s.db.View(fn)
+	endSpan(span, err)
+	return err
+}
+
+// update runs fn in a write transaction traced under ctx. The span covers
+// waiting for the writer lock and the commit's disk sync.
+func (s *StorageServer) update(ctx context.Context, fn func(*bbolt.Tx) error) error {
+	_, span := startSpan(ctx, "boltdb.Update")
+	err := s.db.Update(fn)
+	endSpan(span, err)
+	return err
+}
@@ -0,0 +1,99 @@
+package inference
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/telemetry"
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+// TestTracing installs the global tracer provider, so it must not run in
+// parallel with other tests that set it.
+func TestTracing(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := telemetry.SetupTracing(context.Background(), telemetry.TracingConfig{Exporter: telemetry.TraceExporterFile, File: file, ServiceName: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewStorageServer(t.TempDir())
+	defer server.Close()
+	tracing, err := telemetry.NewTracingInterceptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(tracing)))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL, connect.WithInterceptors(tracing))
+	ctx := context.Background()
+	if _, err := client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "traced"})); err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	if _, err := client.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "traced", Name: "a", Data: []byte("hello")})); err != nil {
+		t.Fatalf("UploadObject failed: %v", err)
+	}
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("flushing traces failed: %v", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type span struct {
+		Name        string
+		SpanContext struct{ TraceID string }
+		Parent      struct{ SpanID string }
+	}
+	// The upload's trace is the one of its client span; every span of the
+	// server handling it must belong to that trace.
+	var spans []span
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var s span
+		if err := dec.Decode(&s); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid trace file: %v", err)
+		}
+		spans = append(spans, s)
+	}
+	var trace string
+	for _, s := range spans {
+		if s.Name == "storage.v1.StorageService/UploadObject" && s.Parent.SpanID == "0000000000000000" {
+			trace = s.SpanContext.TraceID
+		}
+	}
+	if trace == "" {
+		t.Fatalf("no client span for UploadObject in %s", data)
+	}
+	found := map[string]int{}
+	for _, s := range spans {
+		if s.SpanContext.TraceID == trace {
+			found[s.Name]++
+		}
+	}
+	for _, want := range []string{"storage.v1.StorageService/UploadObject", "boltdb.Update", "fs.Stage"} {
+		if found[want] == 0 {
+			t.Errorf("upload trace lacks a %s span: %v", want, found)
+		}
+	}
+	if found["storage.v1.StorageService/UploadObject"] != 2 {
+		t.Errorf("expected a client and a server span for the upload: %v", found)
+	}
+	if found["storage.v1.StorageService/CreateBucket"] != 0 {
+		t.Errorf("CreateBucket must have a trace of its own: %v", found)
+	}
+}
@@ -0,0 +1,21 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSetupTracingErrors(t *testing.T) {
+	t.Parallel()
+	if _, err := SetupTracing(context.Background(), TracingConfig{Exporter: "jaeger"}); err == nil || !strings.Contains(err.Error(), "unknown trace exporter") {
+		t.Errorf("expected an unknown exporter error, got %v", err)
+	}
+	if _, err := SetupTracing(context.Background(), TracingConfig{Exporter: TraceExporterFile}); err == nil {
+		t.Error("expected the file exporter to need a file")
+	}
+	shutdown, err := SetupTracing(context.Background(), TracingConfig{})
+	if err != nil || shutdown(context.Background()) != nil {
+		t.Errorf("expected tracing off without an exporter, got %v", err)
+	}
+}
@@ -0,0 +1,423 @@
+// Command storage-admin runs maintenance on StorageManager's data directory
+// while StorageManager is stopped; export and import can also reach a
+// running StorageManager with -server. Build it with
+// `go build -o storage-admin`.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/inference"
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const usage = `usage: storage-admin <command> [flags]
+
+commands:
+  fsck    check that metadata and stored data agree, and optionally repair
+  export  write all buckets, objects and their configuration to a tar archive
+  import  restore an archive written by export, merging or replacing
+  audit   write audit log entries as JSON lines
+
+Run "storage-admin <command> -h" for the flags of a command.`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes a command and returns the exit status: 0 on success, 1 when
+// the command found problems it left in place, 2 on errors.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "fsck":
+		return runFsck(args[1:], stdout, stderr)
+	case "export":
+		return runExport(args[1:], stdout, stderr)
+	case "import":
+		return runImport(args[1:], os.Stdin, stdout, stderr)
+	case "audit":
+		return runAudit(args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s\n", args[0], usage)
+		return 2
+	}
+}
+
+// storageFlags locate and unlock the storage a command works on, matching
+// the StorageManager flags of the same names.
+type storageFlags struct {
+	dir         string
+	kmsKeyring  string
+	kmsEndpoint string
+	metadataKey string
+}
+
+func (f *storageFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.dir, "storage-dir", "../../60000-Information-Storage/StorageData", "StorageManager data directory")
+	fs.StringVar(&f.kmsKeyring, "kms-keyring", "", "local keyring file for encryption at rest")
+	fs.StringVar(&f.kmsEndpoint, "kms-endpoint", "", "Cloud KMS REST endpoint of the Vault/KMS emulator")
+	fs.StringVar(&f.metadataKey, "kms-metadata-key", "projects/olympus/locations/global/keyRings/storage/cryptoKeys/metadata", "KMS key that encrypts BoltDB metadata")
+}
+
+// open opens the storage, reporting the failures NewStorageServer panics on
+// as errors.
+func (f *storageFlags) open() (server *inference.StorageServer, err error) {
+	var opts []inference.Option
+	switch {
+	case f.kmsEndpoint != "":
+		opts = append(opts, inference.WithKMS(inference.NewKMSClient(f.kmsEndpoint), f.metadataKey))
+	case f.kmsKeyring != "":
+		keyring, err := inference.NewLocalKeyring(f.kmsKeyring)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open keyring %s: %v", f.kmsKeyring, err)
+		}
+		opts = append(opts, inference.WithKMS(keyring, f.metadataKey))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to open storage in %s: %v", f.dir, r)
+		}
+	}()
+	return inference.NewStorageServer(f.dir, opts...), nil
+}
+
+func runFsck(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("fsck", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var storage storageFlags
+	storage.register(fs)
+	repair := fs.Bool("repair", false, "drop dangling metadata, remove temporary files and repair orphaned files")
+	orphans := fs.String("orphans", inference.OrphansQuarantine, "repair for files with no metadata: adopt or quarantine")
+	checksums := fs.Bool("checksums", true, "read all stored data to verify checksums")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	opts := inference.FsckOptions{VerifyChecksums: *checksums}
+	if *repair {
+		opts.Orphans = *orphans
+		opts.DropDangling = true
+		opts.RemoveTempFiles = true
+	}
+	server, err := storage.open()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	defer server.Close()
+	res, err := server.Fsck(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "fsck failed: %v\n", err)
+		return 2
+	}
+	if err := printJSON(stdout, res); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	if res.Unfixed() > 0 {
+		return 1
+	}
+	return 0
+}
+
+// remoteFlags select a running StorageManager to use in place of the data
+// directory.
+type remoteFlags struct {
+	server string
+	token  string
+}
+
+func (f *remoteFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.server, "server", "", "URL of a running StorageManager to use instead of -storage-dir, e.g. http://localhost:8091")
+	fs.StringVar(&f.token, "token", "", "bearer token to present to -server")
+}
+
+func (f *remoteFlags) client() storagev1connect.StorageServiceClient {
+	return storagev1connect.NewStorageServiceClient(http.DefaultClient, f.server)
+}
+
+func (f *remoteFlags) authorize(header http.Header) {
+	if f.token != "" {
+		header.Set("Authorization", "Bearer "+f.token)
+	}
+}
+
+// printJSON writes v to w as indented JSON.
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func runExport(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var storage storageFlags
+	var remote remoteFlags
+	storage.register(fs)
+	remote.register(fs)
+	out := fs.String("o", "-", "archive file to write, or - for standard output")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	// The summary goes to standard error when the archive takes the output.
+	w, report := stdout, stdout
+	var file *os.File
+	if *out == "-" {
+		report = stderr
+	} else {
+		var err error
+		if file, err = os.Create(*out); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		defer file.Close()
+		w = file
+	}
+	ctx := context.Background()
+	var sum *inference.ArchiveSummary
+	var err error
+	if remote.server != "" {
+		sum, err = exportRemote(ctx, &remote, w)
+	} else {
+		var server *inference.StorageServer
+		if server, err = storage.open(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		defer server.Close()
+		sum, err = server.ExportArchive(ctx, w)
+	}
+	if file != nil && err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "export failed: %v\n", err)
+		return 2
+	}
+	if sum != nil {
+		printJSON(report, sum)
+	}
+	return 0
+}
+
+// exportRemote streams ExportState into w. The server reports no summary.
+func exportRemote(ctx context.Context, remote *remoteFlags, w io.Writer) (*inference.ArchiveSummary, error) {
+	req := connect.NewRequest(&storagev1.ExportStateRequest{})
+	remote.authorize(req.Header())
+	stream, err := remote.client().ExportState(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	for stream.Receive() {
+		if _, err := w.Write(stream.Msg().Data); err != nil {
+			return nil, err
+		}
+	}
+	return nil, stream.Err()
+}
+
+func runImport(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: storage-admin import [flags] <archive | ->")
+		fs.PrintDefaults()
+	}
+	var storage storageFlags
+	var remote remoteFlags
+	storage.register(fs)
+	remote.register(fs)
+	mode := fs.String("mode", inference.ImportMerge, "merge to keep state the archive does not hold, replace to discard it")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	r := stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		defer f.Close()
+		r = f
+	}
+	ctx := context.Background()
+	var sum *inference.ArchiveSummary
+	var err error
+	if remote.server != "" {
+		sum, err = importRemote(ctx, &remote, r, *mode)
+	} else {
+		var server *inference.StorageServer
+		if server, err = storage.open(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		defer server.Close()
+		sum, err = server.ImportArchive(ctx, r, *mode)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "import failed: %v\n", err)
+		return 2
+	}
+	printJSON(stdout, sum)
+	return 0
+}
+
+// importRemote streams r to ImportState.
+func importRemote(ctx context.Context, remote *remoteFlags, r io.Reader, mode string) (*inference.ArchiveSummary, error) {
+	stream := remote.client().ImportState(ctx)
+	remote.authorize(stream.RequestHeader())
+	msg := &storagev1.ImportStateRequest{Mode: mode}
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			msg.Data = buf[:n]
+			if err := stream.Send(msg); err != nil {
+				break // CloseAndReceive reports the cause.
+			}
+			msg = &storagev1.ImportStateRequest{}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			stream.CloseAndReceive()
+			return nil, err
+		}
+	}
+	if msg.Mode != "" {
+		// An empty archive still sends the mode.
+		stream.Send(msg)
+	}
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		return nil, err
+	}
+	return &inference.ArchiveSummary{Buckets: int(resp.Msg.Buckets), Objects: int(resp.Msg.Objects)}, nil
+}
+
+// timeFlag is an optional RFC 3339 time flag.
+type timeFlag struct{ time.Time }
+
+func (f *timeFlag) String() string {
+	if f.IsZero() {
+		return ""
+	}
+	return f.Format(time.RFC3339)
+}
+
+func (f *timeFlag) Set(s string) (err error) {
+	f.Time, err = time.Parse(time.RFC3339, s)
+	return err
+}
+
+func runAudit(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var storage storageFlags
+	var remote remoteFlags
+	storage.register(fs)
+	remote.register(fs)
+	var since, until timeFlag
+	fs.Var(&since, "since", "only entries at or after this RFC 3339 time")
+	fs.Var(&until, "until", "only entries before this RFC 3339 time")
+	principal := fs.String("principal", "", "only entries of this IAM member, e.g. user:alice@example.com")
+	bucket := fs.String("bucket", "", "only entries for this bucket")
+	object := fs.String("object", "", "only entries for this object of -bucket")
+	out := fs.String("o", "-", "file to write, or - for standard output")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *object != "" && *bucket == "" {
+		fmt.Fprintln(stderr, "-object needs -bucket")
+		return 2
+	}
+
+	w := stdout
+	var file *os.File
+	if *out != "-" {
+		var err error
+		if file, err = os.Create(*out); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		defer file.Close()
+		w = file
+	}
+	filter := inference.AuditFilter{Start: since.Time, End: until.Time, Principal: *principal, Bucket: *bucket, Object: *object}
+	ctx := context.Background()
+	var err error
+	if remote.server != "" {
+		err = auditRemote(ctx, &remote, w, filter)
+	} else {
+		var server *inference.StorageServer
+		if server, err = storage.open(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		defer server.Close()
+		_, err = server.ExportAuditLog(ctx, w, filter)
+	}
+	if file != nil && err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "audit failed: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// auditRemote pages through QueryAuditLog, writing the entries the caller
+// may see to w in the form ExportAuditLog uses.
+func auditRemote(ctx context.Context, remote *remoteFlags, w io.Writer, filter inference.AuditFilter) error {
+	msg := &storagev1.QueryAuditLogRequest{Principal: filter.Principal, Bucket: filter.Bucket, Object: filter.Object}
+	if !filter.Start.IsZero() {
+		msg.StartTime = timestamppb.New(filter.Start)
+	}
+	if !filter.End.IsZero() {
+		msg.EndTime = timestamppb.New(filter.End)
+	}
+	client := remote.client()
+	enc := json.NewEncoder(w)
+	for {
+		req := connect.NewRequest(msg)
+		remote.authorize(req.Header())
+		resp, err := client.QueryAuditLog(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, e := range resp.Msg.Entries {
+			if err := enc.Encode(inference.AuditEntryFromProto(e)); err != nil {
+				return err
+			}
+		}
+		if resp.Msg.NextPageToken == "" {
+			return nil
+		}
+		msg.PageToken = resp.Msg.NextPageToken
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/inference"
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+)
+
+func TestFsckCommand(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	server := inference.NewStorageServer(dir)
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "gone", Data: []byte("data")}))
+	server.Close()
+	os.Remove(filepath.Join(dir, "b", "gone"))
+	os.WriteFile(filepath.Join(dir, "b", "stray"), []byte("stray"), 0644)
+
+	fsck := func(args ...string) (int, *inference.FsckResult) {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		code := run(append([]string{"fsck", "-storage-dir", dir}, args...), &stdout, &stderr)
+		var res inference.FsckResult
+		if code != 2 {
+			if err := json.Unmarshal(stdout.Bytes(), &res); err != nil {
+				t.Fatalf("bad fsck output %q: %v", stdout.String(), err)
+			}
+		}
+		return code, &res
+	}
+
+	if code, res := fsck(); code != 1 || len(res.Issues) != 2 {
+		t.Errorf("expected exit 1 with 2 issues, got %d: %+v", code, res)
+	}
+	if code, res := fsck("-repair", "-orphans", inference.OrphansAdopt); code != 0 || res.Unfixed() != 0 {
+		t.Errorf("expected a full repair, got %d: %+v", code, res)
+	}
+	if code, res := fsck(); code != 0 || len(res.Issues) != 0 || res.Objects != 1 {
+		t.Errorf("expected a clean check, got %d: %+v", code, res)
+	}
+	if code, _ := fsck("-orphans", "delete", "-repair"); code != 2 {
+		t.Errorf("expected exit 2 for an unknown repair, got %d", code)
+	}
+	if code := run([]string{"defrag"}, &bytes.Buffer{}, &bytes.Buffer{}); code != 2 {
+		t.Errorf("expected exit 2 for an unknown command, got %d", code)
+	}
+}
+
+func TestExportImportCommands(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	ctx := context.Background()
+	server := inference.NewStorageServer(src)
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("data")}))
+	server.Close()
+	server = inference.NewStorageServer(dst)
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "stale"}))
+	server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "stale", Name: "o", Data: []byte("old")}))
+	server.Close()
+
+	archive := filepath.Join(t.TempDir(), "state.tar")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"export", "-storage-dir", src, "-o", archive}, &stdout, &stderr); code != 0 {
+		t.Fatalf("export exited %d: %s", code, stderr.String())
+	}
+	var sum inference.ArchiveSummary
+	if err := json.Unmarshal(stdout.Bytes(), &sum); err != nil || sum.Buckets != 1 || sum.Objects != 1 {
+		t.Errorf("unexpected export summary %q (%v)", stdout.String(), err)
+	}
+	stdout.Reset()
+	if code := run([]string{"import", "-storage-dir", dst, "-mode", inference.ImportReplace, archive}, &stdout, &stderr); code != 0 {
+		t.Fatalf("import exited %d: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(dst, "stale")); !os.IsNotExist(err) {
+		t.Errorf("replaced bucket left behind: %v", err)
+	}
+
+	// The imported state serves, and exports the same over the API.
+	// Remote exports and imports are for authenticated administrators.
+	tokens := filepath.Join(t.TempDir(), "tokens.json")
+	os.WriteFile(tokens, []byte(`{"ops-secret":"user:ops@example.com"}`), 0600)
+	auth, err := inference.NewAuthInterceptor(inference.AuthConfig{TokensFile: tokens})
+	if err != nil {
+		t.Fatalf("NewAuthInterceptor failed: %v", err)
+	}
+	server = inference.NewStorageServer(dst, inference.WithAdmins("user:ops@example.com"))
+	defer server.Close()
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(auth)))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	stdout.Reset()
+	if code := run([]string{"export", "-server", httpServer.URL}, &stdout, &stderr); code == 0 {
+		t.Errorf("expected an anonymous remote export to fail")
+	}
+	stdout.Reset()
+	if code := run([]string{"export", "-server", httpServer.URL, "-token", "ops-secret"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("remote export exited %d: %s", code, stderr.String())
+	}
+	sum2, err := server.ImportArchive(ctx, &stdout, inference.ImportMerge)
+	if err != nil || *sum2 != sum {
+		t.Errorf("remote export does not round-trip: %+v (%v)", sum2, err)
+	}
+	meta, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{Bucket: "b", Name: "o"}))
+	if err != nil || meta.Msg.Size != 4 {
+		t.Errorf("imported object not served: %v (%v)", meta, err)
+	}
+	if code := run([]string{"import", "-server", httpServer.URL, "-token", "ops-secret", "-mode", "bogus", archive}, &stdout, &stderr); code != 2 {
+		t.Errorf("expected exit 2 for an unknown mode, got %d", code)
+	}
+}
+
+func TestAuditCommand(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	server := inference.NewStorageServer(dir)
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, connect.WithInterceptors(inference.NewAuditInterceptor(server))))
+	httpServer := httptest.NewServer(mux)
+	client := storagev1connect.NewStorageServiceClient(httpServer.Client(), httpServer.URL)
+	client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "a"}))
+	client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "b"}))
+	client.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "b", Name: "o", Data: []byte("data")}))
+
+	entries := func(out string) []inference.AuditEntry {
+		t.Helper()
+		var got []inference.AuditEntry
+		dec := json.NewDecoder(strings.NewReader(out))
+		for dec.More() {
+			var e inference.AuditEntry
+			if err := dec.Decode(&e); err != nil {
+				t.Fatalf("bad audit output %q: %v", out, err)
+			}
+			got = append(got, e)
+		}
+		return got
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"audit", "-server", httpServer.URL, "-bucket", "b"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("remote audit exited %d: %s", code, stderr.String())
+	}
+	remote := entries(stdout.String())
+	if len(remote) != 2 || remote[1].Object != "o" || remote[1].Generation == 0 {
+		t.Errorf("unexpected remote audit entries %+v", remote)
+	}
+	httpServer.Close()
+	server.Close()
+
+	out := filepath.Join(t.TempDir(), "audit.jsonl")
+	if code := run([]string{"audit", "-storage-dir", dir, "-bucket", "b", "-o", out}, &stdout, &stderr); code != 0 {
+		t.Fatalf("audit exited %d: %s", code, stderr.String())
+	}
+	data, _ := os.ReadFile(out)
+	if local := entries(string(data)); !reflect.DeepEqual(local, remote) {
+		t.Errorf("local export differs from remote:\n%+v\n%+v", local, remote)
+	}
+	stdout.Reset()
+	if code := run([]string{"audit", "-storage-dir", dir, "-since", time.Now().Add(time.Hour).Format(time.RFC3339)}, &stdout, &stderr); code != 0 || stdout.Len() != 0 {
+		t.Errorf("expected no entries from the future, got %d: %s", code, stdout.String())
+	}
+	if code := run([]string{"audit", "-storage-dir", dir, "-object", "o"}, &stdout, &stderr); code != 2 {
+		t.Errorf("expected exit 2 for -object without -bucket, got %d", code)
+	}
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/inference"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/telemetry"
+	"gopkg.in/yaml.v3"
+)
+
+// config holds the settings that can come from a config file, STORAGE_*
+// environment variables and flags, in rising precedence.
+type config struct {
+	Listen         string        `yaml:"listen"`
+	StorageDir     string        `yaml:"storageDir"`
+	LogLevel       string        `yaml:"logLevel"`
+	LogFormat      string        `yaml:"logFormat"`
+	ShutdownGrace  time.Duration `yaml:"shutdownGrace"`
+	MaxMessageSize int           `yaml:"maxMessageSize"`
+	MinFreeDisk    uint64        `yaml:"minFreeDisk"`
+	Features       features      `yaml:"features"`
+	TLS            tlsSettings   `yaml:"tls"`
+	Tracing        tracing       `yaml:"tracing"`
+	Auth           authSettings  `yaml:"auth"`
+	Admins         memberList    `yaml:"admins"`
+	KMS            kmsSettings   `yaml:"kms"`
+	Quotas         string        `yaml:"quotas"`
+	RateLimits     string        `yaml:"rateLimits"`
+	Fsck           fsckSettings  `yaml:"fsck"`
+	Seed           string        `yaml:"seed"`
+	Faults         faultSettings `yaml:"faults"`
+
+	// file is the config file the settings were read from, if any.
+	file string
+}
+
+// features toggle optional behaviour of the server.
+type features struct {
+	InMemory         bool `yaml:"inMemory"`
+	ContentAddressed bool `yaml:"contentAddressed"`
+	Lifecycle        bool `yaml:"lifecycle"`
+}
+
+// tlsSettings select TLS serving; with none set StorageManager serves h2c.
+type tlsSettings struct {
+	Cert              string `yaml:"cert"`
+	Key               string `yaml:"key"`
+	ClientCA          string `yaml:"clientCA"`
+	RequireClientCert bool   `yaml:"requireClientCert"`
+	SelfSigned        string `yaml:"selfSigned"`
+}
+
+// tracing selects where request traces go; with no exporter tracing is off.
+type tracing struct {
+	Exporter string `yaml:"exporter"`
+	Endpoint string `yaml:"endpoint"`
+	File     string `yaml:"file"`
+}
+
+// authSettings select how callers authenticate; with no credentials
+// StorageManager runs in unauthenticated dev mode.
+type authSettings struct {
+	Tokens   string `yaml:"tokens"`
+	Keys     string `yaml:"keys"`
+	Audience string `yaml:"audience"`
+	Dev      bool   `yaml:"dev"`
+}
+
+// memberList is a list of IAM members, given on the command line and in the
+// environment as one comma-separated value.
+type memberList []string
+
+func (l *memberList) String() string { return strings.Join(*l, ",") }
+
+func (l *memberList) Set(v string) error {
+	*l = nil
+	for _, m := range strings.Split(v, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			*l = append(*l, m)
+		}
+	}
+	return nil
+}
+
+// kmsSettings select the KMS that encrypts data at rest; with neither a
+// keyring nor an endpoint data is stored unencrypted.
+type kmsSettings struct {
+	Keyring     string `yaml:"keyring"`
+	Endpoint    string `yaml:"endpoint"`
+	MetadataKey string `yaml:"metadataKey"`
+}
+
+// fsckSettings select the consistency check run at startup.
+type fsckSettings struct {
+	Mode      string `yaml:"mode"`
+	Orphans   string `yaml:"orphans"`
+	Checksums bool   `yaml:"checksums"`
+}
+
+// faultSettings enable fault injection, for tests only.
+type faultSettings struct {
+	File    string `yaml:"file"`
+	Enabled bool   `yaml:"enabled"`
+}
+
+// configVars pairs each config flag with the environment variable that sets
+// it when the flag is not given.
+var configVars = []struct{ flag, env string }{
+	{"listen", "STORAGE_LISTEN"},
+	{"storage-dir", "STORAGE_DIR"},
+	{"log-level", "STORAGE_LOG_LEVEL"},
+	{"log-format", "STORAGE_LOG_FORMAT"},
+	{"shutdown-grace", "STORAGE_SHUTDOWN_GRACE"},
+	{"max-message-size", "STORAGE_MAX_MESSAGE_SIZE"},
+	{"min-free-disk", "STORAGE_MIN_FREE_DISK"},
+	{"in-memory", "STORAGE_IN_MEMORY"},
+	{"content-addressed", "STORAGE_CONTENT_ADDRESSED"},
+	{"lifecycle", "STORAGE_LIFECYCLE"},
+	{"tls-cert", "STORAGE_TLS_CERT"},
+	{"tls-key", "STORAGE_TLS_KEY"},
+	{"tls-client-ca", "STORAGE_TLS_CLIENT_CA"},
+	{"tls-require-client-cert", "STORAGE_TLS_REQUIRE_CLIENT_CERT"},
+	{"tls-self-signed", "STORAGE_TLS_SELF_SIGNED"},
+	{"trace-exporter", "STORAGE_TRACE_EXPORTER"},
+	{"trace-endpoint", "STORAGE_TRACE_ENDPOINT"},
+	{"trace-file", "STORAGE_TRACE_FILE"},
+	{"auth-tokens", "STORAGE_AUTH_TOKENS"},
+	{"auth-keys", "STORAGE_AUTH_KEYS"},
+	{"auth-audience", "STORAGE_AUTH_AUDIENCE"},
+	{"auth-dev", "STORAGE_AUTH_DEV"},
+	{"admins", "STORAGE_ADMINS"},
+	{"kms-keyring", "STORAGE_KMS_KEYRING"},
+	{"kms-endpoint", "STORAGE_KMS_ENDPOINT"},
+	{"kms-metadata-key", "STORAGE_KMS_METADATA_KEY"},
+	{"quotas", "STORAGE_QUOTAS"},
+	{"rate-limits", "STORAGE_RATE_LIMITS"},
+	{"fsck", "STORAGE_FSCK"},
+	{"fsck-orphans", "STORAGE_FSCK_ORPHANS"},
+	{"fsck-checksums", "STORAGE_FSCK_CHECKSUMS"},
+	{"seed", "STORAGE_SEED"},
+	{"faults", "STORAGE_FAULTS"},
+	{"fault-injection", "STORAGE_FAULT_INJECTION"},
+}
+
+// defaultStorageDir is where data goes when no storage dir is configured,
+// relative to the directory of the StorageManager binary.
+const defaultStorageDir = "../../60000-Information-Storage/StorageData"
+
+func defaultConfig() config {
+	return config{
+		Listen:        ":8091", // From genesis.json
+		LogLevel:      "info",
+		LogFormat:     "text",
+		ShutdownGrace: 5 * time.Second,
+		MinFreeDisk:   100 << 20,
+		Features:      features{Lifecycle: true},
+		KMS:           kmsSettings{MetadataKey: "projects/olympus/locations/global/keyRings/storage/cryptoKeys/metadata"},
+		Fsck:          fsckSettings{Orphans: inference.OrphansQuarantine, Checksums: true},
+	}
+}
+
+// register sets c to the defaults and binds the config flags to it.
+func (c *config) register(fs *flag.FlagSet) {
+	*c = defaultConfig()
+	fs.StringVar(&c.file, "config", "", "YAML or JSON config file (env STORAGE_CONFIG); environment variables and flags override it")
+	fs.StringVar(&c.Listen, "listen", c.Listen, "address to serve on")
+	fs.StringVar(&c.StorageDir, "storage-dir", c.StorageDir, "data directory, by default "+defaultStorageDir+" next to the binary; relative paths in a config file are relative to the file")
+	fs.StringVar(&c.LogLevel, "log-level", c.LogLevel, "minimum log level: debug, info, warn or error")
+	fs.StringVar(&c.LogFormat, "log-format", c.LogFormat, "log format: text or json")
+	fs.DurationVar(&c.ShutdownGrace, "shutdown-grace", c.ShutdownGrace, "time in-flight requests get to finish on shutdown")
+	fs.IntVar(&c.MaxMessageSize, "max-message-size", c.MaxMessageSize, "largest RPC message in bytes the server reads or sends, 0 for no limit")
+	fs.Uint64Var(&c.MinFreeDisk, "min-free-disk", c.MinFreeDisk, "free bytes the data dir's filesystem needs for the server to report ready")
+	fs.BoolVar(&c.Features.InMemory, "in-memory", c.Features.InMemory, "keep all data in memory and discard it on exit")
+	fs.BoolVar(&c.Features.ContentAddressed, "content-addressed", c.Features.ContentAddressed, "store each distinct object content once, by SHA-256")
+	fs.BoolVar(&c.Features.Lifecycle, "lifecycle", c.Features.Lifecycle, "run the hourly lifecycle pass and blob garbage collection")
+	fs.StringVar(&c.TLS.Cert, "tls-cert", c.TLS.Cert, "PEM server certificate chain; serves TLS instead of h2c")
+	fs.StringVar(&c.TLS.Key, "tls-key", c.TLS.Key, "PEM server private key")
+	fs.StringVar(&c.TLS.ClientCA, "tls-client-ca", c.TLS.ClientCA, "PEM CA bundle verifying client certificates, which authenticate by email SAN or CN")
+	fs.BoolVar(&c.TLS.RequireClientCert, "tls-require-client-cert", c.TLS.RequireClientCert, "refuse connections without a verified client certificate")
+	fs.StringVar(&c.TLS.SelfSigned, "tls-self-signed", c.TLS.SelfSigned, "directory to write a throwaway CA, server and client certificates to at startup and serve TLS with")
+	fs.StringVar(&c.Tracing.Exporter, "trace-exporter", c.Tracing.Exporter, "where request traces go: otlp, stdout or file; empty turns tracing off")
+	fs.StringVar(&c.Tracing.Endpoint, "trace-endpoint", c.Tracing.Endpoint, "OTLP/HTTP collector URL, e.g. http://localhost:4318; defaults to OTEL_EXPORTER_OTLP_ENDPOINT")
+	fs.StringVar(&c.Tracing.File, "trace-file", c.Tracing.File, "file the file trace exporter appends JSON spans to")
+	fs.StringVar(&c.Auth.Tokens, "auth-tokens", c.Auth.Tokens, "JSON file mapping static bearer tokens to IAM members")
+	fs.StringVar(&c.Auth.Keys, "auth-keys", c.Auth.Keys, "directory of service account key files accepted as JWT signers")
+	fs.StringVar(&c.Auth.Audience, "auth-audience", c.Auth.Audience, "required aud claim for JWTs")
+	fs.BoolVar(&c.Auth.Dev, "auth-dev", c.Auth.Dev, "admit unauthenticated requests (implied when no credentials are configured)")
+	fs.Var(&c.Admins, "admins", "comma-separated IAM members, e.g. user:ops@example.com, who administer the server: rotate KMS keys, manage fault rules and own buckets without an owner")
+	fs.StringVar(&c.KMS.Keyring, "kms-keyring", c.KMS.Keyring, "local keyring file for encryption at rest")
+	fs.StringVar(&c.KMS.Endpoint, "kms-endpoint", c.KMS.Endpoint, "Cloud KMS REST endpoint of the Vault/KMS emulator, e.g. http://localhost:8092")
+	fs.StringVar(&c.KMS.MetadataKey, "kms-metadata-key", c.KMS.MetadataKey, "KMS key that encrypts BoltDB metadata")
+	fs.StringVar(&c.Quotas, "quotas", c.Quotas, "JSON file of per-bucket and per-project quotas")
+	fs.StringVar(&c.RateLimits, "rate-limits", c.RateLimits, "JSON file of request rate limits, reloaded on SIGHUP")
+	fs.StringVar(&c.Fsck.Mode, "fsck", c.Fsck.Mode, "consistency check at startup: check, or repair to also fix what it finds")
+	fs.StringVar(&c.Fsck.Orphans, "fsck-orphans", c.Fsck.Orphans, "repair for files with no metadata: adopt or quarantine")
+	fs.BoolVar(&c.Fsck.Checksums, "fsck-checksums", c.Fsck.Checksums, "read all stored data during the consistency check to verify checksums")
+	fs.StringVar(&c.Seed, "seed", c.Seed, "YAML or JSON manifest of buckets and objects to create at startup")
+	fs.StringVar(&c.Faults.File, "faults", c.Faults.File, "YAML or JSON file of fault injection rules, reloaded on SIGHUP; for tests only")
+	fs.BoolVar(&c.Faults.Enabled, "fault-injection", c.Faults.Enabled, "enable fault injection, managed through SetFaultRules and the /retry_test API, with no initial rules")
+}
+
+// resolve applies the config file and environment under the flags fs has
+// parsed, then validates the result. lookupEnv is os.LookupEnv outside
+// tests.
+func (c *config) resolve(fs *flag.FlagSet, lookupEnv func(string) (string, bool)) error {
+	given := map[string]string{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = f.Value.String() })
+	file := c.file
+	if file == "" {
+		file, _ = lookupEnv("STORAGE_CONFIG")
+	}
+
+	*c = defaultConfig()
+	if file != "" {
+		if err := c.load(file); err != nil {
+			return err
+		}
+	}
+	for _, v := range configVars {
+		value, ok := given[v.flag]
+		source := "-" + v.flag
+		if !ok {
+			value, ok = lookupEnv(v.env)
+			source = v.env
+		}
+		if !ok {
+			continue
+		}
+		if err := fs.Set(v.flag, value); err != nil {
+			return fmt.Errorf("invalid %s: %v", source, err)
+		}
+	}
+	if c.StorageDir == "" && !c.Features.InMemory {
+		exe, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("no storage dir is configured and the binary's directory is unknown: %v", err)
+		}
+		c.StorageDir = filepath.Join(filepath.Dir(exe), defaultStorageDir)
+	}
+	return c.validate()
+}
+
+// load reads the config file over c.
+func (c *config) load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	relativeTo(path, &c.StorageDir, &c.TLS.Cert, &c.TLS.Key, &c.TLS.ClientCA, &c.TLS.SelfSigned, &c.Tracing.File,
+		&c.Auth.Tokens, &c.Auth.Keys, &c.KMS.Keyring, &c.Quotas, &c.RateLimits, &c.Seed, &c.Faults.File)
+	c.file = path
+	return nil
+}
+
+// relativeTo resolves relative paths read from file against its directory.
+func relativeTo(file string, paths ...*string) {
+	for _, p := range paths {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(filepath.Dir(file), *p)
+		}
+	}
+}
+
+func (c *config) validate() error {
+	if c.Listen == "" {
+		return errors.New("listen address is empty")
+	}
+	if c.StorageDir == "" && !c.Features.InMemory {
+		return errors.New("storage dir is empty")
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return fmt.Errorf("invalid log level %q", c.LogLevel)
+	}
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		return fmt.Errorf("invalid log format %q, expected text or json", c.LogFormat)
+	}
+	if c.ShutdownGrace < 0 {
+		return fmt.Errorf("negative shutdown grace period %v", c.ShutdownGrace)
+	}
+	if c.MaxMessageSize < 0 {
+		return fmt.Errorf("negative max message size %d", c.MaxMessageSize)
+	}
+	switch t := c.TLS; {
+	case t.SelfSigned != "" && (t.Cert != "" || t.Key != "" || t.ClientCA != ""):
+		return errors.New("a self-signed TLS setup takes no certificate, key or client CA")
+	case (t.Cert == "") != (t.Key == ""):
+		return errors.New("a TLS certificate needs its key and the other way round")
+	case t.ClientCA != "" && t.Cert == "":
+		return errors.New("client certificates need a TLS server certificate")
+	case t.RequireClientCert && t.ClientCA == "" && t.SelfSigned == "":
+		return errors.New("requiring client certificates needs a client CA")
+	}
+	switch c.Tracing.Exporter {
+	case "", telemetry.TraceExporterOTLP, telemetry.TraceExporterStdout:
+	case telemetry.TraceExporterFile:
+		if c.Tracing.File == "" {
+			return errors.New("the file trace exporter needs a trace file")
+		}
+	default:
+		return fmt.Errorf("invalid trace exporter %q, expected otlp, stdout or file", c.Tracing.Exporter)
+	}
+	if m := c.Fsck.Mode; m != "" && m != "check" && m != "repair" {
+		return fmt.Errorf("invalid fsck mode %q, expected check or repair", m)
+	}
+	if o := c.Fsck.Orphans; o != inference.OrphansAdopt && o != inference.OrphansQuarantine {
+		return fmt.Errorf("invalid fsck orphans repair %q, expected adopt or quarantine", o)
+	}
+	return nil
+}
+
+// serverTLS returns the TLS config to serve with, or nil for h2c. A
+// self-signed setup is generated for the host of the listen address.
+func (c *config) serverTLS() (*tls.Config, error) {
+	t := c.TLS
+	cfg := inference.TLSConfig{CertFile: t.Cert, KeyFile: t.Key, ClientCAFile: t.ClientCA}
+	switch {
+	case t.SelfSigned != "":
+		host, _, _ := net.SplitHostPort(c.Listen)
+		generated, err := inference.GenerateDevCertificates(t.SelfSigned, host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate TLS certificates: %v", err)
+		}
+		cfg = generated
+	case t.Cert == "":
+		return nil, nil
+	}
+	cfg.RequireClientCert = t.RequireClientCert
+	return inference.NewServerTLSConfig(cfg)
+}
+
+// tracingConfig returns the settings for telemetry.SetupTracing.
+func (c *config) tracingConfig() telemetry.TracingConfig {
+	return telemetry.TracingConfig{
+		Exporter:    c.Tracing.Exporter,
+		Endpoint:    c.Tracing.Endpoint,
+		File:        c.Tracing.File,
+		ServiceName: "StorageManager",
+	}
+}
+
+// authConfig returns the settings for inference.NewAuthInterceptor.
+func (c *config) authConfig() inference.AuthConfig {
+	return inference.AuthConfig{
+		TokensFile: c.Auth.Tokens,
+		KeysDir:    c.Auth.Keys,
+		Audience:   c.Auth.Audience,
+		Dev:        c.Auth.Dev,
+	}
+}
+
+// fsckOptions returns the options for the startup consistency check.
+func (c *config) fsckOptions() inference.FsckOptions {
+	opts := inference.FsckOptions{VerifyChecksums: c.Fsck.Checksums}
+	if c.Fsck.Mode == "repair" {
+		opts.Orphans = c.Fsck.Orphans
+		opts.DropDangling = true
+		opts.RemoveTempFiles = true
+	}
+	return opts
+}
+
+// logger returns a logger writing to w at the configured level and format.
+func (c *config) logger(w io.Writer) *slog.Logger {
+	var level slog.Level
+	level.UnmarshalText([]byte(c.LogLevel))
+	opts := &slog.HandlerOptions{Level: level}
+	if c.LogFormat == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// LogValue reports the effective configuration.
+func (c *config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("file", c.file),
+		slog.String("listen", c.Listen),
+		slog.String("storageDir", c.StorageDir),
+		slog.String("logLevel", c.LogLevel),
+		slog.String("logFormat", c.LogFormat),
+		slog.Duration("shutdownGrace", c.ShutdownGrace),
+		slog.Int("maxMessageSize", c.MaxMessageSize),
+		slog.Uint64("minFreeDisk", c.MinFreeDisk),
+		slog.Bool("inMemory", c.Features.InMemory),
+		slog.Bool("contentAddressed", c.Features.ContentAddressed),
+		slog.Bool("lifecycle", c.Features.Lifecycle),
+		slog.Group("tls",
+			slog.String("cert", c.TLS.Cert),
+			slog.String("key", c.TLS.Key),
+			slog.String("clientCA", c.TLS.ClientCA),
+			slog.Bool("requireClientCert", c.TLS.RequireClientCert),
+			slog.String("selfSigned", c.TLS.SelfSigned),
+		),
+		slog.Group("tracing",
+			slog.String("exporter", c.Tracing.Exporter),
+			slog.String("endpoint", c.Tracing.Endpoint),
+			slog.String("file", c.Tracing.File),
+		),
+		slog.Group("auth",
+			slog.String("tokens", c.Auth.Tokens),
+			slog.String("keys", c.Auth.Keys),
+			slog.String("audience", c.Auth.Audience),
+			slog.Bool("dev", c.Auth.Dev),
+		),
+		slog.String("admins", c.Admins.String()),
+		slog.Group("kms",
+			slog.String("keyring", c.KMS.Keyring),
+			slog.String("endpoint", c.KMS.Endpoint),
+			slog.String("metadataKey", c.KMS.MetadataKey),
+		),
+		slog.String("quotas", c.Quotas),
+		slog.String("rateLimits", c.RateLimits),
+		slog.Group("fsck",
+			slog.String("mode", c.Fsck.Mode),
+			slog.String("orphans", c.Fsck.Orphans),
+			slog.Bool("checksums", c.Fsck.Checksums),
+		),
+		slog.String("seed", c.Seed),
+		slog.Group("faults",
+			slog.String("file", c.Faults.File),
+			slog.Bool("enabled", c.Faults.Enabled),
+		),
+	)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "storage.yaml")
+	os.WriteFile(file, []byte(`
+listen: 127.0.0.1:9000
+storageDir: data
+logLevel: debug
+shutdownGrace: 30s
+maxMessageSize: 1048576
+features:
+  contentAddressed: true
+  lifecycle: false
+tracing:
+  exporter: file
+  file: traces.json
+auth:
+  tokens: tokens.json
+admins: [user:ops@example.com]
+kms:
+  keyring: keyring.json
+fsck:
+  mode: check
+`), 0644)
+
+	resolve := func(args []string, env map[string]string) (*config, error) {
+		fs := flag.NewFlagSet("StorageManager", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		var cfg config
+		cfg.register(fs)
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		err := cfg.resolve(fs, func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		})
+		return &cfg, err
+	}
+
+	// Without a storage dir data goes next to the binary, not the working
+	// directory.
+	exe, _ := os.Executable()
+	defaults := defaultConfig()
+	defaults.StorageDir = filepath.Join(filepath.Dir(exe), defaultStorageDir)
+	cfg, err := resolve(nil, nil)
+	if err != nil || !reflect.DeepEqual(*cfg, defaults) {
+		t.Errorf("expected defaults, got %+v (%v)", cfg, err)
+	}
+
+	cfg, err = resolve([]string{"-config", file}, nil)
+	want := config{
+		Listen:         "127.0.0.1:9000",
+		StorageDir:     filepath.Join(dir, "data"),
+		LogLevel:       "debug",
+		LogFormat:      "text",
+		ShutdownGrace:  30 * time.Second,
+		MaxMessageSize: 1 << 20,
+		MinFreeDisk:    100 << 20,
+		Features:       features{ContentAddressed: true},
+		Tracing:        tracing{Exporter: "file", File: filepath.Join(dir, "traces.json")},
+		Auth:           authSettings{Tokens: filepath.Join(dir, "tokens.json")},
+		Admins:         memberList{"user:ops@example.com"},
+		KMS:            kmsSettings{Keyring: filepath.Join(dir, "keyring.json"), MetadataKey: defaults.KMS.MetadataKey},
+		Fsck:           fsckSettings{Mode: "check", Orphans: defaults.Fsck.Orphans, Checksums: true},
+		file:           file,
+	}
+	if err != nil || !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("unexpected config from file:\n got %+v (%v)\nwant %+v", cfg, err, want)
+	}
+
+	// The environment overrides the file and flags override both.
+	env := map[string]string{
+		"STORAGE_CONFIG":         file,
+		"STORAGE_LISTEN":         ":9100",
+		"STORAGE_LOG_FORMAT":     "json",
+		"STORAGE_SHUTDOWN_GRACE": "1m",
+		"STORAGE_LIFECYCLE":      "true",
+		"STORAGE_TRACE_EXPORTER": "otlp",
+		"STORAGE_ADMINS":         "user:a@example.com, user:b@example.com",
+		"STORAGE_RATE_LIMITS":    "limits.json",
+		"STORAGE_FSCK":           "check",
+	}
+	cfg, err = resolve([]string{"-listen", ":9200", "-storage-dir", "rel", "-lifecycle=false", "-fsck", "repair"}, env)
+	want.Listen, want.StorageDir, want.LogFormat, want.ShutdownGrace = ":9200", "rel", "json", time.Minute
+	want.Tracing.Exporter = "otlp"
+	want.Admins, want.RateLimits, want.Fsck.Mode = memberList{"user:a@example.com", "user:b@example.com"}, "limits.json", "repair"
+	if err != nil || !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("unexpected layered config:\n got %+v (%v)\nwant %+v", cfg, err, want)
+	}
+
+	for _, bad := range []struct {
+		args []string
+		env  map[string]string
+		want string
+	}{
+		{[]string{"-log-level", "loud"}, nil, "invalid log level"},
+		{nil, map[string]string{"STORAGE_LOG_FORMAT": "xml"}, "invalid log format"},
+		{nil, map[string]string{"STORAGE_MAX_MESSAGE_SIZE": "big"}, "invalid STORAGE_MAX_MESSAGE_SIZE"},
+		{[]string{"-shutdown-grace", "-1s"}, nil, "negative shutdown grace"},
+		{[]string{"-config", filepath.Join(dir, "missing.yaml")}, nil, "failed to read config file"},
+		{[]string{"-tls-cert", "server.pem"}, nil, "needs its key"},
+		{[]string{"-tls-require-client-cert"}, nil, "needs a client CA"},
+		{[]string{"-tls-self-signed", dir, "-tls-client-ca", "ca.pem"}, nil, "takes no certificate"},
+		{nil, map[string]string{"STORAGE_TRACE_EXPORTER": "jaeger"}, "invalid trace exporter"},
+		{[]string{"-trace-exporter", "file"}, nil, "needs a trace file"},
+		{nil, map[string]string{"STORAGE_FSCK": "scan"}, "invalid fsck mode"},
+		{[]string{"-fsck-orphans", "delete"}, nil, "invalid fsck orphans repair"},
+		{nil, map[string]string{"STORAGE_AUTH_DEV": "maybe"}, "invalid STORAGE_AUTH_DEV"},
+	} {
+		if _, err := resolve(bad.args, bad.env); err == nil || !strings.Contains(err.Error(), bad.want) {
+			t.Errorf("%v %v: expected %q, got %v", bad.args, bad.env, bad.want, err)
+		}
+	}
+	os.WriteFile(file, []byte("listen: :1\nport: 2\n"), 0644)
+	if _, err := resolve([]string{"-config", file}, nil); err == nil || !strings.Contains(err.Error(), "invalid config file") {
+		t.Errorf("expected unknown config keys to be rejected, got %v", err)
+	}
+}
+
+func TestConfigLogger(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	cfg.LogLevel, cfg.LogFormat = "warn", "json"
+	var out bytes.Buffer
+	logger := cfg.logger(&out)
+	logger.Info("hidden")
+	logger.Warn("shown", "config", &cfg)
+	if got := out.String(); strings.Contains(got, "hidden") || !strings.Contains(got, `"listen":":8091"`) {
+		t.Errorf("unexpected log output %s", got)
+	}
+}
+
+func TestConfigTLS(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	if tlsConfig, err := cfg.serverTLS(); tlsConfig != nil || err != nil {
+		t.Errorf("expected h2c by default, got %v (%v)", tlsConfig, err)
+	}
+
+	cfg.Listen = "storage.test:8443"
+	cfg.TLS = tlsSettings{SelfSigned: t.TempDir(), RequireClientCert: true}
+	tlsConfig, err := cfg.serverTLS()
+	if err != nil || tlsConfig.ClientCAs == nil || tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Fatalf("unexpected self-signed TLS config: %v (%v)", tlsConfig, err)
+	}
+	leaf, _ := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+	if err := leaf.VerifyHostname("storage.test"); err != nil {
+		t.Errorf("server certificate does not cover the listen host: %v", err)
+	}
+
+	// The generated files serve as a regular setup.
+	cfg.TLS = tlsSettings{
+		Cert:     filepath.Join(cfg.TLS.SelfSigned, "server.pem"),
+		Key:      filepath.Join(cfg.TLS.SelfSigned, "server-key.pem"),
+		ClientCA: filepath.Join(cfg.TLS.SelfSigned, "ca.pem"),
+	}
+	if tlsConfig, err := cfg.serverTLS(); err != nil || tlsConfig.ClientAuth != tls.VerifyClientCertIfGiven {
+		t.Errorf("unexpected TLS config from files: %v (%v)", tlsConfig, err)
+	}
+}
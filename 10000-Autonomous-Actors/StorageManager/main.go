@@ -1,58 +1,272 @@
-package main
-
-import (
-	"context"
-	"fmt"
-	"log/slog"
-	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
-	"time"
-
-	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
-	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/inference"
-
-	"golang.org/x/net/http2"
-	"golang.org/x/net/http2/h2c"
-)
-
-func main() {
-	storageDir := "../../60000-Information-Storage/StorageData"
-	server := inference.NewStorageServer(storageDir)
-	defer server.Close()
-
-	mux := http.NewServeMux()
-	path, handler := storagev1connect.NewStorageServiceHandler(server)
-	mux.Handle(path, handler)
-
-	// Health Check / Pulse
-	mux.HandleFunc("/pulse", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		fmt.Fprintf(w, `{"status":"HEALTHY", "workspace":"OlympusGCP-Storage", "time":"%s"}`, time.Now().Format(time.RFC3339))
-	})
-
-	port := "8091" // From genesis.json
-	slog.Info("StorageManager starting", "port", port)
-
-	srv := &http.Server{
-		Addr:              ":" + port,
-		Handler:           h2c.NewHandler(mux, &http2.Server{}),
-		ReadHeaderTimeout: 3 * time.Second,
-	}
-
-	done := make(chan os.Signal, 1)
-	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			slog.Error("Server failed", "error", err)
-		}
-	}()
-
-	<-done
-	slog.Info("StorageManager shutting down...")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	srv.Shutdown(ctx)
-}
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/inference"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/telemetry"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpchealth"
+	"connectrpc.com/grpcreflect"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// newMux routes the StorageService, whose handler takes opts, next to the
+// health, reflection and metrics endpoints, which are open to
+// unauthenticated probes and scrapes.
+func newMux(server *inference.StorageServer, health *inference.HealthChecker, metrics *inference.Metrics, opts ...connect.HandlerOption) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle(storagev1connect.NewStorageServiceHandler(server, opts...))
+	mux.Handle(grpchealth.NewHandler(health))
+	reflector := grpcreflect.NewStaticReflector(storagev1connect.StorageServiceName, grpchealth.HealthV1ServiceName)
+	mux.Handle(grpcreflect.NewHandlerV1(reflector))
+	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+	mux.Handle("/pulse", health)
+	mux.Handle("/metrics", metrics)
+	return mux
+}
+
+func main() {
+	var cfg config
+	cfg.register(flag.CommandLine)
+	flag.Parse()
+	if err := cfg.resolve(flag.CommandLine, os.LookupEnv); err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(cfg.logger(os.Stderr))
+	slog.Info("StorageManager configuration", "config", &cfg)
+	shutdownTracing, err := telemetry.SetupTracing(context.Background(), cfg.tracingConfig())
+	if err != nil {
+		slog.Error("Failed to configure tracing", "error", err)
+		os.Exit(1)
+	}
+	tracing, err := telemetry.NewTracingInterceptor()
+	if err != nil {
+		slog.Error("Failed to configure tracing", "error", err)
+		os.Exit(1)
+	}
+	tlsConfig, err := cfg.serverTLS()
+	if err != nil {
+		slog.Error("Failed to configure TLS", "error", err)
+		os.Exit(1)
+	}
+	auth := cfg.authConfig()
+	if auth.TokensFile == "" && auth.KeysDir == "" && (tlsConfig == nil || tlsConfig.ClientCAs == nil) && !auth.Dev {
+		slog.Warn("No credentials configured, running in unauthenticated dev mode")
+		auth.Dev = true
+		if len(cfg.Admins) > 0 {
+			slog.Warn("No credentials configured, so -admins cannot authenticate: KMS rotation and fault rules are unavailable")
+		}
+	}
+	if !auth.Dev && len(cfg.Admins) == 0 {
+		slog.Warn("Authentication is on but no -admins are named: buckets created anonymously or without a stored policy are open to allUsers")
+	}
+	authInterceptor, err := inference.NewAuthInterceptor(auth)
+	if err != nil {
+		slog.Error("Failed to configure authentication", "error", err)
+		os.Exit(1)
+	}
+
+	var interceptors []connect.Interceptor
+	if cfg.RateLimits != "" {
+		limits, err := inference.LoadRateLimitConfig(cfg.RateLimits)
+		if err != nil {
+			slog.Error("Failed to load rate limits", "path", cfg.RateLimits, "error", err)
+			os.Exit(1)
+		}
+		limiter := inference.NewRateLimiter(limits)
+		interceptors = append(interceptors, limiter)
+		// A bad file on reload keeps the limits in force.
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go func() {
+			for range hup {
+				limits, err := inference.LoadRateLimitConfig(cfg.RateLimits)
+				if err != nil {
+					slog.Error("Failed to reload rate limits", "path", cfg.RateLimits, "error", err)
+					continue
+				}
+				limiter.Reload(limits)
+			}
+		}()
+	}
+
+	opts := []inference.Option{inference.WithAdmins(cfg.Admins...)}
+	var retryTests *inference.RetryTests
+	if cfg.Faults.File != "" || cfg.Faults.Enabled {
+		var faultCfg inference.FaultConfig
+		if cfg.Faults.File != "" {
+			if faultCfg, err = inference.LoadFaultConfig(cfg.Faults.File); err != nil {
+				slog.Error("Failed to load fault rules", "path", cfg.Faults.File, "error", err)
+				os.Exit(1)
+			}
+		}
+		faults, err := inference.NewFaultInjector(faultCfg)
+		if err != nil {
+			slog.Error("Invalid fault rules", "path", cfg.Faults.File, "error", err)
+			os.Exit(1)
+		}
+		slog.Warn("Fault injection enabled, requests may fail on purpose", "rules", len(faultCfg.Rules))
+		// Faults are injected innermost, so every other interceptor sees
+		// them as the handler's own outcome.
+		retryTests = inference.NewRetryTests()
+		interceptors = append(interceptors, faults, retryTests)
+		opts = append(opts, inference.WithFaultInjector(faults))
+		if cfg.Faults.File != "" {
+			hup := make(chan os.Signal, 1)
+			signal.Notify(hup, syscall.SIGHUP)
+			go func() {
+				for range hup {
+					rules, err := inference.LoadFaultConfig(cfg.Faults.File)
+					if err == nil {
+						err = faults.Reload(rules)
+					}
+					if err != nil {
+						slog.Error("Failed to reload fault rules", "path", cfg.Faults.File, "error", err)
+					}
+				}
+			}()
+		}
+	}
+	if cfg.Features.InMemory {
+		slog.Warn("Running in memory, data is discarded on exit")
+		opts = append(opts, inference.WithInMemory())
+	}
+	if cfg.Features.ContentAddressed {
+		opts = append(opts, inference.WithContentAddressing())
+	}
+	// Object change notifications go to the Pub/Sub emulator named by the
+	// same variable the Google client libraries honour.
+	if host := os.Getenv("PUBSUB_EMULATOR_HOST"); host != "" {
+		opts = append(opts, inference.WithPubSubEndpoint("http://"+host))
+	}
+	switch {
+	case cfg.KMS.Endpoint != "":
+		opts = append(opts, inference.WithKMS(inference.NewKMSClient(cfg.KMS.Endpoint), cfg.KMS.MetadataKey))
+	case cfg.KMS.Keyring != "":
+		keyring, err := inference.NewLocalKeyring(cfg.KMS.Keyring)
+		if err != nil {
+			slog.Error("Failed to open keyring", "path", cfg.KMS.Keyring, "error", err)
+			os.Exit(1)
+		}
+		opts = append(opts, inference.WithKMS(keyring, cfg.KMS.MetadataKey))
+	default:
+		slog.Warn("No KMS configured, data is stored unencrypted")
+	}
+	if cfg.Quotas != "" {
+		quotas, err := inference.LoadQuotas(cfg.Quotas)
+		if err != nil {
+			slog.Error("Failed to load quotas", "path", cfg.Quotas, "error", err)
+			os.Exit(1)
+		}
+		opts = append(opts, inference.WithQuotas(quotas))
+	}
+	var seed *inference.SeedManifest
+	if cfg.Seed != "" {
+		if seed, err = inference.LoadSeedManifest(cfg.Seed); err != nil {
+			slog.Error("Failed to load seed manifest", "path", cfg.Seed, "error", err)
+			os.Exit(1)
+		}
+	}
+	server := inference.NewStorageServer(cfg.StorageDir, opts...)
+	defer server.Close()
+
+	if cfg.Fsck.Mode != "" {
+		res, err := server.Fsck(context.Background(), cfg.fsckOptions())
+		if err != nil {
+			slog.Error("Consistency check failed", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Consistency check finished", "buckets", res.Buckets, "objects", res.Objects, "issues", len(res.Issues), "unfixed", res.Unfixed())
+	}
+	if seed != nil {
+		if _, err := server.Seed(context.Background(), seed); err != nil {
+			slog.Error("Seeding failed", "path", cfg.Seed, "error", err)
+			os.Exit(1)
+		}
+	}
+
+	health := inference.NewHealthChecker(server, cfg.MinFreeDisk)
+	// Metrics come first to count the requests the others reject, after
+	// tracing, which starts the request span the rest report under. The
+	// audit log follows authentication to record the principal it settles
+	// on, and precedes rate limiting to record the calls it refuses.
+	metrics := inference.NewMetrics(server)
+	audit := inference.NewAuditInterceptor(server)
+	interceptors = append([]connect.Interceptor{tracing, metrics, authInterceptor, audit}, interceptors...)
+	mux := newMux(server, health, metrics,
+		connect.WithInterceptors(interceptors...),
+		connect.WithReadMaxBytes(cfg.MaxMessageSize),
+		connect.WithSendMaxBytes(cfg.MaxMessageSize),
+	)
+	// The retry conformance API of Google's storage testbench, which the
+	// client libraries' conformance suites drive, is as open as the
+	// testbench's.
+	if retryTests != nil {
+		for _, pattern := range []string{"/retry_test", "/retry_test/", "/retry_tests"} {
+			mux.Handle(pattern, retryTests)
+		}
+	}
+
+	// Lifecycle rules are evaluated on a fixed cadence rather than per
+	// request, as GCS does. Blobs the pass released are collected after it.
+	if cfg.Features.Lifecycle {
+		go func() {
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+			for t := range ticker.C {
+				if err := server.ApplyLifecycle(context.Background(), t.UTC()); err != nil {
+					slog.Error("Lifecycle pass failed", "error", err)
+				}
+				if _, err := server.CollectGarbage(context.Background()); err != nil {
+					slog.Error("Blob garbage collection failed", "error", err)
+				}
+			}
+		}()
+	}
+
+	slog.Info("StorageManager starting", "listen", cfg.Listen, "tls", tlsConfig != nil)
+
+	srv := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           h2c.NewHandler(mux, &http2.Server{}),
+		ReadHeaderTimeout: 3 * time.Second,
+	}
+	serve := srv.ListenAndServe
+	if tlsConfig != nil {
+		srv.Handler = inference.ClientCertAuth(mux)
+		srv.TLSConfig = tlsConfig
+		serve = func() error { return srv.ListenAndServeTLS("", "") }
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		if err := serve(); err != nil && err != http.ErrServerClosed {
+			slog.Error("Server failed", "error", err)
+		}
+	}()
+
+	<-done
+	slog.Info("StorageManager shutting down...")
+	health.SetStatus("", grpchealth.StatusNotServing)
+	health.SetStatus(storagev1connect.StorageServiceName, grpchealth.StatusNotServing)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownGrace)
+	defer cancel()
+	srv.Shutdown(ctx)
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
+	}
+}
@@ -1,56 +1,115 @@
-package main
-
-import (
-	"context"
-	"testing"
-
-	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
-	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/inference"
-	"connectrpc.com/connect"
-)
-
-func TestStorageServerAdvanced(t *testing.T) {
-	tempDir := t.TempDir()
-	server := inference.NewStorageServer(tempDir)
-	defer server.Close()
-	ctx := context.Background()
-
-	bucket := "meta-bucket"
-	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: bucket}))
-
-	// Test Upload with Metadata
-	object := "data.bin"
-	metadata := map[string]string{"type": "binary", "owner": "jules"}
-	_, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{
-		Bucket:   bucket,
-		Name:     object,
-		Data:     []byte{0, 1, 2},
-		Metadata: metadata,
-	}))
-	if err != nil {
-		t.Fatalf("Upload failed: %v", err)
-	}
-
-	// Test GetMetadata
-	metaRes, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{
-		Bucket: bucket,
-		Name:   object,
-	}))
-	if err != nil {
-		t.Fatalf("GetMetadata failed: %v", err)
-	}
-	if metaRes.Msg.Metadata["type"] != "binary" {
-		t.Errorf("Expected metadata type 'binary', got '%s'", metaRes.Msg.Metadata["type"])
-	}
-
-	// Test List
-	listRes, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{
-		Bucket: bucket,
-	}))
-	if err != nil {
-		t.Fatalf("List failed: %v", err)
-	}
-	if len(listRes.Msg.ObjectNames) != 1 {
-		t.Errorf("Expected 1 object, got %d", len(listRes.Msg.ObjectNames))
-	}
-}
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/inference"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	"connectrpc.com/connect"
+	"connectrpc.com/grpchealth"
+	"connectrpc.com/grpcreflect"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestStorageServerAdvanced(t *testing.T) {
+	tempDir := t.TempDir()
+	server := inference.NewStorageServer(tempDir)
+	defer server.Close()
+	ctx := context.Background()
+
+	bucket := "meta-bucket"
+	server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: bucket}))
+
+	// Test Upload with Metadata
+	object := "data.bin"
+	metadata := map[string]string{"type": "binary", "owner": "jules"}
+	_, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{
+		Bucket:   bucket,
+		Name:     object,
+		Data:     []byte{0, 1, 2},
+		Metadata: metadata,
+	}))
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	// Test GetMetadata
+	metaRes, err := server.GetObjectMetadata(ctx, connect.NewRequest(&storagev1.GetObjectMetadataRequest{
+		Bucket: bucket,
+		Name:   object,
+	}))
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if metaRes.Msg.Metadata["type"] != "binary" {
+		t.Errorf("Expected metadata type 'binary', got '%s'", metaRes.Msg.Metadata["type"])
+	}
+
+	// Test List
+	listRes, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{
+		Bucket: bucket,
+	}))
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(listRes.Msg.ObjectNames) != 1 {
+		t.Errorf("Expected 1 object, got %d", len(listRes.Msg.ObjectNames))
+	}
+}
+
+func TestStorageServerInMemory(t *testing.T) {
+	t.Parallel()
+	server := inference.NewStorageServer("", inference.WithInMemory())
+	defer server.Close()
+	ctx := context.Background()
+
+	if _, err := server.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{Name: "scratch"})); err != nil {
+		t.Fatalf("CreateBucket failed: %v", err)
+	}
+	if _, err := server.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{Bucket: "scratch", Name: "note.txt", Data: []byte("hi")})); err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	listRes, err := server.ListObjects(ctx, connect.NewRequest(&storagev1.ListObjectsRequest{Bucket: "scratch"}))
+	if err != nil || len(listRes.Msg.ObjectNames) != 1 {
+		t.Errorf("unexpected in-memory listing: %v (%v)", listRes, err)
+	}
+}
+
+func TestProbeEndpoints(t *testing.T) {
+	t.Parallel()
+	server := inference.NewStorageServer("", inference.WithInMemory())
+	defer server.Close()
+	mux := newMux(server, inference.NewHealthChecker(server, 0), inference.NewMetrics(server))
+	httpServer := httptest.NewUnstartedServer(mux)
+	httpServer.EnableHTTP2 = true
+	httpServer.StartTLS()
+	defer httpServer.Close()
+
+	stream := grpcreflect.NewClient(httpServer.Client(), httpServer.URL, connect.WithGRPC()).NewStream(context.Background())
+	defer stream.Close()
+	services, err := stream.ListServices()
+	if err != nil {
+		t.Fatalf("ListServices failed: %v", err)
+	}
+	for _, want := range []protoreflect.FullName{storagev1connect.StorageServiceName, grpchealth.HealthV1ServiceName} {
+		if !slices.Contains(services, want) {
+			t.Errorf("reflection does not list %s: %v", want, services)
+		}
+	}
+	if _, err := stream.FileContainingSymbol(storagev1connect.StorageServiceName + ".UploadObject"); err != nil {
+		t.Errorf("no descriptor for UploadObject: %v", err)
+	}
+	if _, pattern := mux.Handler(httptest.NewRequest(http.MethodPost, "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", nil)); pattern == "" {
+		t.Error("v1alpha reflection is not registered")
+	}
+	for _, path := range []string{"/pulse", "/metrics"} {
+		if resp, err := httpServer.Client().Get(httpServer.URL + path); err != nil || resp.StatusCode != http.StatusOK {
+			t.Errorf("unexpected %s response: %v (%v)", path, resp, err)
+		}
+	}
+}
@@ -1,69 +1,92 @@
-package main
-
-import (
-	"context"
-	"fmt"
-	"net/http"
-
-	"connectrpc.com/connect"
-	"github.com/mark3labs/mcp-go/mcp"
-
-	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
-	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
-	"olympus.fleet/00SDLC/Olympus2/90000-Enablement-Labs/90200-Logic-Libraries/140-MCPBridge"
-)
-
-func main() {
-	s := mcpbridge.NewBridgeServer("OlympusStorageBridge", "1.0.0")
-
-	client := storagev1connect.NewStorageServiceClient(
-		http.DefaultClient,
-		"http://localhost:8091",
-	)
-
-	s.AddTool(mcp.NewTool("storage_create_bucket",
-		mcp.WithDescription("Create a new storage bucket. Args: {name: string}"),
-	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		m, err := mcpbridge.ExtractMap(request)
-		if err != nil {
-			return mcpbridge.HandleError(err)
-		}
-
-		name, _ := m["name"].(string)
-
-		_, err = client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{
-			Name: name,
-		}))
-		if err != nil {
-			return mcpbridge.HandleError(err)
-		}
-
-		return mcp.NewToolResultText(fmt.Sprintf("Bucket '%s' created successfully.", name)), nil
-	})
-
-	s.AddTool(mcp.NewTool("storage_upload",
-		mcp.WithDescription("Upload an object to a bucket. Args: {bucket: string, name: string, data: string}"),
-	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		m, err := mcpbridge.ExtractMap(request)
-		if err != nil {
-			return mcpbridge.HandleError(err)
-		}
-
-		bucket, _ := m["bucket"].(string)
-		name, _ := m["name"].(string)
-		data, _ := m["data"].(string)
-
-		_, err = client.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{
-			Bucket: bucket,
-			Name:   name,
-			Data:   []byte(data),
-		}))
-		if err != nil {
-			return mcpbridge.HandleError(err)
-		}
-
-		return mcp.NewToolResultText(fmt.Sprintf("Object '%s' uploaded to bucket '%s'.", name, bucket)), nil
-	})
-
-	s.Run()
-}
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"connectrpc.com/connect"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/10000-Autonomous-Actors/10700-Processing-Engines/10710-Reasoning-Inference/telemetry"
+	"olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage/storagev1connect"
+	storagev1 "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	"olympus.fleet/00SDLC/Olympus2/90000-Enablement-Labs/90200-Logic-Libraries/140-MCPBridge"
+)
+
+func main() {
+	s := mcpbridge.NewBridgeServer("OlympusStorageBridge", "1.0.0")
+
+	// Traces are configured like StorageManager's, through the environment.
+	// Stdout carries the MCP protocol, so the stdout exporter writes to
+	// stderr.
+	shutdownTracing, err := telemetry.SetupTracing(context.Background(), telemetry.TracingConfig{
+		Exporter:    os.Getenv("STORAGE_TRACE_EXPORTER"),
+		Endpoint:    os.Getenv("STORAGE_TRACE_ENDPOINT"),
+		File:        os.Getenv("STORAGE_TRACE_FILE"),
+		Stdout:      os.Stderr,
+		ServiceName: "StorageBridge",
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+	tracing, err := telemetry.NewTracingInterceptor()
+	if err != nil {
+		log.Fatalf("Failed to configure tracing: %v", err)
+	}
+
+	client := storagev1connect.NewStorageServiceClient(
+		http.DefaultClient,
+		"http://localhost:8091",
+		connect.WithInterceptors(tracing),
+	)
+
+	s.AddTool(mcp.NewTool("storage_create_bucket",
+		mcp.WithDescription("Create a new storage bucket. Args: {name: string}"),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		m, err := mcpbridge.ExtractMap(request)
+		if err != nil {
+			return mcpbridge.HandleError(err)
+		}
+
+		name, _ := m["name"].(string)
+
+		_, err = client.CreateBucket(ctx, connect.NewRequest(&storagev1.CreateBucketRequest{
+			Name: name,
+		}))
+		if err != nil {
+			return mcpbridge.HandleError(err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Bucket '%s' created successfully.", name)), nil
+	})
+
+	s.AddTool(mcp.NewTool("storage_upload",
+		mcp.WithDescription("Upload an object to a bucket. Args: {bucket: string, name: string, data: string}"),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		m, err := mcpbridge.ExtractMap(request)
+		if err != nil {
+			return mcpbridge.HandleError(err)
+		}
+
+		bucket, _ := m["bucket"].(string)
+		name, _ := m["name"].(string)
+		data, _ := m["data"].(string)
+
+		_, err = client.UploadObject(ctx, connect.NewRequest(&storagev1.UploadObjectRequest{
+			Bucket: bucket,
+			Name:   name,
+			Data:   []byte(data),
+		}))
+		if err != nil {
+			return mcpbridge.HandleError(err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Object '%s' uploaded to bucket '%s'.", name, bucket)), nil
+	})
+
+	s.Run()
+}
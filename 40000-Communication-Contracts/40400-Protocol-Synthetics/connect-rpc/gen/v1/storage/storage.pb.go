@@ -1,635 +1,5654 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.36.11
-// 	protoc        (unknown)
-// source: v1/storage/storage.proto
-
-package storagev1
-
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-type CreateBucketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateBucketRequest) Reset() {
-	*x = CreateBucketRequest{}
-	mi := &file_v1_storage_storage_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateBucketRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateBucketRequest) ProtoMessage() {}
-
-func (x *CreateBucketRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateBucketRequest.ProtoReflect.Descriptor instead.
-func (*CreateBucketRequest) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *CreateBucketRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-type CreateBucketResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateBucketResponse) Reset() {
-	*x = CreateBucketResponse{}
-	mi := &file_v1_storage_storage_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateBucketResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateBucketResponse) ProtoMessage() {}
-
-func (x *CreateBucketResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateBucketResponse.ProtoReflect.Descriptor instead.
-func (*CreateBucketResponse) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{1}
-}
-
-type UploadObjectRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UploadObjectRequest) Reset() {
-	*x = UploadObjectRequest{}
-	mi := &file_v1_storage_storage_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UploadObjectRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UploadObjectRequest) ProtoMessage() {}
-
-func (x *UploadObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[2]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UploadObjectRequest.ProtoReflect.Descriptor instead.
-func (*UploadObjectRequest) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *UploadObjectRequest) GetBucket() string {
-	if x != nil {
-		return x.Bucket
-	}
-	return ""
-}
-
-func (x *UploadObjectRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *UploadObjectRequest) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *UploadObjectRequest) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-type UploadObjectResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *UploadObjectResponse) Reset() {
-	*x = UploadObjectResponse{}
-	mi := &file_v1_storage_storage_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *UploadObjectResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UploadObjectResponse) ProtoMessage() {}
-
-func (x *UploadObjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[3]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UploadObjectResponse.ProtoReflect.Descriptor instead.
-func (*UploadObjectResponse) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{3}
-}
-
-type GetObjectMetadataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetObjectMetadataRequest) Reset() {
-	*x = GetObjectMetadataRequest{}
-	mi := &file_v1_storage_storage_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetObjectMetadataRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetObjectMetadataRequest) ProtoMessage() {}
-
-func (x *GetObjectMetadataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[4]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetObjectMetadataRequest.ProtoReflect.Descriptor instead.
-func (*GetObjectMetadataRequest) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *GetObjectMetadataRequest) GetBucket() string {
-	if x != nil {
-		return x.Bucket
-	}
-	return ""
-}
-
-func (x *GetObjectMetadataRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-type GetObjectMetadataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetObjectMetadataResponse) Reset() {
-	*x = GetObjectMetadataResponse{}
-	mi := &file_v1_storage_storage_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetObjectMetadataResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetObjectMetadataResponse) ProtoMessage() {}
-
-func (x *GetObjectMetadataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[5]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetObjectMetadataResponse.ProtoReflect.Descriptor instead.
-func (*GetObjectMetadataResponse) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *GetObjectMetadataResponse) GetBucket() string {
-	if x != nil {
-		return x.Bucket
-	}
-	return ""
-}
-
-func (x *GetObjectMetadataResponse) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *GetObjectMetadataResponse) GetSize() int64 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
-}
-
-func (x *GetObjectMetadataResponse) GetMetadata() map[string]string {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
-}
-
-type ListObjectsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListObjectsRequest) Reset() {
-	*x = ListObjectsRequest{}
-	mi := &file_v1_storage_storage_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListObjectsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListObjectsRequest) ProtoMessage() {}
-
-func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[6]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
-func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *ListObjectsRequest) GetBucket() string {
-	if x != nil {
-		return x.Bucket
-	}
-	return ""
-}
-
-func (x *ListObjectsRequest) GetPrefix() string {
-	if x != nil {
-		return x.Prefix
-	}
-	return ""
-}
-
-type ListObjectsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ObjectNames   []string               `protobuf:"bytes,1,rep,name=object_names,json=objectNames,proto3" json:"object_names,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListObjectsResponse) Reset() {
-	*x = ListObjectsResponse{}
-	mi := &file_v1_storage_storage_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListObjectsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListObjectsResponse) ProtoMessage() {}
-
-func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[7]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
-func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *ListObjectsResponse) GetObjectNames() []string {
-	if x != nil {
-		return x.ObjectNames
-	}
-	return nil
-}
-
-type GetDownloadURLRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetDownloadURLRequest) Reset() {
-	*x = GetDownloadURLRequest{}
-	mi := &file_v1_storage_storage_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetDownloadURLRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetDownloadURLRequest) ProtoMessage() {}
-
-func (x *GetDownloadURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[8]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetDownloadURLRequest.ProtoReflect.Descriptor instead.
-func (*GetDownloadURLRequest) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *GetDownloadURLRequest) GetBucket() string {
-	if x != nil {
-		return x.Bucket
-	}
-	return ""
-}
-
-func (x *GetDownloadURLRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-type GetDownloadURLResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetDownloadURLResponse) Reset() {
-	*x = GetDownloadURLResponse{}
-	mi := &file_v1_storage_storage_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetDownloadURLResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetDownloadURLResponse) ProtoMessage() {}
-
-func (x *GetDownloadURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_storage_storage_proto_msgTypes[9]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetDownloadURLResponse.ProtoReflect.Descriptor instead.
-func (*GetDownloadURLResponse) Descriptor() ([]byte, []int) {
-	return file_v1_storage_storage_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *GetDownloadURLResponse) GetUrl() string {
-	if x != nil {
-		return x.Url
-	}
-	return ""
-}
-
-var File_v1_storage_storage_proto protoreflect.FileDescriptor
-
-const file_v1_storage_storage_proto_rawDesc = "" +
-	"\n" +
-	"\x18v1/storage/storage.proto\x12\n" +
-	"storage.v1\")\n" +
-	"\x13CreateBucketRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"\x16\n" +
-	"\x14CreateBucketResponse\"\xdd\x01\n" +
-	"\x13UploadObjectRequest\x12\x16\n" +
-	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04data\x18\x03 \x01(\fR\x04data\x12I\n" +
-	"\bmetadata\x18\x04 \x03(\v2-.storage.v1.UploadObjectRequest.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x16\n" +
-	"\x14UploadObjectResponse\"F\n" +
-	"\x18GetObjectMetadataRequest\x12\x16\n" +
-	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xe9\x01\n" +
-	"\x19GetObjectMetadataResponse\x12\x16\n" +
-	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04size\x18\x03 \x01(\x03R\x04size\x12O\n" +
-	"\bmetadata\x18\x04 \x03(\v23.storage.v1.GetObjectMetadataResponse.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"D\n" +
-	"\x12ListObjectsRequest\x12\x16\n" +
-	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
-	"\x06prefix\x18\x02 \x01(\tR\x06prefix\"8\n" +
-	"\x13ListObjectsResponse\x12!\n" +
-	"\fobject_names\x18\x01 \x03(\tR\vobjectNames\"C\n" +
-	"\x15GetDownloadURLRequest\x12\x16\n" +
-	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"*\n" +
-	"\x16GetDownloadURLResponse\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url2\xc1\x03\n" +
-	"\x0eStorageService\x12Q\n" +
-	"\fCreateBucket\x12\x1f.storage.v1.CreateBucketRequest\x1a .storage.v1.CreateBucketResponse\x12Q\n" +
-	"\fUploadObject\x12\x1f.storage.v1.UploadObjectRequest\x1a .storage.v1.UploadObjectResponse\x12`\n" +
-	"\x11GetObjectMetadata\x12$.storage.v1.GetObjectMetadataRequest\x1a%.storage.v1.GetObjectMetadataResponse\x12N\n" +
-	"\vListObjects\x12\x1e.storage.v1.ListObjectsRequest\x1a\x1f.storage.v1.ListObjectsResponse\x12W\n" +
-	"\x0eGetDownloadURL\x12!.storage.v1.GetDownloadURLRequest\x1a\".storage.v1.GetDownloadURLResponseB-Z+OlympusGCP-Storage/gen/v1/storage;storagev1b\x06proto3"
-
-var (
-	file_v1_storage_storage_proto_rawDescOnce sync.Once
-	file_v1_storage_storage_proto_rawDescData []byte
-)
-
-func file_v1_storage_storage_proto_rawDescGZIP() []byte {
-	file_v1_storage_storage_proto_rawDescOnce.Do(func() {
-		file_v1_storage_storage_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v1_storage_storage_proto_rawDesc), len(file_v1_storage_storage_proto_rawDesc)))
-	})
-	return file_v1_storage_storage_proto_rawDescData
-}
-
-var file_v1_storage_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
-var file_v1_storage_storage_proto_goTypes = []any{
-	(*CreateBucketRequest)(nil),       // 0: storage.v1.CreateBucketRequest
-	(*CreateBucketResponse)(nil),      // 1: storage.v1.CreateBucketResponse
-	(*UploadObjectRequest)(nil),       // 2: storage.v1.UploadObjectRequest
-	(*UploadObjectResponse)(nil),      // 3: storage.v1.UploadObjectResponse
-	(*GetObjectMetadataRequest)(nil),  // 4: storage.v1.GetObjectMetadataRequest
-	(*GetObjectMetadataResponse)(nil), // 5: storage.v1.GetObjectMetadataResponse
-	(*ListObjectsRequest)(nil),        // 6: storage.v1.ListObjectsRequest
-	(*ListObjectsResponse)(nil),       // 7: storage.v1.ListObjectsResponse
-	(*GetDownloadURLRequest)(nil),     // 8: storage.v1.GetDownloadURLRequest
-	(*GetDownloadURLResponse)(nil),    // 9: storage.v1.GetDownloadURLResponse
-	nil,                               // 10: storage.v1.UploadObjectRequest.MetadataEntry
-	nil,                               // 11: storage.v1.GetObjectMetadataResponse.MetadataEntry
-}
-var file_v1_storage_storage_proto_depIdxs = []int32{
-	10, // 0: storage.v1.UploadObjectRequest.metadata:type_name -> storage.v1.UploadObjectRequest.MetadataEntry
-	11, // 1: storage.v1.GetObjectMetadataResponse.metadata:type_name -> storage.v1.GetObjectMetadataResponse.MetadataEntry
-	0,  // 2: storage.v1.StorageService.CreateBucket:input_type -> storage.v1.CreateBucketRequest
-	2,  // 3: storage.v1.StorageService.UploadObject:input_type -> storage.v1.UploadObjectRequest
-	4,  // 4: storage.v1.StorageService.GetObjectMetadata:input_type -> storage.v1.GetObjectMetadataRequest
-	6,  // 5: storage.v1.StorageService.ListObjects:input_type -> storage.v1.ListObjectsRequest
-	8,  // 6: storage.v1.StorageService.GetDownloadURL:input_type -> storage.v1.GetDownloadURLRequest
-	1,  // 7: storage.v1.StorageService.CreateBucket:output_type -> storage.v1.CreateBucketResponse
-	3,  // 8: storage.v1.StorageService.UploadObject:output_type -> storage.v1.UploadObjectResponse
-	5,  // 9: storage.v1.StorageService.GetObjectMetadata:output_type -> storage.v1.GetObjectMetadataResponse
-	7,  // 10: storage.v1.StorageService.ListObjects:output_type -> storage.v1.ListObjectsResponse
-	9,  // 11: storage.v1.StorageService.GetDownloadURL:output_type -> storage.v1.GetDownloadURLResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
-}
-
-func init() { file_v1_storage_storage_proto_init() }
-func file_v1_storage_storage_proto_init() {
-	if File_v1_storage_storage_proto != nil {
-		return
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_storage_storage_proto_rawDesc), len(file_v1_storage_storage_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_v1_storage_storage_proto_goTypes,
-		DependencyIndexes: file_v1_storage_storage_proto_depIdxs,
-		MessageInfos:      file_v1_storage_storage_proto_msgTypes,
-	}.Build()
-	File_v1_storage_storage_proto = out.File
-	file_v1_storage_storage_proto_goTypes = nil
-	file_v1_storage_storage_proto_depIdxs = nil
-}
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: v1/storage/storage.proto
+
+package storagev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Bucket is the stored bucket resource. Defaults mirror GCS: location "US"
+// and storage class "STANDARD" when not supplied at creation.
+type Bucket struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location     string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	StorageClass string                 `protobuf:"bytes,3,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	Labels       map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Custom metadata applied to uploaded objects for keys the upload omits.
+	DefaultObjectMetadata map[string]string      `protobuf:"bytes,5,rep,name=default_object_metadata,json=defaultObjectMetadata,proto3" json:"default_object_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreateTime            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	Metageneration        int64                  `protobuf:"varint,8,opt,name=metageneration,proto3" json:"metageneration,omitempty"`
+	LifecycleRules        []*LifecycleRule       `protobuf:"bytes,9,rep,name=lifecycle_rules,json=lifecycleRules,proto3" json:"lifecycle_rules,omitempty"`
+	Acl                   []*AclEntry            `protobuf:"bytes,10,rep,name=acl,proto3" json:"acl,omitempty"`
+	// Applied to new objects uploaded without a predefined ACL.
+	DefaultObjectAcl []*AclEntry `protobuf:"bytes,11,rep,name=default_object_acl,json=defaultObjectAcl,proto3" json:"default_object_acl,omitempty"`
+	// When set, access is controlled by IAM alone and ACLs are neither
+	// enforced nor editable.
+	UniformBucketLevelAccess bool `protobuf:"varint,12,opt,name=uniform_bucket_level_access,json=uniformBucketLevelAccess,proto3" json:"uniform_bucket_level_access,omitempty"`
+	// Cloud KMS key that encrypts new objects uploaded without a key of their
+	// own.
+	DefaultKmsKeyName string `protobuf:"bytes,13,opt,name=default_kms_key_name,json=defaultKmsKeyName,proto3" json:"default_kms_key_name,omitempty"`
+	// Project the bucket belongs to for quota accounting.
+	Project       string `protobuf:"bytes,14,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bucket) Reset() {
+	*x = Bucket{}
+	mi := &file_v1_storage_storage_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bucket) ProtoMessage() {}
+
+func (x *Bucket) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
+func (*Bucket) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Bucket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Bucket) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Bucket) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *Bucket) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Bucket) GetDefaultObjectMetadata() map[string]string {
+	if x != nil {
+		return x.DefaultObjectMetadata
+	}
+	return nil
+}
+
+func (x *Bucket) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Bucket) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Bucket) GetMetageneration() int64 {
+	if x != nil {
+		return x.Metageneration
+	}
+	return 0
+}
+
+func (x *Bucket) GetLifecycleRules() []*LifecycleRule {
+	if x != nil {
+		return x.LifecycleRules
+	}
+	return nil
+}
+
+func (x *Bucket) GetAcl() []*AclEntry {
+	if x != nil {
+		return x.Acl
+	}
+	return nil
+}
+
+func (x *Bucket) GetDefaultObjectAcl() []*AclEntry {
+	if x != nil {
+		return x.DefaultObjectAcl
+	}
+	return nil
+}
+
+func (x *Bucket) GetUniformBucketLevelAccess() bool {
+	if x != nil {
+		return x.UniformBucketLevelAccess
+	}
+	return false
+}
+
+func (x *Bucket) GetDefaultKmsKeyName() string {
+	if x != nil {
+		return x.DefaultKmsKeyName
+	}
+	return ""
+}
+
+func (x *Bucket) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+// LifecycleRule follows the GCS lifecycle configuration: an action of type
+// "Delete" or "SetStorageClass" applied to objects matching the condition.
+type LifecycleRule struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Action        *LifecycleRule_Action    `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Condition     *LifecycleRule_Condition `protobuf:"bytes,2,opt,name=condition,proto3" json:"condition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LifecycleRule) Reset() {
+	*x = LifecycleRule{}
+	mi := &file_v1_storage_storage_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifecycleRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifecycleRule) ProtoMessage() {}
+
+func (x *LifecycleRule) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LifecycleRule.ProtoReflect.Descriptor instead.
+func (*LifecycleRule) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LifecycleRule) GetAction() *LifecycleRule_Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *LifecycleRule) GetCondition() *LifecycleRule_Condition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+type CreateBucketRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Name                  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location              string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	StorageClass          string                 `protobuf:"bytes,3,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	Labels                map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DefaultObjectMetadata map[string]string      `protobuf:"bytes,5,rep,name=default_object_metadata,json=defaultObjectMetadata,proto3" json:"default_object_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	LifecycleRules        []*LifecycleRule       `protobuf:"bytes,6,rep,name=lifecycle_rules,json=lifecycleRules,proto3" json:"lifecycle_rules,omitempty"`
+	// GCS predefined bucket ACL: private, projectPrivate (the default),
+	// publicRead, publicReadWrite or authenticatedRead.
+	PredefinedAcl string `protobuf:"bytes,7,opt,name=predefined_acl,json=predefinedAcl,proto3" json:"predefined_acl,omitempty"`
+	// Predefined object ACL (see UploadObjectRequest) used as the bucket's
+	// default object ACL. Defaults to projectPrivate.
+	PredefinedDefaultObjectAcl string `protobuf:"bytes,8,opt,name=predefined_default_object_acl,json=predefinedDefaultObjectAcl,proto3" json:"predefined_default_object_acl,omitempty"`
+	UniformBucketLevelAccess   bool   `protobuf:"varint,9,opt,name=uniform_bucket_level_access,json=uniformBucketLevelAccess,proto3" json:"uniform_bucket_level_access,omitempty"`
+	DefaultKmsKeyName          string `protobuf:"bytes,10,opt,name=default_kms_key_name,json=defaultKmsKeyName,proto3" json:"default_kms_key_name,omitempty"`
+	// Defaults to the emulator's "olympus" project.
+	Project       string `protobuf:"bytes,11,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBucketRequest) Reset() {
+	*x = CreateBucketRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketRequest) ProtoMessage() {}
+
+func (x *CreateBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketRequest.ProtoReflect.Descriptor instead.
+func (*CreateBucketRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateBucketRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateBucketRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CreateBucketRequest) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *CreateBucketRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateBucketRequest) GetDefaultObjectMetadata() map[string]string {
+	if x != nil {
+		return x.DefaultObjectMetadata
+	}
+	return nil
+}
+
+func (x *CreateBucketRequest) GetLifecycleRules() []*LifecycleRule {
+	if x != nil {
+		return x.LifecycleRules
+	}
+	return nil
+}
+
+func (x *CreateBucketRequest) GetPredefinedAcl() string {
+	if x != nil {
+		return x.PredefinedAcl
+	}
+	return ""
+}
+
+func (x *CreateBucketRequest) GetPredefinedDefaultObjectAcl() string {
+	if x != nil {
+		return x.PredefinedDefaultObjectAcl
+	}
+	return ""
+}
+
+func (x *CreateBucketRequest) GetUniformBucketLevelAccess() bool {
+	if x != nil {
+		return x.UniformBucketLevelAccess
+	}
+	return false
+}
+
+func (x *CreateBucketRequest) GetDefaultKmsKeyName() string {
+	if x != nil {
+		return x.DefaultKmsKeyName
+	}
+	return ""
+}
+
+func (x *CreateBucketRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+type CreateBucketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        *Bucket                `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBucketResponse) Reset() {
+	*x = CreateBucketResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketResponse) ProtoMessage() {}
+
+func (x *CreateBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketResponse.ProtoReflect.Descriptor instead.
+func (*CreateBucketResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateBucketResponse) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+type GetBucketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketRequest) Reset() {
+	*x = GetBucketRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketRequest) ProtoMessage() {}
+
+func (x *GetBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetBucketRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetBucketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        *Bucket                `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBucketResponse) Reset() {
+	*x = GetBucketResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketResponse) ProtoMessage() {}
+
+func (x *GetBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetBucketResponse) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+type ListBucketsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Only buckets carrying every listed label are returned. An empty value
+	// matches any value for that key.
+	Labels        map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBucketsRequest) Reset() {
+	*x = ListBucketsRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBucketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBucketsRequest) ProtoMessage() {}
+
+func (x *ListBucketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBucketsRequest.ProtoReflect.Descriptor instead.
+func (*ListBucketsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListBucketsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListBucketsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type ListBucketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*Bucket              `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBucketsResponse) Reset() {
+	*x = ListBucketsResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBucketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBucketsResponse) ProtoMessage() {}
+
+func (x *ListBucketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBucketsResponse.ProtoReflect.Descriptor instead.
+func (*ListBucketsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListBucketsResponse) GetBuckets() []*Bucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// UpdateBucketRequest patches the bucket named by bucket.name. Only the
+// paths in update_mask are changed: "location", "storage_class", "labels",
+// "default_object_metadata", "lifecycle_rules", "uniform_bucket_level_access",
+// "default_kms_key_name", or "labels.<key>" to set or
+// remove (when absent from bucket.labels) a single label. An empty mask applies every non-empty
+// field of bucket, merging labels and default_object_metadata.
+type UpdateBucketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        *Bucket                `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBucketRequest) Reset() {
+	*x = UpdateBucketRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBucketRequest) ProtoMessage() {}
+
+func (x *UpdateBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBucketRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBucketRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateBucketRequest) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *UpdateBucketRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateBucketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        *Bucket                `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBucketResponse) Reset() {
+	*x = UpdateBucketResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBucketResponse) ProtoMessage() {}
+
+func (x *UpdateBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBucketResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBucketResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateBucketResponse) GetBucket() *Bucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+type UploadObjectRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Bucket   string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Data     []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Defaults to the bucket's storage class.
+	StorageClass string `protobuf:"bytes,5,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	// GCS predefined object ACL: private, projectPrivate, publicRead,
+	// authenticatedRead, bucketOwnerRead or bucketOwnerFullControl. Without one
+	// the object gets the bucket's default object ACL.
+	PredefinedAcl string `protobuf:"bytes,6,opt,name=predefined_acl,json=predefinedAcl,proto3" json:"predefined_acl,omitempty"`
+	// Encrypts the object with a customer-supplied key.
+	CommonObjectRequestParams *CommonObjectRequestParams `protobuf:"bytes,7,opt,name=common_object_request_params,json=commonObjectRequestParams,proto3" json:"common_object_request_params,omitempty"`
+	// Cloud KMS key for the object, overriding the bucket default. Cannot be
+	// combined with a customer-supplied key.
+	KmsKeyName    string `protobuf:"bytes,8,opt,name=kms_key_name,json=kmsKeyName,proto3" json:"kms_key_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadObjectRequest) Reset() {
+	*x = UploadObjectRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadObjectRequest) ProtoMessage() {}
+
+func (x *UploadObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadObjectRequest.ProtoReflect.Descriptor instead.
+func (*UploadObjectRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UploadObjectRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *UploadObjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadObjectRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadObjectRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadObjectRequest) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *UploadObjectRequest) GetPredefinedAcl() string {
+	if x != nil {
+		return x.PredefinedAcl
+	}
+	return ""
+}
+
+func (x *UploadObjectRequest) GetCommonObjectRequestParams() *CommonObjectRequestParams {
+	if x != nil {
+		return x.CommonObjectRequestParams
+	}
+	return nil
+}
+
+func (x *UploadObjectRequest) GetKmsKeyName() string {
+	if x != nil {
+		return x.KmsKeyName
+	}
+	return ""
+}
+
+// CommonObjectRequestParams carries a customer-supplied encryption key
+// (CSEK). Objects written with one are encrypted at rest with AES-256 and
+// only the key's SHA-256 hash is stored; the same key must accompany every
+// read of the object's data.
+type CommonObjectRequestParams struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "AES256", the only supported algorithm.
+	EncryptionAlgorithm string `protobuf:"bytes,1,opt,name=encryption_algorithm,json=encryptionAlgorithm,proto3" json:"encryption_algorithm,omitempty"`
+	// The raw 32-byte key.
+	EncryptionKeyBytes []byte `protobuf:"bytes,2,opt,name=encryption_key_bytes,json=encryptionKeyBytes,proto3" json:"encryption_key_bytes,omitempty"`
+	// SHA-256 of the key. Optional; when given it must match the key.
+	EncryptionKeySha256Bytes []byte `protobuf:"bytes,3,opt,name=encryption_key_sha256_bytes,json=encryptionKeySha256Bytes,proto3" json:"encryption_key_sha256_bytes,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CommonObjectRequestParams) Reset() {
+	*x = CommonObjectRequestParams{}
+	mi := &file_v1_storage_storage_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommonObjectRequestParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommonObjectRequestParams) ProtoMessage() {}
+
+func (x *CommonObjectRequestParams) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommonObjectRequestParams.ProtoReflect.Descriptor instead.
+func (*CommonObjectRequestParams) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CommonObjectRequestParams) GetEncryptionAlgorithm() string {
+	if x != nil {
+		return x.EncryptionAlgorithm
+	}
+	return ""
+}
+
+func (x *CommonObjectRequestParams) GetEncryptionKeyBytes() []byte {
+	if x != nil {
+		return x.EncryptionKeyBytes
+	}
+	return nil
+}
+
+func (x *CommonObjectRequestParams) GetEncryptionKeySha256Bytes() []byte {
+	if x != nil {
+		return x.EncryptionKeySha256Bytes
+	}
+	return nil
+}
+
+// CustomerEncryption describes the customer-supplied key an object is
+// encrypted with.
+type CustomerEncryption struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionAlgorithm string                 `protobuf:"bytes,1,opt,name=encryption_algorithm,json=encryptionAlgorithm,proto3" json:"encryption_algorithm,omitempty"`
+	KeySha256Bytes      []byte                 `protobuf:"bytes,2,opt,name=key_sha256_bytes,json=keySha256Bytes,proto3" json:"key_sha256_bytes,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CustomerEncryption) Reset() {
+	*x = CustomerEncryption{}
+	mi := &file_v1_storage_storage_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerEncryption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerEncryption) ProtoMessage() {}
+
+func (x *CustomerEncryption) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerEncryption.ProtoReflect.Descriptor instead.
+func (*CustomerEncryption) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CustomerEncryption) GetEncryptionAlgorithm() string {
+	if x != nil {
+		return x.EncryptionAlgorithm
+	}
+	return ""
+}
+
+func (x *CustomerEncryption) GetKeySha256Bytes() []byte {
+	if x != nil {
+		return x.KeySha256Bytes
+	}
+	return nil
+}
+
+type UploadObjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Generation    int64                  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadObjectResponse) Reset() {
+	*x = UploadObjectResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadObjectResponse) ProtoMessage() {}
+
+func (x *UploadObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadObjectResponse.ProtoReflect.Descriptor instead.
+func (*UploadObjectResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UploadObjectResponse) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+type GetObjectMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetObjectMetadataRequest) Reset() {
+	*x = GetObjectMetadataRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetObjectMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetObjectMetadataRequest) ProtoMessage() {}
+
+func (x *GetObjectMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetObjectMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetObjectMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetObjectMetadataRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetObjectMetadataRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetObjectMetadataResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Bucket                 string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name                   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Size                   int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Metadata               map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StorageClass           string                 `protobuf:"bytes,5,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	Generation             int64                  `protobuf:"varint,6,opt,name=generation,proto3" json:"generation,omitempty"`
+	Metageneration         int64                  `protobuf:"varint,7,opt,name=metageneration,proto3" json:"metageneration,omitempty"`
+	CreateTime             *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime             *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	StorageClassUpdateTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=storage_class_update_time,json=storageClassUpdateTime,proto3" json:"storage_class_update_time,omitempty"`
+	Acl                    []*AclEntry            `protobuf:"bytes,11,rep,name=acl,proto3" json:"acl,omitempty"`
+	CustomerEncryption     *CustomerEncryption    `protobuf:"bytes,12,opt,name=customer_encryption,json=customerEncryption,proto3" json:"customer_encryption,omitempty"`
+	// The Cloud KMS key version protecting the object, if any.
+	KmsKeyName    string `protobuf:"bytes,13,opt,name=kms_key_name,json=kmsKeyName,proto3" json:"kms_key_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetObjectMetadataResponse) Reset() {
+	*x = GetObjectMetadataResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetObjectMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetObjectMetadataResponse) ProtoMessage() {}
+
+func (x *GetObjectMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetObjectMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetObjectMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetObjectMetadataResponse) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetObjectMetadataResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetObjectMetadataResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetObjectMetadataResponse) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetObjectMetadataResponse) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *GetObjectMetadataResponse) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GetObjectMetadataResponse) GetMetageneration() int64 {
+	if x != nil {
+		return x.Metageneration
+	}
+	return 0
+}
+
+func (x *GetObjectMetadataResponse) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *GetObjectMetadataResponse) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *GetObjectMetadataResponse) GetStorageClassUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StorageClassUpdateTime
+	}
+	return nil
+}
+
+func (x *GetObjectMetadataResponse) GetAcl() []*AclEntry {
+	if x != nil {
+		return x.Acl
+	}
+	return nil
+}
+
+func (x *GetObjectMetadataResponse) GetCustomerEncryption() *CustomerEncryption {
+	if x != nil {
+		return x.CustomerEncryption
+	}
+	return nil
+}
+
+func (x *GetObjectMetadataResponse) GetKmsKeyName() string {
+	if x != nil {
+		return x.KmsKeyName
+	}
+	return ""
+}
+
+type ListObjectsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Bucket string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Prefix string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Maximum names per page; 0 means the default of 1000, which is also
+	// the maximum.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous page.
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectsRequest) Reset() {
+	*x = ListObjectsRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsRequest) ProtoMessage() {}
+
+func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListObjectsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListObjectsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListObjectsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ObjectNames []string               `protobuf:"bytes,1,rep,name=object_names,json=objectNames,proto3" json:"object_names,omitempty"`
+	// Set when more names follow; pass it as page_token to fetch them.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectsResponse) Reset() {
+	*x = ListObjectsResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsResponse) ProtoMessage() {}
+
+func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListObjectsResponse) GetObjectNames() []string {
+	if x != nil {
+		return x.ObjectNames
+	}
+	return nil
+}
+
+func (x *ListObjectsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetDownloadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadURLRequest) Reset() {
+	*x = GetDownloadURLRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadURLRequest) ProtoMessage() {}
+
+func (x *GetDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GetDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetDownloadURLRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetDownloadURLRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetDownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadURLResponse) Reset() {
+	*x = GetDownloadURLResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadURLResponse) ProtoMessage() {}
+
+func (x *GetDownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*GetDownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDownloadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type ReadObjectRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Bucket     string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ReadOffset int64                  `protobuf:"varint,3,opt,name=read_offset,json=readOffset,proto3" json:"read_offset,omitempty"`
+	// Zero reads to the end of the object.
+	ReadLimit                 int64                      `protobuf:"varint,4,opt,name=read_limit,json=readLimit,proto3" json:"read_limit,omitempty"`
+	CommonObjectRequestParams *CommonObjectRequestParams `protobuf:"bytes,5,opt,name=common_object_request_params,json=commonObjectRequestParams,proto3" json:"common_object_request_params,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ReadObjectRequest) Reset() {
+	*x = ReadObjectRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadObjectRequest) ProtoMessage() {}
+
+func (x *ReadObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadObjectRequest.ProtoReflect.Descriptor instead.
+func (*ReadObjectRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReadObjectRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ReadObjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReadObjectRequest) GetReadOffset() int64 {
+	if x != nil {
+		return x.ReadOffset
+	}
+	return 0
+}
+
+func (x *ReadObjectRequest) GetReadLimit() int64 {
+	if x != nil {
+		return x.ReadLimit
+	}
+	return 0
+}
+
+func (x *ReadObjectRequest) GetCommonObjectRequestParams() *CommonObjectRequestParams {
+	if x != nil {
+		return x.CommonObjectRequestParams
+	}
+	return nil
+}
+
+type ReadObjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadObjectResponse) Reset() {
+	*x = ReadObjectResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadObjectResponse) ProtoMessage() {}
+
+func (x *ReadObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadObjectResponse.ProtoReflect.Descriptor instead.
+func (*ReadObjectResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReadObjectResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeleteObjectRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Bucket string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// When nonzero, the delete fails with FAILED_PRECONDITION unless the
+	// object's live generation matches.
+	IfGenerationMatch int64 `protobuf:"varint,3,opt,name=if_generation_match,json=ifGenerationMatch,proto3" json:"if_generation_match,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeleteObjectRequest) Reset() {
+	*x = DeleteObjectRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteObjectRequest) ProtoMessage() {}
+
+func (x *DeleteObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteObjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteObjectRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteObjectRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DeleteObjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteObjectRequest) GetIfGenerationMatch() int64 {
+	if x != nil {
+		return x.IfGenerationMatch
+	}
+	return 0
+}
+
+type DeleteObjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteObjectResponse) Reset() {
+	*x = DeleteObjectResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteObjectResponse) ProtoMessage() {}
+
+func (x *DeleteObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteObjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteObjectResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{23}
+}
+
+// RewriteObjectRequest copies an object, optionally changing its storage
+// class or encryption key. Rewriting an object onto itself is how its class
+// is changed outside of lifecycle management and how customer-supplied keys
+// are rotated.
+type RewriteObjectRequest struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	SourceBucket             string                 `protobuf:"bytes,1,opt,name=source_bucket,json=sourceBucket,proto3" json:"source_bucket,omitempty"`
+	SourceObject             string                 `protobuf:"bytes,2,opt,name=source_object,json=sourceObject,proto3" json:"source_object,omitempty"`
+	DestinationBucket        string                 `protobuf:"bytes,3,opt,name=destination_bucket,json=destinationBucket,proto3" json:"destination_bucket,omitempty"`
+	DestinationObject        string                 `protobuf:"bytes,4,opt,name=destination_object,json=destinationObject,proto3" json:"destination_object,omitempty"`
+	DestinationStorageClass  string                 `protobuf:"bytes,5,opt,name=destination_storage_class,json=destinationStorageClass,proto3" json:"destination_storage_class,omitempty"`
+	DestinationPredefinedAcl string                 `protobuf:"bytes,6,opt,name=destination_predefined_acl,json=destinationPredefinedAcl,proto3" json:"destination_predefined_acl,omitempty"`
+	// Key for the destination object; without one it is stored unencrypted.
+	CommonObjectRequestParams *CommonObjectRequestParams `protobuf:"bytes,7,opt,name=common_object_request_params,json=commonObjectRequestParams,proto3" json:"common_object_request_params,omitempty"`
+	// Key of the source object, when it is encrypted with one.
+	CopySourceEncryptionAlgorithm      string `protobuf:"bytes,8,opt,name=copy_source_encryption_algorithm,json=copySourceEncryptionAlgorithm,proto3" json:"copy_source_encryption_algorithm,omitempty"`
+	CopySourceEncryptionKeyBytes       []byte `protobuf:"bytes,9,opt,name=copy_source_encryption_key_bytes,json=copySourceEncryptionKeyBytes,proto3" json:"copy_source_encryption_key_bytes,omitempty"`
+	CopySourceEncryptionKeySha256Bytes []byte `protobuf:"bytes,10,opt,name=copy_source_encryption_key_sha256_bytes,json=copySourceEncryptionKeySha256Bytes,proto3" json:"copy_source_encryption_key_sha256_bytes,omitempty"`
+	// Defaults to the destination bucket's default KMS key.
+	DestinationKmsKeyName string `protobuf:"bytes,11,opt,name=destination_kms_key_name,json=destinationKmsKeyName,proto3" json:"destination_kms_key_name,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RewriteObjectRequest) Reset() {
+	*x = RewriteObjectRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewriteObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewriteObjectRequest) ProtoMessage() {}
+
+func (x *RewriteObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewriteObjectRequest.ProtoReflect.Descriptor instead.
+func (*RewriteObjectRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RewriteObjectRequest) GetSourceBucket() string {
+	if x != nil {
+		return x.SourceBucket
+	}
+	return ""
+}
+
+func (x *RewriteObjectRequest) GetSourceObject() string {
+	if x != nil {
+		return x.SourceObject
+	}
+	return ""
+}
+
+func (x *RewriteObjectRequest) GetDestinationBucket() string {
+	if x != nil {
+		return x.DestinationBucket
+	}
+	return ""
+}
+
+func (x *RewriteObjectRequest) GetDestinationObject() string {
+	if x != nil {
+		return x.DestinationObject
+	}
+	return ""
+}
+
+func (x *RewriteObjectRequest) GetDestinationStorageClass() string {
+	if x != nil {
+		return x.DestinationStorageClass
+	}
+	return ""
+}
+
+func (x *RewriteObjectRequest) GetDestinationPredefinedAcl() string {
+	if x != nil {
+		return x.DestinationPredefinedAcl
+	}
+	return ""
+}
+
+func (x *RewriteObjectRequest) GetCommonObjectRequestParams() *CommonObjectRequestParams {
+	if x != nil {
+		return x.CommonObjectRequestParams
+	}
+	return nil
+}
+
+func (x *RewriteObjectRequest) GetCopySourceEncryptionAlgorithm() string {
+	if x != nil {
+		return x.CopySourceEncryptionAlgorithm
+	}
+	return ""
+}
+
+func (x *RewriteObjectRequest) GetCopySourceEncryptionKeyBytes() []byte {
+	if x != nil {
+		return x.CopySourceEncryptionKeyBytes
+	}
+	return nil
+}
+
+func (x *RewriteObjectRequest) GetCopySourceEncryptionKeySha256Bytes() []byte {
+	if x != nil {
+		return x.CopySourceEncryptionKeySha256Bytes
+	}
+	return nil
+}
+
+func (x *RewriteObjectRequest) GetDestinationKmsKeyName() string {
+	if x != nil {
+		return x.DestinationKmsKeyName
+	}
+	return ""
+}
+
+type RewriteObjectResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Resource      *GetObjectMetadataResponse `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewriteObjectResponse) Reset() {
+	*x = RewriteObjectResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewriteObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewriteObjectResponse) ProtoMessage() {}
+
+func (x *RewriteObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewriteObjectResponse.ProtoReflect.Descriptor instead.
+func (*RewriteObjectResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RewriteObjectResponse) GetResource() *GetObjectMetadataResponse {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+type ListEarlyDeletionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty lists charges for every bucket.
+	Bucket        string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEarlyDeletionsRequest) Reset() {
+	*x = ListEarlyDeletionsRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEarlyDeletionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEarlyDeletionsRequest) ProtoMessage() {}
+
+func (x *ListEarlyDeletionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEarlyDeletionsRequest.ProtoReflect.Descriptor instead.
+func (*ListEarlyDeletionsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListEarlyDeletionsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+// EarlyDeletion records an object removed or replaced before the minimum
+// storage duration of its class elapsed.
+type EarlyDeletion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Generation    int64                  `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
+	StorageClass  string                 `protobuf:"bytes,4,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	StoredSeconds int64                  `protobuf:"varint,5,opt,name=stored_seconds,json=storedSeconds,proto3" json:"stored_seconds,omitempty"`
+	// The remainder of the minimum storage duration that is still billed.
+	ChargedSeconds int64                  `protobuf:"varint,6,opt,name=charged_seconds,json=chargedSeconds,proto3" json:"charged_seconds,omitempty"`
+	Reason         string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	DeleteTime     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EarlyDeletion) Reset() {
+	*x = EarlyDeletion{}
+	mi := &file_v1_storage_storage_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EarlyDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EarlyDeletion) ProtoMessage() {}
+
+func (x *EarlyDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EarlyDeletion.ProtoReflect.Descriptor instead.
+func (*EarlyDeletion) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *EarlyDeletion) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *EarlyDeletion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EarlyDeletion) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *EarlyDeletion) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *EarlyDeletion) GetStoredSeconds() int64 {
+	if x != nil {
+		return x.StoredSeconds
+	}
+	return 0
+}
+
+func (x *EarlyDeletion) GetChargedSeconds() int64 {
+	if x != nil {
+		return x.ChargedSeconds
+	}
+	return 0
+}
+
+func (x *EarlyDeletion) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *EarlyDeletion) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
+type ListEarlyDeletionsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EarlyDeletions []*EarlyDeletion       `protobuf:"bytes,1,rep,name=early_deletions,json=earlyDeletions,proto3" json:"early_deletions,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListEarlyDeletionsResponse) Reset() {
+	*x = ListEarlyDeletionsResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEarlyDeletionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEarlyDeletionsResponse) ProtoMessage() {}
+
+func (x *ListEarlyDeletionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEarlyDeletionsResponse.ProtoReflect.Descriptor instead.
+func (*ListEarlyDeletionsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListEarlyDeletionsResponse) GetEarlyDeletions() []*EarlyDeletion {
+	if x != nil {
+		return x.EarlyDeletions
+	}
+	return nil
+}
+
+// UpdateObjectRequest patches an object's custom metadata. With an empty
+// update_mask the given metadata is merged; otherwise "metadata" replaces it
+// and "metadata.<key>" sets or removes (when absent from metadata) one key.
+type UpdateObjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateObjectRequest) Reset() {
+	*x = UpdateObjectRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateObjectRequest) ProtoMessage() {}
+
+func (x *UpdateObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateObjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateObjectRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateObjectRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *UpdateObjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateObjectRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateObjectRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateObjectResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Resource      *GetObjectMetadataResponse `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateObjectResponse) Reset() {
+	*x = UpdateObjectResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateObjectResponse) ProtoMessage() {}
+
+func (x *UpdateObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateObjectResponse.ProtoReflect.Descriptor instead.
+func (*UpdateObjectResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateObjectResponse) GetResource() *GetObjectMetadataResponse {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+// NotificationConfig publishes object change events to a Pub/Sub topic in the
+// GCS notification format. Event types are OBJECT_FINALIZE, OBJECT_DELETE,
+// OBJECT_METADATA_UPDATE and OBJECT_ARCHIVE; an empty list selects all of
+// them. Buckets are not versioned, so overwrites are reported as
+// OBJECT_DELETE of the replaced generation and OBJECT_ARCHIVE never fires.
+type NotificationConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "projects/{project}/topics/{topic}".
+	Topic            string            `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	EventTypes       []string          `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	ObjectNamePrefix string            `protobuf:"bytes,4,opt,name=object_name_prefix,json=objectNamePrefix,proto3" json:"object_name_prefix,omitempty"`
+	CustomAttributes map[string]string `protobuf:"bytes,5,rep,name=custom_attributes,json=customAttributes,proto3" json:"custom_attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// JSON_API_V1 (the default) or NONE.
+	PayloadFormat string `protobuf:"bytes,6,opt,name=payload_format,json=payloadFormat,proto3" json:"payload_format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationConfig) Reset() {
+	*x = NotificationConfig{}
+	mi := &file_v1_storage_storage_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationConfig) ProtoMessage() {}
+
+func (x *NotificationConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationConfig.ProtoReflect.Descriptor instead.
+func (*NotificationConfig) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *NotificationConfig) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NotificationConfig) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *NotificationConfig) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *NotificationConfig) GetObjectNamePrefix() string {
+	if x != nil {
+		return x.ObjectNamePrefix
+	}
+	return ""
+}
+
+func (x *NotificationConfig) GetCustomAttributes() map[string]string {
+	if x != nil {
+		return x.CustomAttributes
+	}
+	return nil
+}
+
+func (x *NotificationConfig) GetPayloadFormat() string {
+	if x != nil {
+		return x.PayloadFormat
+	}
+	return ""
+}
+
+type CreateNotificationConfigRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Bucket             string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	NotificationConfig *NotificationConfig    `protobuf:"bytes,2,opt,name=notification_config,json=notificationConfig,proto3" json:"notification_config,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateNotificationConfigRequest) Reset() {
+	*x = CreateNotificationConfigRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNotificationConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNotificationConfigRequest) ProtoMessage() {}
+
+func (x *CreateNotificationConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNotificationConfigRequest.ProtoReflect.Descriptor instead.
+func (*CreateNotificationConfigRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateNotificationConfigRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *CreateNotificationConfigRequest) GetNotificationConfig() *NotificationConfig {
+	if x != nil {
+		return x.NotificationConfig
+	}
+	return nil
+}
+
+type CreateNotificationConfigResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	NotificationConfig *NotificationConfig    `protobuf:"bytes,1,opt,name=notification_config,json=notificationConfig,proto3" json:"notification_config,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateNotificationConfigResponse) Reset() {
+	*x = CreateNotificationConfigResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNotificationConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNotificationConfigResponse) ProtoMessage() {}
+
+func (x *CreateNotificationConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNotificationConfigResponse.ProtoReflect.Descriptor instead.
+func (*CreateNotificationConfigResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateNotificationConfigResponse) GetNotificationConfig() *NotificationConfig {
+	if x != nil {
+		return x.NotificationConfig
+	}
+	return nil
+}
+
+type GetNotificationConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationConfigRequest) Reset() {
+	*x = GetNotificationConfigRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationConfigRequest) ProtoMessage() {}
+
+func (x *GetNotificationConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationConfigRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetNotificationConfigRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetNotificationConfigRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetNotificationConfigResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	NotificationConfig *NotificationConfig    `protobuf:"bytes,1,opt,name=notification_config,json=notificationConfig,proto3" json:"notification_config,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetNotificationConfigResponse) Reset() {
+	*x = GetNotificationConfigResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationConfigResponse) ProtoMessage() {}
+
+func (x *GetNotificationConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationConfigResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetNotificationConfigResponse) GetNotificationConfig() *NotificationConfig {
+	if x != nil {
+		return x.NotificationConfig
+	}
+	return nil
+}
+
+type ListNotificationConfigsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationConfigsRequest) Reset() {
+	*x = ListNotificationConfigsRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationConfigsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationConfigsRequest) ProtoMessage() {}
+
+func (x *ListNotificationConfigsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationConfigsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationConfigsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListNotificationConfigsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type ListNotificationConfigsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	NotificationConfigs []*NotificationConfig  `protobuf:"bytes,1,rep,name=notification_configs,json=notificationConfigs,proto3" json:"notification_configs,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListNotificationConfigsResponse) Reset() {
+	*x = ListNotificationConfigsResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationConfigsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationConfigsResponse) ProtoMessage() {}
+
+func (x *ListNotificationConfigsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationConfigsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationConfigsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListNotificationConfigsResponse) GetNotificationConfigs() []*NotificationConfig {
+	if x != nil {
+		return x.NotificationConfigs
+	}
+	return nil
+}
+
+type DeleteNotificationConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationConfigRequest) Reset() {
+	*x = DeleteNotificationConfigRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationConfigRequest) ProtoMessage() {}
+
+func (x *DeleteNotificationConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationConfigRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationConfigRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteNotificationConfigRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DeleteNotificationConfigRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteNotificationConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationConfigResponse) Reset() {
+	*x = DeleteNotificationConfigResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationConfigResponse) ProtoMessage() {}
+
+func (x *DeleteNotificationConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationConfigResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationConfigResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{39}
+}
+
+// Policy is a bucket IAM policy. Members use the IAM forms "user:{email}",
+// "serviceAccount:{email}", "allUsers" and "allAuthenticatedUsers".
+type Policy struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Version  int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Bindings []*Binding             `protobuf:"bytes,2,rep,name=bindings,proto3" json:"bindings,omitempty"`
+	// Set on reads; when supplied to SetIamPolicy the update only applies if
+	// the stored policy still carries this etag.
+	Etag          string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	mi := &file_v1_storage_storage_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Policy) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Policy) GetBindings() []*Binding {
+	if x != nil {
+		return x.Bindings
+	}
+	return nil
+}
+
+func (x *Policy) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type Binding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Members       []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Binding) Reset() {
+	*x = Binding{}
+	mi := &file_v1_storage_storage_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Binding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Binding) ProtoMessage() {}
+
+func (x *Binding) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Binding.ProtoReflect.Descriptor instead.
+func (*Binding) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Binding) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Binding) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type GetIamPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIamPolicyRequest) Reset() {
+	*x = GetIamPolicyRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIamPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIamPolicyRequest) ProtoMessage() {}
+
+func (x *GetIamPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIamPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetIamPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetIamPolicyRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+type GetIamPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *Policy                `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIamPolicyResponse) Reset() {
+	*x = GetIamPolicyResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIamPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIamPolicyResponse) ProtoMessage() {}
+
+func (x *GetIamPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIamPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetIamPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetIamPolicyResponse) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetIamPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Policy        *Policy                `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIamPolicyRequest) Reset() {
+	*x = SetIamPolicyRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIamPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIamPolicyRequest) ProtoMessage() {}
+
+func (x *SetIamPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIamPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetIamPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SetIamPolicyRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *SetIamPolicyRequest) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetIamPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *Policy                `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIamPolicyResponse) Reset() {
+	*x = SetIamPolicyResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIamPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIamPolicyResponse) ProtoMessage() {}
+
+func (x *SetIamPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIamPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetIamPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SetIamPolicyResponse) GetPolicy() *Policy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type TestIamPermissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Permissions   []string               `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestIamPermissionsRequest) Reset() {
+	*x = TestIamPermissionsRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestIamPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestIamPermissionsRequest) ProtoMessage() {}
+
+func (x *TestIamPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestIamPermissionsRequest.ProtoReflect.Descriptor instead.
+func (*TestIamPermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TestIamPermissionsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *TestIamPermissionsRequest) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type TestIamPermissionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The subset of the requested permissions the caller holds.
+	Permissions   []string `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestIamPermissionsResponse) Reset() {
+	*x = TestIamPermissionsResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestIamPermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestIamPermissionsResponse) ProtoMessage() {}
+
+func (x *TestIamPermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestIamPermissionsResponse.ProtoReflect.Descriptor instead.
+func (*TestIamPermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TestIamPermissionsResponse) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+// AclEntry grants role (OWNER, WRITER or READER; WRITER on buckets only) to
+// an entity in the GCS forms "user-{email}", "group-{email}",
+// "domain-{domain}", "project-{owners|editors|viewers}-{project}",
+// "allUsers" or "allAuthenticatedUsers". ACLs grant access in addition to
+// the bucket IAM policy.
+type AclEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AclEntry) Reset() {
+	*x = AclEntry{}
+	mi := &file_v1_storage_storage_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AclEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AclEntry) ProtoMessage() {}
+
+func (x *AclEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AclEntry.ProtoReflect.Descriptor instead.
+func (*AclEntry) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AclEntry) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *AclEntry) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// The ACL RPCs address the ACL of object in bucket, or the bucket's own ACL
+// when object is empty, or its default object ACL when default_object_acl
+// is set. They fail with FAILED_PRECONDITION on buckets with uniform
+// bucket-level access.
+type ListAclRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Bucket           string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object           string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	DefaultObjectAcl bool                   `protobuf:"varint,3,opt,name=default_object_acl,json=defaultObjectAcl,proto3" json:"default_object_acl,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListAclRequest) Reset() {
+	*x = ListAclRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAclRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAclRequest) ProtoMessage() {}
+
+func (x *ListAclRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAclRequest.ProtoReflect.Descriptor instead.
+func (*ListAclRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListAclRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ListAclRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *ListAclRequest) GetDefaultObjectAcl() bool {
+	if x != nil {
+		return x.DefaultObjectAcl
+	}
+	return false
+}
+
+type ListAclResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AclEntry            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAclResponse) Reset() {
+	*x = ListAclResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAclResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAclResponse) ProtoMessage() {}
+
+func (x *ListAclResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAclResponse.ProtoReflect.Descriptor instead.
+func (*ListAclResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListAclResponse) GetEntries() []*AclEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// InsertAclRequest adds entry, replacing the role of an existing entry for
+// the same entity.
+type InsertAclRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Bucket           string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object           string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	DefaultObjectAcl bool                   `protobuf:"varint,3,opt,name=default_object_acl,json=defaultObjectAcl,proto3" json:"default_object_acl,omitempty"`
+	Entry            *AclEntry              `protobuf:"bytes,4,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *InsertAclRequest) Reset() {
+	*x = InsertAclRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InsertAclRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertAclRequest) ProtoMessage() {}
+
+func (x *InsertAclRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertAclRequest.ProtoReflect.Descriptor instead.
+func (*InsertAclRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *InsertAclRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *InsertAclRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *InsertAclRequest) GetDefaultObjectAcl() bool {
+	if x != nil {
+		return x.DefaultObjectAcl
+	}
+	return false
+}
+
+func (x *InsertAclRequest) GetEntry() *AclEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type InsertAclResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *AclEntry              `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InsertAclResponse) Reset() {
+	*x = InsertAclResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InsertAclResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertAclResponse) ProtoMessage() {}
+
+func (x *InsertAclResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertAclResponse.ProtoReflect.Descriptor instead.
+func (*InsertAclResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *InsertAclResponse) GetEntry() *AclEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+// PatchAclRequest changes the role of an existing entry.
+type PatchAclRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Bucket           string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object           string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	DefaultObjectAcl bool                   `protobuf:"varint,3,opt,name=default_object_acl,json=defaultObjectAcl,proto3" json:"default_object_acl,omitempty"`
+	Entry            *AclEntry              `protobuf:"bytes,4,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PatchAclRequest) Reset() {
+	*x = PatchAclRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchAclRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchAclRequest) ProtoMessage() {}
+
+func (x *PatchAclRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchAclRequest.ProtoReflect.Descriptor instead.
+func (*PatchAclRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *PatchAclRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *PatchAclRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *PatchAclRequest) GetDefaultObjectAcl() bool {
+	if x != nil {
+		return x.DefaultObjectAcl
+	}
+	return false
+}
+
+func (x *PatchAclRequest) GetEntry() *AclEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type PatchAclResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *AclEntry              `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchAclResponse) Reset() {
+	*x = PatchAclResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchAclResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchAclResponse) ProtoMessage() {}
+
+func (x *PatchAclResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchAclResponse.ProtoReflect.Descriptor instead.
+func (*PatchAclResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *PatchAclResponse) GetEntry() *AclEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type DeleteAclRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Bucket           string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object           string                 `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	DefaultObjectAcl bool                   `protobuf:"varint,3,opt,name=default_object_acl,json=defaultObjectAcl,proto3" json:"default_object_acl,omitempty"`
+	Entity           string                 `protobuf:"bytes,4,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteAclRequest) Reset() {
+	*x = DeleteAclRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAclRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAclRequest) ProtoMessage() {}
+
+func (x *DeleteAclRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAclRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAclRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteAclRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DeleteAclRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *DeleteAclRequest) GetDefaultObjectAcl() bool {
+	if x != nil {
+		return x.DefaultObjectAcl
+	}
+	return false
+}
+
+func (x *DeleteAclRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+type DeleteAclResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAclResponse) Reset() {
+	*x = DeleteAclResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAclResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAclResponse) ProtoMessage() {}
+
+func (x *DeleteAclResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAclResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAclResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{56}
+}
+
+// RotateKmsKeyRequest makes a new version of a Cloud KMS key primary. Objects
+// protected by older versions are re-encrypted in the background. Only server
+// administrators may rotate keys.
+type RotateKmsKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KmsKeyName    string                 `protobuf:"bytes,1,opt,name=kms_key_name,json=kmsKeyName,proto3" json:"kms_key_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateKmsKeyRequest) Reset() {
+	*x = RotateKmsKeyRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateKmsKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKmsKeyRequest) ProtoMessage() {}
+
+func (x *RotateKmsKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKmsKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateKmsKeyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RotateKmsKeyRequest) GetKmsKeyName() string {
+	if x != nil {
+		return x.KmsKeyName
+	}
+	return ""
+}
+
+type RotateKmsKeyResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PrimaryVersion string                 `protobuf:"bytes,1,opt,name=primary_version,json=primaryVersion,proto3" json:"primary_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RotateKmsKeyResponse) Reset() {
+	*x = RotateKmsKeyResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateKmsKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKmsKeyResponse) ProtoMessage() {}
+
+func (x *RotateKmsKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKmsKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateKmsKeyResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RotateKmsKeyResponse) GetPrimaryVersion() string {
+	if x != nil {
+		return x.PrimaryVersion
+	}
+	return ""
+}
+
+// ComposeObjectRequest concatenates up to 32 objects of one bucket into a
+// new object. Encrypted sources must share the key supplied here.
+type ComposeObjectRequest struct {
+	state                     protoimpl.MessageState     `protogen:"open.v1"`
+	Bucket                    string                     `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	DestinationObject         string                     `protobuf:"bytes,2,opt,name=destination_object,json=destinationObject,proto3" json:"destination_object,omitempty"`
+	SourceObjects             []string                   `protobuf:"bytes,3,rep,name=source_objects,json=sourceObjects,proto3" json:"source_objects,omitempty"`
+	Metadata                  map[string]string          `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DestinationPredefinedAcl  string                     `protobuf:"bytes,5,opt,name=destination_predefined_acl,json=destinationPredefinedAcl,proto3" json:"destination_predefined_acl,omitempty"`
+	CommonObjectRequestParams *CommonObjectRequestParams `protobuf:"bytes,6,opt,name=common_object_request_params,json=commonObjectRequestParams,proto3" json:"common_object_request_params,omitempty"`
+	KmsKeyName                string                     `protobuf:"bytes,7,opt,name=kms_key_name,json=kmsKeyName,proto3" json:"kms_key_name,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ComposeObjectRequest) Reset() {
+	*x = ComposeObjectRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComposeObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComposeObjectRequest) ProtoMessage() {}
+
+func (x *ComposeObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComposeObjectRequest.ProtoReflect.Descriptor instead.
+func (*ComposeObjectRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ComposeObjectRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ComposeObjectRequest) GetDestinationObject() string {
+	if x != nil {
+		return x.DestinationObject
+	}
+	return ""
+}
+
+func (x *ComposeObjectRequest) GetSourceObjects() []string {
+	if x != nil {
+		return x.SourceObjects
+	}
+	return nil
+}
+
+func (x *ComposeObjectRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ComposeObjectRequest) GetDestinationPredefinedAcl() string {
+	if x != nil {
+		return x.DestinationPredefinedAcl
+	}
+	return ""
+}
+
+func (x *ComposeObjectRequest) GetCommonObjectRequestParams() *CommonObjectRequestParams {
+	if x != nil {
+		return x.CommonObjectRequestParams
+	}
+	return nil
+}
+
+func (x *ComposeObjectRequest) GetKmsKeyName() string {
+	if x != nil {
+		return x.KmsKeyName
+	}
+	return ""
+}
+
+type ComposeObjectResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Resource      *GetObjectMetadataResponse `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComposeObjectResponse) Reset() {
+	*x = ComposeObjectResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComposeObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComposeObjectResponse) ProtoMessage() {}
+
+func (x *ComposeObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComposeObjectResponse.ProtoReflect.Descriptor instead.
+func (*ComposeObjectResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ComposeObjectResponse) GetResource() *GetObjectMetadataResponse {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+// StorageQuota bounds a bucket or project. Zero means unlimited.
+type StorageQuota struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxBytes      int64                  `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	MaxObjects    int64                  `protobuf:"varint,2,opt,name=max_objects,json=maxObjects,proto3" json:"max_objects,omitempty"`
+	MaxObjectSize int64                  `protobuf:"varint,3,opt,name=max_object_size,json=maxObjectSize,proto3" json:"max_object_size,omitempty"`
+	// Total size of an object's custom metadata keys and values.
+	MaxCustomMetadataSize int64 `protobuf:"varint,4,opt,name=max_custom_metadata_size,json=maxCustomMetadataSize,proto3" json:"max_custom_metadata_size,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StorageQuota) Reset() {
+	*x = StorageQuota{}
+	mi := &file_v1_storage_storage_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageQuota) ProtoMessage() {}
+
+func (x *StorageQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageQuota.ProtoReflect.Descriptor instead.
+func (*StorageQuota) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *StorageQuota) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *StorageQuota) GetMaxObjects() int64 {
+	if x != nil {
+		return x.MaxObjects
+	}
+	return 0
+}
+
+func (x *StorageQuota) GetMaxObjectSize() int64 {
+	if x != nil {
+		return x.MaxObjectSize
+	}
+	return 0
+}
+
+func (x *StorageQuota) GetMaxCustomMetadataSize() int64 {
+	if x != nil {
+		return x.MaxCustomMetadataSize
+	}
+	return 0
+}
+
+// GetStorageUsageRequest names either a bucket or a project.
+type GetStorageUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Project       string                 `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStorageUsageRequest) Reset() {
+	*x = GetStorageUsageRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStorageUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStorageUsageRequest) ProtoMessage() {}
+
+func (x *GetStorageUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStorageUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetStorageUsageRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetStorageUsageRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetStorageUsageRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+type GetStorageUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bytes         int64                  `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Objects       int64                  `protobuf:"varint,2,opt,name=objects,proto3" json:"objects,omitempty"`
+	Quota         *StorageQuota          `protobuf:"bytes,3,opt,name=quota,proto3" json:"quota,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStorageUsageResponse) Reset() {
+	*x = GetStorageUsageResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStorageUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStorageUsageResponse) ProtoMessage() {}
+
+func (x *GetStorageUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStorageUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetStorageUsageResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetStorageUsageResponse) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *GetStorageUsageResponse) GetObjects() int64 {
+	if x != nil {
+		return x.Objects
+	}
+	return 0
+}
+
+func (x *GetStorageUsageResponse) GetQuota() *StorageQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+// WriteObjectRequest streams an upload. The first message carries the spec,
+// an UploadObjectRequest whose data is ignored; the data of every message is
+// appended in order and the object is written when the stream closes.
+type WriteObjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spec          *UploadObjectRequest   `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteObjectRequest) Reset() {
+	*x = WriteObjectRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteObjectRequest) ProtoMessage() {}
+
+func (x *WriteObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteObjectRequest.ProtoReflect.Descriptor instead.
+func (*WriteObjectRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *WriteObjectRequest) GetSpec() *UploadObjectRequest {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *WriteObjectRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WriteObjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Generation    int64                  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	PersistedSize int64                  `protobuf:"varint,2,opt,name=persisted_size,json=persistedSize,proto3" json:"persisted_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteObjectResponse) Reset() {
+	*x = WriteObjectResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteObjectResponse) ProtoMessage() {}
+
+func (x *WriteObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteObjectResponse.ProtoReflect.Descriptor instead.
+func (*WriteObjectResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *WriteObjectResponse) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *WriteObjectResponse) GetPersistedSize() int64 {
+	if x != nil {
+		return x.PersistedSize
+	}
+	return 0
+}
+
+// ExportStateRequest asks for the whole storage state as a tar archive:
+// buckets with their configuration, policies and notification configs,
+// object records and stored data. The caller needs getIamPolicy and
+// objects.get on every bucket.
+type ExportStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStateRequest) Reset() {
+	*x = ExportStateRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStateRequest) ProtoMessage() {}
+
+func (x *ExportStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStateRequest.ProtoReflect.Descriptor instead.
+func (*ExportStateRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{66}
+}
+
+// ExportStateResponse carries the next chunk of the archive.
+type ExportStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStateResponse) Reset() {
+	*x = ExportStateResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStateResponse) ProtoMessage() {}
+
+func (x *ExportStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStateResponse.ProtoReflect.Descriptor instead.
+func (*ExportStateResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ExportStateResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ImportStateRequest streams an archive made by ExportState. The mode of the
+// first message applies: "merge" (the default) keeps state the archive does
+// not mention, "replace" discards it. The data of every message is appended
+// in order.
+type ImportStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStateRequest) Reset() {
+	*x = ImportStateRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStateRequest) ProtoMessage() {}
+
+func (x *ImportStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStateRequest.ProtoReflect.Descriptor instead.
+func (*ImportStateRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ImportStateRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ImportStateRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       int64                  `protobuf:"varint,1,opt,name=buckets,proto3" json:"buckets,omitempty"`
+	Objects       int64                  `protobuf:"varint,2,opt,name=objects,proto3" json:"objects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStateResponse) Reset() {
+	*x = ImportStateResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStateResponse) ProtoMessage() {}
+
+func (x *ImportStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStateResponse.ProtoReflect.Descriptor instead.
+func (*ImportStateResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ImportStateResponse) GetBuckets() int64 {
+	if x != nil {
+		return x.Buckets
+	}
+	return 0
+}
+
+func (x *ImportStateResponse) GetObjects() int64 {
+	if x != nil {
+		return x.Objects
+	}
+	return 0
+}
+
+// SeedStorageRequest applies a seed manifest, YAML or JSON, as the caller:
+// buckets and objects it declares are created, or updated where they
+// differ. Object files are read by the server.
+type SeedStorageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Manifest      []byte                 `protobuf:"bytes,1,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedStorageRequest) Reset() {
+	*x = SeedStorageRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedStorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedStorageRequest) ProtoMessage() {}
+
+func (x *SeedStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedStorageRequest.ProtoReflect.Descriptor instead.
+func (*SeedStorageRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SeedStorageRequest) GetManifest() []byte {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+type SeedStorageResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BucketsCreated   int64                  `protobuf:"varint,1,opt,name=buckets_created,json=bucketsCreated,proto3" json:"buckets_created,omitempty"`
+	BucketsUpdated   int64                  `protobuf:"varint,2,opt,name=buckets_updated,json=bucketsUpdated,proto3" json:"buckets_updated,omitempty"`
+	ObjectsWritten   int64                  `protobuf:"varint,3,opt,name=objects_written,json=objectsWritten,proto3" json:"objects_written,omitempty"`
+	ObjectsUnchanged int64                  `protobuf:"varint,4,opt,name=objects_unchanged,json=objectsUnchanged,proto3" json:"objects_unchanged,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SeedStorageResponse) Reset() {
+	*x = SeedStorageResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedStorageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedStorageResponse) ProtoMessage() {}
+
+func (x *SeedStorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedStorageResponse.ProtoReflect.Descriptor instead.
+func (*SeedStorageResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *SeedStorageResponse) GetBucketsCreated() int64 {
+	if x != nil {
+		return x.BucketsCreated
+	}
+	return 0
+}
+
+func (x *SeedStorageResponse) GetBucketsUpdated() int64 {
+	if x != nil {
+		return x.BucketsUpdated
+	}
+	return 0
+}
+
+func (x *SeedStorageResponse) GetObjectsWritten() int64 {
+	if x != nil {
+		return x.ObjectsWritten
+	}
+	return 0
+}
+
+func (x *SeedStorageResponse) GetObjectsUnchanged() int64 {
+	if x != nil {
+		return x.ObjectsUnchanged
+	}
+	return 0
+}
+
+// AuditEntry records one call of a mutating RPC, successful or not.
+type AuditEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position in the log; entries are numbered in the order they were made.
+	Sequence int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Time     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// IAM member of the caller, empty for anonymous callers.
+	Principal string `protobuf:"bytes,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	// Full procedure name, e.g. "/storage.v1.StorageService/UploadObject".
+	Procedure string `protobuf:"bytes,4,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	Bucket    string `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object    string `protobuf:"bytes,6,opt,name=object,proto3" json:"object,omitempty"`
+	// Generation the call created, when it wrote an object.
+	Generation int64 `protobuf:"varint,7,opt,name=generation,proto3" json:"generation,omitempty"`
+	// The caller's X-Request-Id, or the one the server assigned.
+	RequestId string `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Connect code of the outcome: "ok" or an error code such as
+	// "permission_denied".
+	Code          string `protobuf:"bytes,9,opt,name=code,proto3" json:"code,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,10,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_v1_storage_storage_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *AuditEntry) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// QueryAuditLogRequest filters the audit log; unset filters match all
+// entries. The caller sees the entries of buckets they hold getIamPolicy on,
+// and their own.
+type QueryAuditLogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entries at or after start_time and before end_time.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Principal string                 `protobuf:"bytes,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	Bucket    string                 `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Only applies together with bucket.
+	Object string `protobuf:"bytes,5,opt,name=object,proto3" json:"object,omitempty"`
+	// Maximum entries per page; 0 means the default of 1000, which is also
+	// the maximum.
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous page.
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogRequest) Reset() {
+	*x = QueryAuditLogRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogRequest) ProtoMessage() {}
+
+func (x *QueryAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *QueryAuditLogRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *QueryAuditLogRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *QueryAuditLogRequest) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *QueryAuditLogRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type QueryAuditLogResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first.
+	Entries []*AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Set when more entries may follow; pass it as page_token to fetch them.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogResponse) Reset() {
+	*x = QueryAuditLogResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogResponse) ProtoMessage() {}
+
+func (x *QueryAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *QueryAuditLogResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *QueryAuditLogResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// FaultRule makes the StorageService calls it matches misbehave, to test
+// how clients cope. Empty procedure, bucket and object match every call.
+type FaultRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RPC name, e.g. "ReadObject".
+	Procedure string `protobuf:"bytes,1,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	Bucket    string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Glob over the object name, in Go path.Match syntax, e.g. "logs/*".
+	Object string `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`
+	// Chance that a matching call is affected; 0 means every call.
+	Probability float64 `protobuf:"fixed64,4,opt,name=probability,proto3" json:"probability,omitempty"`
+	// Number of calls the rule affects before it lapses; 0 means no limit.
+	Count int64 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	// Connect code to fail with, e.g. "unavailable". Without
+	// truncate_after_bytes the call fails before it takes effect.
+	Code    string `protobuf:"bytes,6,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	// Delay before the call is handled.
+	Latency *durationpb.Duration `protobuf:"bytes,8,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Ends a stream with code, or "unavailable", once it has carried this
+	// many bytes of object or archive data.
+	TruncateAfterBytes int64 `protobuf:"varint,9,opt,name=truncate_after_bytes,json=truncateAfterBytes,proto3" json:"truncate_after_bytes,omitempty"`
+	// Paces the data of streamed messages to this rate.
+	BytesPerSecond int64 `protobuf:"varint,10,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// Output only: the calls the rule has affected.
+	Fired         int64 `protobuf:"varint,11,opt,name=fired,proto3" json:"fired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FaultRule) Reset() {
+	*x = FaultRule{}
+	mi := &file_v1_storage_storage_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FaultRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultRule) ProtoMessage() {}
+
+func (x *FaultRule) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultRule.ProtoReflect.Descriptor instead.
+func (*FaultRule) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *FaultRule) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
+	}
+	return ""
+}
+
+func (x *FaultRule) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *FaultRule) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *FaultRule) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *FaultRule) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *FaultRule) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *FaultRule) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *FaultRule) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *FaultRule) GetTruncateAfterBytes() int64 {
+	if x != nil {
+		return x.TruncateAfterBytes
+	}
+	return 0
+}
+
+func (x *FaultRule) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *FaultRule) GetFired() int64 {
+	if x != nil {
+		return x.Fired
+	}
+	return 0
+}
+
+// SetFaultRulesRequest replaces the fault rules. The first rule that
+// matches a call and fires applies to it. Fault injection must be enabled
+// on the server, and only server administrators may change it. Never enable
+// it outside tests.
+type SetFaultRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*FaultRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFaultRulesRequest) Reset() {
+	*x = SetFaultRulesRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFaultRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFaultRulesRequest) ProtoMessage() {}
+
+func (x *SetFaultRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFaultRulesRequest.ProtoReflect.Descriptor instead.
+func (*SetFaultRulesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SetFaultRulesRequest) GetRules() []*FaultRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type SetFaultRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*FaultRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFaultRulesResponse) Reset() {
+	*x = SetFaultRulesResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFaultRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFaultRulesResponse) ProtoMessage() {}
+
+func (x *SetFaultRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFaultRulesResponse.ProtoReflect.Descriptor instead.
+func (*SetFaultRulesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *SetFaultRulesResponse) GetRules() []*FaultRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type ListFaultRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFaultRulesRequest) Reset() {
+	*x = ListFaultRulesRequest{}
+	mi := &file_v1_storage_storage_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFaultRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFaultRulesRequest) ProtoMessage() {}
+
+func (x *ListFaultRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFaultRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListFaultRulesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{78}
+}
+
+type ListFaultRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*FaultRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFaultRulesResponse) Reset() {
+	*x = ListFaultRulesResponse{}
+	mi := &file_v1_storage_storage_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFaultRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFaultRulesResponse) ProtoMessage() {}
+
+func (x *ListFaultRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFaultRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListFaultRulesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListFaultRulesResponse) GetRules() []*FaultRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type LifecycleRule_Action struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	StorageClass  string                 `protobuf:"bytes,2,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LifecycleRule_Action) Reset() {
+	*x = LifecycleRule_Action{}
+	mi := &file_v1_storage_storage_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifecycleRule_Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifecycleRule_Action) ProtoMessage() {}
+
+func (x *LifecycleRule_Action) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LifecycleRule_Action.ProtoReflect.Descriptor instead.
+func (*LifecycleRule_Action) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *LifecycleRule_Action) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LifecycleRule_Action) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+type LifecycleRule_Condition struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AgeDays             int32                  `protobuf:"varint,1,opt,name=age_days,json=ageDays,proto3" json:"age_days,omitempty"`
+	MatchesStorageClass []string               `protobuf:"bytes,2,rep,name=matches_storage_class,json=matchesStorageClass,proto3" json:"matches_storage_class,omitempty"`
+	MatchesPrefix       []string               `protobuf:"bytes,3,rep,name=matches_prefix,json=matchesPrefix,proto3" json:"matches_prefix,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *LifecycleRule_Condition) Reset() {
+	*x = LifecycleRule_Condition{}
+	mi := &file_v1_storage_storage_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifecycleRule_Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifecycleRule_Condition) ProtoMessage() {}
+
+func (x *LifecycleRule_Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_storage_storage_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LifecycleRule_Condition.ProtoReflect.Descriptor instead.
+func (*LifecycleRule_Condition) Descriptor() ([]byte, []int) {
+	return file_v1_storage_storage_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *LifecycleRule_Condition) GetAgeDays() int32 {
+	if x != nil {
+		return x.AgeDays
+	}
+	return 0
+}
+
+func (x *LifecycleRule_Condition) GetMatchesStorageClass() []string {
+	if x != nil {
+		return x.MatchesStorageClass
+	}
+	return nil
+}
+
+func (x *LifecycleRule_Condition) GetMatchesPrefix() []string {
+	if x != nil {
+		return x.MatchesPrefix
+	}
+	return nil
+}
+
+var File_v1_storage_storage_proto protoreflect.FileDescriptor
+
+const file_v1_storage_storage_proto_rawDesc = "" +
+	"\n" +
+	"\x18v1/storage/storage.proto\x12\n" +
+	"storage.v1\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x06\n" +
+	"\x06Bucket\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12#\n" +
+	"\rstorage_class\x18\x03 \x01(\tR\fstorageClass\x126\n" +
+	"\x06labels\x18\x04 \x03(\v2\x1e.storage.v1.Bucket.LabelsEntryR\x06labels\x12e\n" +
+	"\x17default_object_metadata\x18\x05 \x03(\v2-.storage.v1.Bucket.DefaultObjectMetadataEntryR\x15defaultObjectMetadata\x12;\n" +
+	"\vcreate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12&\n" +
+	"\x0emetageneration\x18\b \x01(\x03R\x0emetageneration\x12B\n" +
+	"\x0flifecycle_rules\x18\t \x03(\v2\x19.storage.v1.LifecycleRuleR\x0elifecycleRules\x12&\n" +
+	"\x03acl\x18\n" +
+	" \x03(\v2\x14.storage.v1.AclEntryR\x03acl\x12B\n" +
+	"\x12default_object_acl\x18\v \x03(\v2\x14.storage.v1.AclEntryR\x10defaultObjectAcl\x12=\n" +
+	"\x1buniform_bucket_level_access\x18\f \x01(\bR\x18uniformBucketLevelAccess\x12/\n" +
+	"\x14default_kms_key_name\x18\r \x01(\tR\x11defaultKmsKeyName\x12\x18\n" +
+	"\aproject\x18\x0e \x01(\tR\aproject\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aH\n" +
+	"\x1aDefaultObjectMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\x02\n" +
+	"\rLifecycleRule\x128\n" +
+	"\x06action\x18\x01 \x01(\v2 .storage.v1.LifecycleRule.ActionR\x06action\x12A\n" +
+	"\tcondition\x18\x02 \x01(\v2#.storage.v1.LifecycleRule.ConditionR\tcondition\x1aA\n" +
+	"\x06Action\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12#\n" +
+	"\rstorage_class\x18\x02 \x01(\tR\fstorageClass\x1a\x81\x01\n" +
+	"\tCondition\x12\x19\n" +
+	"\bage_days\x18\x01 \x01(\x05R\aageDays\x122\n" +
+	"\x15matches_storage_class\x18\x02 \x03(\tR\x13matchesStorageClass\x12%\n" +
+	"\x0ematches_prefix\x18\x03 \x03(\tR\rmatchesPrefix\"\xe0\x05\n" +
+	"\x13CreateBucketRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12#\n" +
+	"\rstorage_class\x18\x03 \x01(\tR\fstorageClass\x12C\n" +
+	"\x06labels\x18\x04 \x03(\v2+.storage.v1.CreateBucketRequest.LabelsEntryR\x06labels\x12r\n" +
+	"\x17default_object_metadata\x18\x05 \x03(\v2:.storage.v1.CreateBucketRequest.DefaultObjectMetadataEntryR\x15defaultObjectMetadata\x12B\n" +
+	"\x0flifecycle_rules\x18\x06 \x03(\v2\x19.storage.v1.LifecycleRuleR\x0elifecycleRules\x12%\n" +
+	"\x0epredefined_acl\x18\a \x01(\tR\rpredefinedAcl\x12A\n" +
+	"\x1dpredefined_default_object_acl\x18\b \x01(\tR\x1apredefinedDefaultObjectAcl\x12=\n" +
+	"\x1buniform_bucket_level_access\x18\t \x01(\bR\x18uniformBucketLevelAccess\x12/\n" +
+	"\x14default_kms_key_name\x18\n" +
+	" \x01(\tR\x11defaultKmsKeyName\x12\x18\n" +
+	"\aproject\x18\v \x01(\tR\aproject\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aH\n" +
+	"\x1aDefaultObjectMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"B\n" +
+	"\x14CreateBucketResponse\x12*\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x12.storage.v1.BucketR\x06bucket\"&\n" +
+	"\x10GetBucketRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"?\n" +
+	"\x11GetBucketResponse\x12*\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x12.storage.v1.BucketR\x06bucket\"\xab\x01\n" +
+	"\x12ListBucketsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12B\n" +
+	"\x06labels\x18\x02 \x03(\v2*.storage.v1.ListBucketsRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"C\n" +
+	"\x13ListBucketsResponse\x12,\n" +
+	"\abuckets\x18\x01 \x03(\v2\x12.storage.v1.BucketR\abuckets\"~\n" +
+	"\x13UpdateBucketRequest\x12*\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x12.storage.v1.BucketR\x06bucket\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"B\n" +
+	"\x14UpdateBucketResponse\x12*\n" +
+	"\x06bucket\x18\x01 \x01(\v2\x12.storage.v1.BucketR\x06bucket\"\xb3\x03\n" +
+	"\x13UploadObjectRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12I\n" +
+	"\bmetadata\x18\x04 \x03(\v2-.storage.v1.UploadObjectRequest.MetadataEntryR\bmetadata\x12#\n" +
+	"\rstorage_class\x18\x05 \x01(\tR\fstorageClass\x12%\n" +
+	"\x0epredefined_acl\x18\x06 \x01(\tR\rpredefinedAcl\x12f\n" +
+	"\x1ccommon_object_request_params\x18\a \x01(\v2%.storage.v1.CommonObjectRequestParamsR\x19commonObjectRequestParams\x12 \n" +
+	"\fkms_key_name\x18\b \x01(\tR\n" +
+	"kmsKeyName\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbf\x01\n" +
+	"\x19CommonObjectRequestParams\x121\n" +
+	"\x14encryption_algorithm\x18\x01 \x01(\tR\x13encryptionAlgorithm\x120\n" +
+	"\x14encryption_key_bytes\x18\x02 \x01(\fR\x12encryptionKeyBytes\x12=\n" +
+	"\x1bencryption_key_sha256_bytes\x18\x03 \x01(\fR\x18encryptionKeySha256Bytes\"q\n" +
+	"\x12CustomerEncryption\x121\n" +
+	"\x14encryption_algorithm\x18\x01 \x01(\tR\x13encryptionAlgorithm\x12(\n" +
+	"\x10key_sha256_bytes\x18\x02 \x01(\fR\x0ekeySha256Bytes\"6\n" +
+	"\x14UploadObjectResponse\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\x03R\n" +
+	"generation\"F\n" +
+	"\x18GetObjectMetadataRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xc2\x05\n" +
+	"\x19GetObjectMetadataResponse\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12O\n" +
+	"\bmetadata\x18\x04 \x03(\v23.storage.v1.GetObjectMetadataResponse.MetadataEntryR\bmetadata\x12#\n" +
+	"\rstorage_class\x18\x05 \x01(\tR\fstorageClass\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x06 \x01(\x03R\n" +
+	"generation\x12&\n" +
+	"\x0emetageneration\x18\a \x01(\x03R\x0emetageneration\x12;\n" +
+	"\vcreate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12U\n" +
+	"\x19storage_class_update_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x16storageClassUpdateTime\x12&\n" +
+	"\x03acl\x18\v \x03(\v2\x14.storage.v1.AclEntryR\x03acl\x12O\n" +
+	"\x13customer_encryption\x18\f \x01(\v2\x1e.storage.v1.CustomerEncryptionR\x12customerEncryption\x12 \n" +
+	"\fkms_key_name\x18\r \x01(\tR\n" +
+	"kmsKeyName\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x01\n" +
+	"\x12ListObjectsRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"`\n" +
+	"\x13ListObjectsResponse\x12!\n" +
+	"\fobject_names\x18\x01 \x03(\tR\vobjectNames\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"C\n" +
+	"\x15GetDownloadURLRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"*\n" +
+	"\x16GetDownloadURLResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\"\xe7\x01\n" +
+	"\x11ReadObjectRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vread_offset\x18\x03 \x01(\x03R\n" +
+	"readOffset\x12\x1d\n" +
+	"\n" +
+	"read_limit\x18\x04 \x01(\x03R\treadLimit\x12f\n" +
+	"\x1ccommon_object_request_params\x18\x05 \x01(\v2%.storage.v1.CommonObjectRequestParamsR\x19commonObjectRequestParams\"(\n" +
+	"\x12ReadObjectResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"q\n" +
+	"\x13DeleteObjectRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12.\n" +
+	"\x13if_generation_match\x18\x03 \x01(\x03R\x11ifGenerationMatch\"\x16\n" +
+	"\x14DeleteObjectResponse\"\xbf\x05\n" +
+	"\x14RewriteObjectRequest\x12#\n" +
+	"\rsource_bucket\x18\x01 \x01(\tR\fsourceBucket\x12#\n" +
+	"\rsource_object\x18\x02 \x01(\tR\fsourceObject\x12-\n" +
+	"\x12destination_bucket\x18\x03 \x01(\tR\x11destinationBucket\x12-\n" +
+	"\x12destination_object\x18\x04 \x01(\tR\x11destinationObject\x12:\n" +
+	"\x19destination_storage_class\x18\x05 \x01(\tR\x17destinationStorageClass\x12<\n" +
+	"\x1adestination_predefined_acl\x18\x06 \x01(\tR\x18destinationPredefinedAcl\x12f\n" +
+	"\x1ccommon_object_request_params\x18\a \x01(\v2%.storage.v1.CommonObjectRequestParamsR\x19commonObjectRequestParams\x12G\n" +
+	" copy_source_encryption_algorithm\x18\b \x01(\tR\x1dcopySourceEncryptionAlgorithm\x12F\n" +
+	" copy_source_encryption_key_bytes\x18\t \x01(\fR\x1ccopySourceEncryptionKeyBytes\x12S\n" +
+	"'copy_source_encryption_key_sha256_bytes\x18\n" +
+	" \x01(\fR\"copySourceEncryptionKeySha256Bytes\x127\n" +
+	"\x18destination_kms_key_name\x18\v \x01(\tR\x15destinationKmsKeyName\"Z\n" +
+	"\x15RewriteObjectResponse\x12A\n" +
+	"\bresource\x18\x01 \x01(\v2%.storage.v1.GetObjectMetadataResponseR\bresource\"3\n" +
+	"\x19ListEarlyDeletionsRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\"\xa5\x02\n" +
+	"\rEarlyDeletion\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x03 \x01(\x03R\n" +
+	"generation\x12#\n" +
+	"\rstorage_class\x18\x04 \x01(\tR\fstorageClass\x12%\n" +
+	"\x0estored_seconds\x18\x05 \x01(\x03R\rstoredSeconds\x12'\n" +
+	"\x0fcharged_seconds\x18\x06 \x01(\x03R\x0echargedSeconds\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12;\n" +
+	"\vdelete_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTime\"`\n" +
+	"\x1aListEarlyDeletionsResponse\x12B\n" +
+	"\x0fearly_deletions\x18\x01 \x03(\v2\x19.storage.v1.EarlyDeletionR\x0eearlyDeletions\"\x86\x02\n" +
+	"\x13UpdateObjectRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12I\n" +
+	"\bmetadata\x18\x03 \x03(\v2-.storage.v1.UpdateObjectRequest.MetadataEntryR\bmetadata\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Y\n" +
+	"\x14UpdateObjectResponse\x12A\n" +
+	"\bresource\x18\x01 \x01(\v2%.storage.v1.GetObjectMetadataResponseR\bresource\"\xd8\x02\n" +
+	"\x12NotificationConfig\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12,\n" +
+	"\x12object_name_prefix\x18\x04 \x01(\tR\x10objectNamePrefix\x12a\n" +
+	"\x11custom_attributes\x18\x05 \x03(\v24.storage.v1.NotificationConfig.CustomAttributesEntryR\x10customAttributes\x12%\n" +
+	"\x0epayload_format\x18\x06 \x01(\tR\rpayloadFormat\x1aC\n" +
+	"\x15CustomAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8a\x01\n" +
+	"\x1fCreateNotificationConfigRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12O\n" +
+	"\x13notification_config\x18\x02 \x01(\v2\x1e.storage.v1.NotificationConfigR\x12notificationConfig\"s\n" +
+	" CreateNotificationConfigResponse\x12O\n" +
+	"\x13notification_config\x18\x01 \x01(\v2\x1e.storage.v1.NotificationConfigR\x12notificationConfig\"F\n" +
+	"\x1cGetNotificationConfigRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"p\n" +
+	"\x1dGetNotificationConfigResponse\x12O\n" +
+	"\x13notification_config\x18\x01 \x01(\v2\x1e.storage.v1.NotificationConfigR\x12notificationConfig\"8\n" +
+	"\x1eListNotificationConfigsRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\"t\n" +
+	"\x1fListNotificationConfigsResponse\x12Q\n" +
+	"\x14notification_configs\x18\x01 \x03(\v2\x1e.storage.v1.NotificationConfigR\x13notificationConfigs\"I\n" +
+	"\x1fDeleteNotificationConfigRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\"\n" +
+	" DeleteNotificationConfigResponse\"g\n" +
+	"\x06Policy\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12/\n" +
+	"\bbindings\x18\x02 \x03(\v2\x13.storage.v1.BindingR\bbindings\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"7\n" +
+	"\aBinding\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"-\n" +
+	"\x13GetIamPolicyRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\"B\n" +
+	"\x14GetIamPolicyResponse\x12*\n" +
+	"\x06policy\x18\x01 \x01(\v2\x12.storage.v1.PolicyR\x06policy\"Y\n" +
+	"\x13SetIamPolicyRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12*\n" +
+	"\x06policy\x18\x02 \x01(\v2\x12.storage.v1.PolicyR\x06policy\"B\n" +
+	"\x14SetIamPolicyResponse\x12*\n" +
+	"\x06policy\x18\x01 \x01(\v2\x12.storage.v1.PolicyR\x06policy\"U\n" +
+	"\x19TestIamPermissionsRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12 \n" +
+	"\vpermissions\x18\x02 \x03(\tR\vpermissions\">\n" +
+	"\x1aTestIamPermissionsResponse\x12 \n" +
+	"\vpermissions\x18\x01 \x03(\tR\vpermissions\"6\n" +
+	"\bAclEntry\x12\x16\n" +
+	"\x06entity\x18\x01 \x01(\tR\x06entity\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"n\n" +
+	"\x0eListAclRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12,\n" +
+	"\x12default_object_acl\x18\x03 \x01(\bR\x10defaultObjectAcl\"A\n" +
+	"\x0fListAclResponse\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.storage.v1.AclEntryR\aentries\"\x9c\x01\n" +
+	"\x10InsertAclRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12,\n" +
+	"\x12default_object_acl\x18\x03 \x01(\bR\x10defaultObjectAcl\x12*\n" +
+	"\x05entry\x18\x04 \x01(\v2\x14.storage.v1.AclEntryR\x05entry\"?\n" +
+	"\x11InsertAclResponse\x12*\n" +
+	"\x05entry\x18\x01 \x01(\v2\x14.storage.v1.AclEntryR\x05entry\"\x9b\x01\n" +
+	"\x0fPatchAclRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12,\n" +
+	"\x12default_object_acl\x18\x03 \x01(\bR\x10defaultObjectAcl\x12*\n" +
+	"\x05entry\x18\x04 \x01(\v2\x14.storage.v1.AclEntryR\x05entry\">\n" +
+	"\x10PatchAclResponse\x12*\n" +
+	"\x05entry\x18\x01 \x01(\v2\x14.storage.v1.AclEntryR\x05entry\"\x88\x01\n" +
+	"\x10DeleteAclRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12,\n" +
+	"\x12default_object_acl\x18\x03 \x01(\bR\x10defaultObjectAcl\x12\x16\n" +
+	"\x06entity\x18\x04 \x01(\tR\x06entity\"\x13\n" +
+	"\x11DeleteAclResponse\"7\n" +
+	"\x13RotateKmsKeyRequest\x12 \n" +
+	"\fkms_key_name\x18\x01 \x01(\tR\n" +
+	"kmsKeyName\"?\n" +
+	"\x14RotateKmsKeyResponse\x12'\n" +
+	"\x0fprimary_version\x18\x01 \x01(\tR\x0eprimaryVersion\"\xd5\x03\n" +
+	"\x14ComposeObjectRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12-\n" +
+	"\x12destination_object\x18\x02 \x01(\tR\x11destinationObject\x12%\n" +
+	"\x0esource_objects\x18\x03 \x03(\tR\rsourceObjects\x12J\n" +
+	"\bmetadata\x18\x04 \x03(\v2..storage.v1.ComposeObjectRequest.MetadataEntryR\bmetadata\x12<\n" +
+	"\x1adestination_predefined_acl\x18\x05 \x01(\tR\x18destinationPredefinedAcl\x12f\n" +
+	"\x1ccommon_object_request_params\x18\x06 \x01(\v2%.storage.v1.CommonObjectRequestParamsR\x19commonObjectRequestParams\x12 \n" +
+	"\fkms_key_name\x18\a \x01(\tR\n" +
+	"kmsKeyName\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Z\n" +
+	"\x15ComposeObjectResponse\x12A\n" +
+	"\bresource\x18\x01 \x01(\v2%.storage.v1.GetObjectMetadataResponseR\bresource\"\xad\x01\n" +
+	"\fStorageQuota\x12\x1b\n" +
+	"\tmax_bytes\x18\x01 \x01(\x03R\bmaxBytes\x12\x1f\n" +
+	"\vmax_objects\x18\x02 \x01(\x03R\n" +
+	"maxObjects\x12&\n" +
+	"\x0fmax_object_size\x18\x03 \x01(\x03R\rmaxObjectSize\x127\n" +
+	"\x18max_custom_metadata_size\x18\x04 \x01(\x03R\x15maxCustomMetadataSize\"J\n" +
+	"\x16GetStorageUsageRequest\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x18\n" +
+	"\aproject\x18\x02 \x01(\tR\aproject\"y\n" +
+	"\x17GetStorageUsageResponse\x12\x14\n" +
+	"\x05bytes\x18\x01 \x01(\x03R\x05bytes\x12\x18\n" +
+	"\aobjects\x18\x02 \x01(\x03R\aobjects\x12.\n" +
+	"\x05quota\x18\x03 \x01(\v2\x18.storage.v1.StorageQuotaR\x05quota\"]\n" +
+	"\x12WriteObjectRequest\x123\n" +
+	"\x04spec\x18\x01 \x01(\v2\x1f.storage.v1.UploadObjectRequestR\x04spec\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\\\n" +
+	"\x13WriteObjectResponse\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\x03R\n" +
+	"generation\x12%\n" +
+	"\x0epersisted_size\x18\x02 \x01(\x03R\rpersistedSize\"\x14\n" +
+	"\x12ExportStateRequest\")\n" +
+	"\x13ExportStateResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"<\n" +
+	"\x12ImportStateRequest\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"I\n" +
+	"\x13ImportStateResponse\x12\x18\n" +
+	"\abuckets\x18\x01 \x01(\x03R\abuckets\x12\x18\n" +
+	"\aobjects\x18\x02 \x01(\x03R\aobjects\"0\n" +
+	"\x12SeedStorageRequest\x12\x1a\n" +
+	"\bmanifest\x18\x01 \x01(\fR\bmanifest\"\xbd\x01\n" +
+	"\x13SeedStorageResponse\x12'\n" +
+	"\x0fbuckets_created\x18\x01 \x01(\x03R\x0ebucketsCreated\x12'\n" +
+	"\x0fbuckets_updated\x18\x02 \x01(\x03R\x0ebucketsUpdated\x12'\n" +
+	"\x0fobjects_written\x18\x03 \x01(\x03R\x0eobjectsWritten\x12+\n" +
+	"\x11objects_unchanged\x18\x04 \x01(\x03R\x10objectsUnchanged\"\xbc\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1c\n" +
+	"\tprincipal\x18\x03 \x01(\tR\tprincipal\x12\x1c\n" +
+	"\tprocedure\x18\x04 \x01(\tR\tprocedure\x12\x16\n" +
+	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x06 \x01(\tR\x06object\x12\x1e\n" +
+	"\n" +
+	"generation\x18\a \x01(\x03R\n" +
+	"generation\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\b \x01(\tR\trequestId\x12\x12\n" +
+	"\x04code\x18\t \x01(\tR\x04code\x12#\n" +
+	"\rerror_message\x18\n" +
+	" \x01(\tR\ferrorMessage\"\x92\x02\n" +
+	"\x14QueryAuditLogRequest\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1c\n" +
+	"\tprincipal\x18\x03 \x01(\tR\tprincipal\x12\x16\n" +
+	"\x06bucket\x18\x04 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x05 \x01(\tR\x06object\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"q\n" +
+	"\x15QueryAuditLogResponse\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.storage.v1.AuditEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe6\x02\n" +
+	"\tFaultRule\x12\x1c\n" +
+	"\tprocedure\x18\x01 \x01(\tR\tprocedure\x12\x16\n" +
+	"\x06bucket\x18\x02 \x01(\tR\x06bucket\x12\x16\n" +
+	"\x06object\x18\x03 \x01(\tR\x06object\x12 \n" +
+	"\vprobability\x18\x04 \x01(\x01R\vprobability\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\x12\x12\n" +
+	"\x04code\x18\x06 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x123\n" +
+	"\alatency\x18\b \x01(\v2\x19.google.protobuf.DurationR\alatency\x120\n" +
+	"\x14truncate_after_bytes\x18\t \x01(\x03R\x12truncateAfterBytes\x12(\n" +
+	"\x10bytes_per_second\x18\n" +
+	" \x01(\x03R\x0ebytesPerSecond\x12\x14\n" +
+	"\x05fired\x18\v \x01(\x03R\x05fired\"C\n" +
+	"\x14SetFaultRulesRequest\x12+\n" +
+	"\x05rules\x18\x01 \x03(\v2\x15.storage.v1.FaultRuleR\x05rules\"D\n" +
+	"\x15SetFaultRulesResponse\x12+\n" +
+	"\x05rules\x18\x01 \x03(\v2\x15.storage.v1.FaultRuleR\x05rules\"\x17\n" +
+	"\x15ListFaultRulesRequest\"E\n" +
+	"\x16ListFaultRulesResponse\x12+\n" +
+	"\x05rules\x18\x01 \x03(\v2\x15.storage.v1.FaultRuleR\x05rules2\xa8\x17\n" +
+	"\x0eStorageService\x12Q\n" +
+	"\fCreateBucket\x12\x1f.storage.v1.CreateBucketRequest\x1a .storage.v1.CreateBucketResponse\x12Q\n" +
+	"\fUploadObject\x12\x1f.storage.v1.UploadObjectRequest\x1a .storage.v1.UploadObjectResponse\x12`\n" +
+	"\x11GetObjectMetadata\x12$.storage.v1.GetObjectMetadataRequest\x1a%.storage.v1.GetObjectMetadataResponse\x12N\n" +
+	"\vListObjects\x12\x1e.storage.v1.ListObjectsRequest\x1a\x1f.storage.v1.ListObjectsResponse\x12W\n" +
+	"\x0eGetDownloadURL\x12!.storage.v1.GetDownloadURLRequest\x1a\".storage.v1.GetDownloadURLResponse\x12H\n" +
+	"\tGetBucket\x12\x1c.storage.v1.GetBucketRequest\x1a\x1d.storage.v1.GetBucketResponse\x12N\n" +
+	"\vListBuckets\x12\x1e.storage.v1.ListBucketsRequest\x1a\x1f.storage.v1.ListBucketsResponse\x12Q\n" +
+	"\fUpdateBucket\x12\x1f.storage.v1.UpdateBucketRequest\x1a .storage.v1.UpdateBucketResponse\x12M\n" +
+	"\n" +
+	"ReadObject\x12\x1d.storage.v1.ReadObjectRequest\x1a\x1e.storage.v1.ReadObjectResponse0\x01\x12Q\n" +
+	"\fDeleteObject\x12\x1f.storage.v1.DeleteObjectRequest\x1a .storage.v1.DeleteObjectResponse\x12T\n" +
+	"\rRewriteObject\x12 .storage.v1.RewriteObjectRequest\x1a!.storage.v1.RewriteObjectResponse\x12c\n" +
+	"\x12ListEarlyDeletions\x12%.storage.v1.ListEarlyDeletionsRequest\x1a&.storage.v1.ListEarlyDeletionsResponse\x12Q\n" +
+	"\fUpdateObject\x12\x1f.storage.v1.UpdateObjectRequest\x1a .storage.v1.UpdateObjectResponse\x12u\n" +
+	"\x18CreateNotificationConfig\x12+.storage.v1.CreateNotificationConfigRequest\x1a,.storage.v1.CreateNotificationConfigResponse\x12l\n" +
+	"\x15GetNotificationConfig\x12(.storage.v1.GetNotificationConfigRequest\x1a).storage.v1.GetNotificationConfigResponse\x12r\n" +
+	"\x17ListNotificationConfigs\x12*.storage.v1.ListNotificationConfigsRequest\x1a+.storage.v1.ListNotificationConfigsResponse\x12u\n" +
+	"\x18DeleteNotificationConfig\x12+.storage.v1.DeleteNotificationConfigRequest\x1a,.storage.v1.DeleteNotificationConfigResponse\x12Q\n" +
+	"\fGetIamPolicy\x12\x1f.storage.v1.GetIamPolicyRequest\x1a .storage.v1.GetIamPolicyResponse\x12Q\n" +
+	"\fSetIamPolicy\x12\x1f.storage.v1.SetIamPolicyRequest\x1a .storage.v1.SetIamPolicyResponse\x12c\n" +
+	"\x12TestIamPermissions\x12%.storage.v1.TestIamPermissionsRequest\x1a&.storage.v1.TestIamPermissionsResponse\x12B\n" +
+	"\aListAcl\x12\x1a.storage.v1.ListAclRequest\x1a\x1b.storage.v1.ListAclResponse\x12H\n" +
+	"\tInsertAcl\x12\x1c.storage.v1.InsertAclRequest\x1a\x1d.storage.v1.InsertAclResponse\x12E\n" +
+	"\bPatchAcl\x12\x1b.storage.v1.PatchAclRequest\x1a\x1c.storage.v1.PatchAclResponse\x12H\n" +
+	"\tDeleteAcl\x12\x1c.storage.v1.DeleteAclRequest\x1a\x1d.storage.v1.DeleteAclResponse\x12Q\n" +
+	"\fRotateKmsKey\x12\x1f.storage.v1.RotateKmsKeyRequest\x1a .storage.v1.RotateKmsKeyResponse\x12T\n" +
+	"\rComposeObject\x12 .storage.v1.ComposeObjectRequest\x1a!.storage.v1.ComposeObjectResponse\x12Z\n" +
+	"\x0fGetStorageUsage\x12\".storage.v1.GetStorageUsageRequest\x1a#.storage.v1.GetStorageUsageResponse\x12P\n" +
+	"\vWriteObject\x12\x1e.storage.v1.WriteObjectRequest\x1a\x1f.storage.v1.WriteObjectResponse(\x01\x12P\n" +
+	"\vExportState\x12\x1e.storage.v1.ExportStateRequest\x1a\x1f.storage.v1.ExportStateResponse0\x01\x12P\n" +
+	"\vImportState\x12\x1e.storage.v1.ImportStateRequest\x1a\x1f.storage.v1.ImportStateResponse(\x01\x12N\n" +
+	"\vSeedStorage\x12\x1e.storage.v1.SeedStorageRequest\x1a\x1f.storage.v1.SeedStorageResponse\x12T\n" +
+	"\rQueryAuditLog\x12 .storage.v1.QueryAuditLogRequest\x1a!.storage.v1.QueryAuditLogResponse\x12T\n" +
+	"\rSetFaultRules\x12 .storage.v1.SetFaultRulesRequest\x1a!.storage.v1.SetFaultRulesResponse\x12W\n" +
+	"\x0eListFaultRules\x12!.storage.v1.ListFaultRulesRequest\x1a\".storage.v1.ListFaultRulesResponseB-Z+OlympusGCP-Storage/gen/v1/storage;storagev1b\x06proto3"
+
+var (
+	file_v1_storage_storage_proto_rawDescOnce sync.Once
+	file_v1_storage_storage_proto_rawDescData []byte
+)
+
+func file_v1_storage_storage_proto_rawDescGZIP() []byte {
+	file_v1_storage_storage_proto_rawDescOnce.Do(func() {
+		file_v1_storage_storage_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v1_storage_storage_proto_rawDesc), len(file_v1_storage_storage_proto_rawDesc)))
+	})
+	return file_v1_storage_storage_proto_rawDescData
+}
+
+var file_v1_storage_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 92)
+var file_v1_storage_storage_proto_goTypes = []any{
+	(*Bucket)(nil),                           // 0: storage.v1.Bucket
+	(*LifecycleRule)(nil),                    // 1: storage.v1.LifecycleRule
+	(*CreateBucketRequest)(nil),              // 2: storage.v1.CreateBucketRequest
+	(*CreateBucketResponse)(nil),             // 3: storage.v1.CreateBucketResponse
+	(*GetBucketRequest)(nil),                 // 4: storage.v1.GetBucketRequest
+	(*GetBucketResponse)(nil),                // 5: storage.v1.GetBucketResponse
+	(*ListBucketsRequest)(nil),               // 6: storage.v1.ListBucketsRequest
+	(*ListBucketsResponse)(nil),              // 7: storage.v1.ListBucketsResponse
+	(*UpdateBucketRequest)(nil),              // 8: storage.v1.UpdateBucketRequest
+	(*UpdateBucketResponse)(nil),             // 9: storage.v1.UpdateBucketResponse
+	(*UploadObjectRequest)(nil),              // 10: storage.v1.UploadObjectRequest
+	(*CommonObjectRequestParams)(nil),        // 11: storage.v1.CommonObjectRequestParams
+	(*CustomerEncryption)(nil),               // 12: storage.v1.CustomerEncryption
+	(*UploadObjectResponse)(nil),             // 13: storage.v1.UploadObjectResponse
+	(*GetObjectMetadataRequest)(nil),         // 14: storage.v1.GetObjectMetadataRequest
+	(*GetObjectMetadataResponse)(nil),        // 15: storage.v1.GetObjectMetadataResponse
+	(*ListObjectsRequest)(nil),               // 16: storage.v1.ListObjectsRequest
+	(*ListObjectsResponse)(nil),              // 17: storage.v1.ListObjectsResponse
+	(*GetDownloadURLRequest)(nil),            // 18: storage.v1.GetDownloadURLRequest
+	(*GetDownloadURLResponse)(nil),           // 19: storage.v1.GetDownloadURLResponse
+	(*ReadObjectRequest)(nil),                // 20: storage.v1.ReadObjectRequest
+	(*ReadObjectResponse)(nil),               // 21: storage.v1.ReadObjectResponse
+	(*DeleteObjectRequest)(nil),              // 22: storage.v1.DeleteObjectRequest
+	(*DeleteObjectResponse)(nil),             // 23: storage.v1.DeleteObjectResponse
+	(*RewriteObjectRequest)(nil),             // 24: storage.v1.RewriteObjectRequest
+	(*RewriteObjectResponse)(nil),            // 25: storage.v1.RewriteObjectResponse
+	(*ListEarlyDeletionsRequest)(nil),        // 26: storage.v1.ListEarlyDeletionsRequest
+	(*EarlyDeletion)(nil),                    // 27: storage.v1.EarlyDeletion
+	(*ListEarlyDeletionsResponse)(nil),       // 28: storage.v1.ListEarlyDeletionsResponse
+	(*UpdateObjectRequest)(nil),              // 29: storage.v1.UpdateObjectRequest
+	(*UpdateObjectResponse)(nil),             // 30: storage.v1.UpdateObjectResponse
+	(*NotificationConfig)(nil),               // 31: storage.v1.NotificationConfig
+	(*CreateNotificationConfigRequest)(nil),  // 32: storage.v1.CreateNotificationConfigRequest
+	(*CreateNotificationConfigResponse)(nil), // 33: storage.v1.CreateNotificationConfigResponse
+	(*GetNotificationConfigRequest)(nil),     // 34: storage.v1.GetNotificationConfigRequest
+	(*GetNotificationConfigResponse)(nil),    // 35: storage.v1.GetNotificationConfigResponse
+	(*ListNotificationConfigsRequest)(nil),   // 36: storage.v1.ListNotificationConfigsRequest
+	(*ListNotificationConfigsResponse)(nil),  // 37: storage.v1.ListNotificationConfigsResponse
+	(*DeleteNotificationConfigRequest)(nil),  // 38: storage.v1.DeleteNotificationConfigRequest
+	(*DeleteNotificationConfigResponse)(nil), // 39: storage.v1.DeleteNotificationConfigResponse
+	(*Policy)(nil),                           // 40: storage.v1.Policy
+	(*Binding)(nil),                          // 41: storage.v1.Binding
+	(*GetIamPolicyRequest)(nil),              // 42: storage.v1.GetIamPolicyRequest
+	(*GetIamPolicyResponse)(nil),             // 43: storage.v1.GetIamPolicyResponse
+	(*SetIamPolicyRequest)(nil),              // 44: storage.v1.SetIamPolicyRequest
+	(*SetIamPolicyResponse)(nil),             // 45: storage.v1.SetIamPolicyResponse
+	(*TestIamPermissionsRequest)(nil),        // 46: storage.v1.TestIamPermissionsRequest
+	(*TestIamPermissionsResponse)(nil),       // 47: storage.v1.TestIamPermissionsResponse
+	(*AclEntry)(nil),                         // 48: storage.v1.AclEntry
+	(*ListAclRequest)(nil),                   // 49: storage.v1.ListAclRequest
+	(*ListAclResponse)(nil),                  // 50: storage.v1.ListAclResponse
+	(*InsertAclRequest)(nil),                 // 51: storage.v1.InsertAclRequest
+	(*InsertAclResponse)(nil),                // 52: storage.v1.InsertAclResponse
+	(*PatchAclRequest)(nil),                  // 53: storage.v1.PatchAclRequest
+	(*PatchAclResponse)(nil),                 // 54: storage.v1.PatchAclResponse
+	(*DeleteAclRequest)(nil),                 // 55: storage.v1.DeleteAclRequest
+	(*DeleteAclResponse)(nil),                // 56: storage.v1.DeleteAclResponse
+	(*RotateKmsKeyRequest)(nil),              // 57: storage.v1.RotateKmsKeyRequest
+	(*RotateKmsKeyResponse)(nil),             // 58: storage.v1.RotateKmsKeyResponse
+	(*ComposeObjectRequest)(nil),             // 59: storage.v1.ComposeObjectRequest
+	(*ComposeObjectResponse)(nil),            // 60: storage.v1.ComposeObjectResponse
+	(*StorageQuota)(nil),                     // 61: storage.v1.StorageQuota
+	(*GetStorageUsageRequest)(nil),           // 62: storage.v1.GetStorageUsageRequest
+	(*GetStorageUsageResponse)(nil),          // 63: storage.v1.GetStorageUsageResponse
+	(*WriteObjectRequest)(nil),               // 64: storage.v1.WriteObjectRequest
+	(*WriteObjectResponse)(nil),              // 65: storage.v1.WriteObjectResponse
+	(*ExportStateRequest)(nil),               // 66: storage.v1.ExportStateRequest
+	(*ExportStateResponse)(nil),              // 67: storage.v1.ExportStateResponse
+	(*ImportStateRequest)(nil),               // 68: storage.v1.ImportStateRequest
+	(*ImportStateResponse)(nil),              // 69: storage.v1.ImportStateResponse
+	(*SeedStorageRequest)(nil),               // 70: storage.v1.SeedStorageRequest
+	(*SeedStorageResponse)(nil),              // 71: storage.v1.SeedStorageResponse
+	(*AuditEntry)(nil),                       // 72: storage.v1.AuditEntry
+	(*QueryAuditLogRequest)(nil),             // 73: storage.v1.QueryAuditLogRequest
+	(*QueryAuditLogResponse)(nil),            // 74: storage.v1.QueryAuditLogResponse
+	(*FaultRule)(nil),                        // 75: storage.v1.FaultRule
+	(*SetFaultRulesRequest)(nil),             // 76: storage.v1.SetFaultRulesRequest
+	(*SetFaultRulesResponse)(nil),            // 77: storage.v1.SetFaultRulesResponse
+	(*ListFaultRulesRequest)(nil),            // 78: storage.v1.ListFaultRulesRequest
+	(*ListFaultRulesResponse)(nil),           // 79: storage.v1.ListFaultRulesResponse
+	nil,                                      // 80: storage.v1.Bucket.LabelsEntry
+	nil,                                      // 81: storage.v1.Bucket.DefaultObjectMetadataEntry
+	(*LifecycleRule_Action)(nil),             // 82: storage.v1.LifecycleRule.Action
+	(*LifecycleRule_Condition)(nil),          // 83: storage.v1.LifecycleRule.Condition
+	nil,                                      // 84: storage.v1.CreateBucketRequest.LabelsEntry
+	nil,                                      // 85: storage.v1.CreateBucketRequest.DefaultObjectMetadataEntry
+	nil,                                      // 86: storage.v1.ListBucketsRequest.LabelsEntry
+	nil,                                      // 87: storage.v1.UploadObjectRequest.MetadataEntry
+	nil,                                      // 88: storage.v1.GetObjectMetadataResponse.MetadataEntry
+	nil,                                      // 89: storage.v1.UpdateObjectRequest.MetadataEntry
+	nil,                                      // 90: storage.v1.NotificationConfig.CustomAttributesEntry
+	nil,                                      // 91: storage.v1.ComposeObjectRequest.MetadataEntry
+	(*timestamppb.Timestamp)(nil),            // 92: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),            // 93: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),              // 94: google.protobuf.Duration
+}
+var file_v1_storage_storage_proto_depIdxs = []int32{
+	80, // 0: storage.v1.Bucket.labels:type_name -> storage.v1.Bucket.LabelsEntry
+	81, // 1: storage.v1.Bucket.default_object_metadata:type_name -> storage.v1.Bucket.DefaultObjectMetadataEntry
+	92, // 2: storage.v1.Bucket.create_time:type_name -> google.protobuf.Timestamp
+	92, // 3: storage.v1.Bucket.update_time:type_name -> google.protobuf.Timestamp
+	1,  // 4: storage.v1.Bucket.lifecycle_rules:type_name -> storage.v1.LifecycleRule
+	48, // 5: storage.v1.Bucket.acl:type_name -> storage.v1.AclEntry
+	48, // 6: storage.v1.Bucket.default_object_acl:type_name -> storage.v1.AclEntry
+	82, // 7: storage.v1.LifecycleRule.action:type_name -> storage.v1.LifecycleRule.Action
+	83, // 8: storage.v1.LifecycleRule.condition:type_name -> storage.v1.LifecycleRule.Condition
+	84, // 9: storage.v1.CreateBucketRequest.labels:type_name -> storage.v1.CreateBucketRequest.LabelsEntry
+	85, // 10: storage.v1.CreateBucketRequest.default_object_metadata:type_name -> storage.v1.CreateBucketRequest.DefaultObjectMetadataEntry
+	1,  // 11: storage.v1.CreateBucketRequest.lifecycle_rules:type_name -> storage.v1.LifecycleRule
+	0,  // 12: storage.v1.CreateBucketResponse.bucket:type_name -> storage.v1.Bucket
+	0,  // 13: storage.v1.GetBucketResponse.bucket:type_name -> storage.v1.Bucket
+	86, // 14: storage.v1.ListBucketsRequest.labels:type_name -> storage.v1.ListBucketsRequest.LabelsEntry
+	0,  // 15: storage.v1.ListBucketsResponse.buckets:type_name -> storage.v1.Bucket
+	0,  // 16: storage.v1.UpdateBucketRequest.bucket:type_name -> storage.v1.Bucket
+	93, // 17: storage.v1.UpdateBucketRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 18: storage.v1.UpdateBucketResponse.bucket:type_name -> storage.v1.Bucket
+	87, // 19: storage.v1.UploadObjectRequest.metadata:type_name -> storage.v1.UploadObjectRequest.MetadataEntry
+	11, // 20: storage.v1.UploadObjectRequest.common_object_request_params:type_name -> storage.v1.CommonObjectRequestParams
+	88, // 21: storage.v1.GetObjectMetadataResponse.metadata:type_name -> storage.v1.GetObjectMetadataResponse.MetadataEntry
+	92, // 22: storage.v1.GetObjectMetadataResponse.create_time:type_name -> google.protobuf.Timestamp
+	92, // 23: storage.v1.GetObjectMetadataResponse.update_time:type_name -> google.protobuf.Timestamp
+	92, // 24: storage.v1.GetObjectMetadataResponse.storage_class_update_time:type_name -> google.protobuf.Timestamp
+	48, // 25: storage.v1.GetObjectMetadataResponse.acl:type_name -> storage.v1.AclEntry
+	12, // 26: storage.v1.GetObjectMetadataResponse.customer_encryption:type_name -> storage.v1.CustomerEncryption
+	11, // 27: storage.v1.ReadObjectRequest.common_object_request_params:type_name -> storage.v1.CommonObjectRequestParams
+	11, // 28: storage.v1.RewriteObjectRequest.common_object_request_params:type_name -> storage.v1.CommonObjectRequestParams
+	15, // 29: storage.v1.RewriteObjectResponse.resource:type_name -> storage.v1.GetObjectMetadataResponse
+	92, // 30: storage.v1.EarlyDeletion.delete_time:type_name -> google.protobuf.Timestamp
+	27, // 31: storage.v1.ListEarlyDeletionsResponse.early_deletions:type_name -> storage.v1.EarlyDeletion
+	89, // 32: storage.v1.UpdateObjectRequest.metadata:type_name -> storage.v1.UpdateObjectRequest.MetadataEntry
+	93, // 33: storage.v1.UpdateObjectRequest.update_mask:type_name -> google.protobuf.FieldMask
+	15, // 34: storage.v1.UpdateObjectResponse.resource:type_name -> storage.v1.GetObjectMetadataResponse
+	90, // 35: storage.v1.NotificationConfig.custom_attributes:type_name -> storage.v1.NotificationConfig.CustomAttributesEntry
+	31, // 36: storage.v1.CreateNotificationConfigRequest.notification_config:type_name -> storage.v1.NotificationConfig
+	31, // 37: storage.v1.CreateNotificationConfigResponse.notification_config:type_name -> storage.v1.NotificationConfig
+	31, // 38: storage.v1.GetNotificationConfigResponse.notification_config:type_name -> storage.v1.NotificationConfig
+	31, // 39: storage.v1.ListNotificationConfigsResponse.notification_configs:type_name -> storage.v1.NotificationConfig
+	41, // 40: storage.v1.Policy.bindings:type_name -> storage.v1.Binding
+	40, // 41: storage.v1.GetIamPolicyResponse.policy:type_name -> storage.v1.Policy
+	40, // 42: storage.v1.SetIamPolicyRequest.policy:type_name -> storage.v1.Policy
+	40, // 43: storage.v1.SetIamPolicyResponse.policy:type_name -> storage.v1.Policy
+	48, // 44: storage.v1.ListAclResponse.entries:type_name -> storage.v1.AclEntry
+	48, // 45: storage.v1.InsertAclRequest.entry:type_name -> storage.v1.AclEntry
+	48, // 46: storage.v1.InsertAclResponse.entry:type_name -> storage.v1.AclEntry
+	48, // 47: storage.v1.PatchAclRequest.entry:type_name -> storage.v1.AclEntry
+	48, // 48: storage.v1.PatchAclResponse.entry:type_name -> storage.v1.AclEntry
+	91, // 49: storage.v1.ComposeObjectRequest.metadata:type_name -> storage.v1.ComposeObjectRequest.MetadataEntry
+	11, // 50: storage.v1.ComposeObjectRequest.common_object_request_params:type_name -> storage.v1.CommonObjectRequestParams
+	15, // 51: storage.v1.ComposeObjectResponse.resource:type_name -> storage.v1.GetObjectMetadataResponse
+	61, // 52: storage.v1.GetStorageUsageResponse.quota:type_name -> storage.v1.StorageQuota
+	10, // 53: storage.v1.WriteObjectRequest.spec:type_name -> storage.v1.UploadObjectRequest
+	92, // 54: storage.v1.AuditEntry.time:type_name -> google.protobuf.Timestamp
+	92, // 55: storage.v1.QueryAuditLogRequest.start_time:type_name -> google.protobuf.Timestamp
+	92, // 56: storage.v1.QueryAuditLogRequest.end_time:type_name -> google.protobuf.Timestamp
+	72, // 57: storage.v1.QueryAuditLogResponse.entries:type_name -> storage.v1.AuditEntry
+	94, // 58: storage.v1.FaultRule.latency:type_name -> google.protobuf.Duration
+	75, // 59: storage.v1.SetFaultRulesRequest.rules:type_name -> storage.v1.FaultRule
+	75, // 60: storage.v1.SetFaultRulesResponse.rules:type_name -> storage.v1.FaultRule
+	75, // 61: storage.v1.ListFaultRulesResponse.rules:type_name -> storage.v1.FaultRule
+	2,  // 62: storage.v1.StorageService.CreateBucket:input_type -> storage.v1.CreateBucketRequest
+	10, // 63: storage.v1.StorageService.UploadObject:input_type -> storage.v1.UploadObjectRequest
+	14, // 64: storage.v1.StorageService.GetObjectMetadata:input_type -> storage.v1.GetObjectMetadataRequest
+	16, // 65: storage.v1.StorageService.ListObjects:input_type -> storage.v1.ListObjectsRequest
+	18, // 66: storage.v1.StorageService.GetDownloadURL:input_type -> storage.v1.GetDownloadURLRequest
+	4,  // 67: storage.v1.StorageService.GetBucket:input_type -> storage.v1.GetBucketRequest
+	6,  // 68: storage.v1.StorageService.ListBuckets:input_type -> storage.v1.ListBucketsRequest
+	8,  // 69: storage.v1.StorageService.UpdateBucket:input_type -> storage.v1.UpdateBucketRequest
+	20, // 70: storage.v1.StorageService.ReadObject:input_type -> storage.v1.ReadObjectRequest
+	22, // 71: storage.v1.StorageService.DeleteObject:input_type -> storage.v1.DeleteObjectRequest
+	24, // 72: storage.v1.StorageService.RewriteObject:input_type -> storage.v1.RewriteObjectRequest
+	26, // 73: storage.v1.StorageService.ListEarlyDeletions:input_type -> storage.v1.ListEarlyDeletionsRequest
+	29, // 74: storage.v1.StorageService.UpdateObject:input_type -> storage.v1.UpdateObjectRequest
+	32, // 75: storage.v1.StorageService.CreateNotificationConfig:input_type -> storage.v1.CreateNotificationConfigRequest
+	34, // 76: storage.v1.StorageService.GetNotificationConfig:input_type -> storage.v1.GetNotificationConfigRequest
+	36, // 77: storage.v1.StorageService.ListNotificationConfigs:input_type -> storage.v1.ListNotificationConfigsRequest
+	38, // 78: storage.v1.StorageService.DeleteNotificationConfig:input_type -> storage.v1.DeleteNotificationConfigRequest
+	42, // 79: storage.v1.StorageService.GetIamPolicy:input_type -> storage.v1.GetIamPolicyRequest
+	44, // 80: storage.v1.StorageService.SetIamPolicy:input_type -> storage.v1.SetIamPolicyRequest
+	46, // 81: storage.v1.StorageService.TestIamPermissions:input_type -> storage.v1.TestIamPermissionsRequest
+	49, // 82: storage.v1.StorageService.ListAcl:input_type -> storage.v1.ListAclRequest
+	51, // 83: storage.v1.StorageService.InsertAcl:input_type -> storage.v1.InsertAclRequest
+	53, // 84: storage.v1.StorageService.PatchAcl:input_type -> storage.v1.PatchAclRequest
+	55, // 85: storage.v1.StorageService.DeleteAcl:input_type -> storage.v1.DeleteAclRequest
+	57, // 86: storage.v1.StorageService.RotateKmsKey:input_type -> storage.v1.RotateKmsKeyRequest
+	59, // 87: storage.v1.StorageService.ComposeObject:input_type -> storage.v1.ComposeObjectRequest
+	62, // 88: storage.v1.StorageService.GetStorageUsage:input_type -> storage.v1.GetStorageUsageRequest
+	64, // 89: storage.v1.StorageService.WriteObject:input_type -> storage.v1.WriteObjectRequest
+	66, // 90: storage.v1.StorageService.ExportState:input_type -> storage.v1.ExportStateRequest
+	68, // 91: storage.v1.StorageService.ImportState:input_type -> storage.v1.ImportStateRequest
+	70, // 92: storage.v1.StorageService.SeedStorage:input_type -> storage.v1.SeedStorageRequest
+	73, // 93: storage.v1.StorageService.QueryAuditLog:input_type -> storage.v1.QueryAuditLogRequest
+	76, // 94: storage.v1.StorageService.SetFaultRules:input_type -> storage.v1.SetFaultRulesRequest
+	78, // 95: storage.v1.StorageService.ListFaultRules:input_type -> storage.v1.ListFaultRulesRequest
+	3,  // 96: storage.v1.StorageService.CreateBucket:output_type -> storage.v1.CreateBucketResponse
+	13, // 97: storage.v1.StorageService.UploadObject:output_type -> storage.v1.UploadObjectResponse
+	15, // 98: storage.v1.StorageService.GetObjectMetadata:output_type -> storage.v1.GetObjectMetadataResponse
+	17, // 99: storage.v1.StorageService.ListObjects:output_type -> storage.v1.ListObjectsResponse
+	19, // 100: storage.v1.StorageService.GetDownloadURL:output_type -> storage.v1.GetDownloadURLResponse
+	5,  // 101: storage.v1.StorageService.GetBucket:output_type -> storage.v1.GetBucketResponse
+	7,  // 102: storage.v1.StorageService.ListBuckets:output_type -> storage.v1.ListBucketsResponse
+	9,  // 103: storage.v1.StorageService.UpdateBucket:output_type -> storage.v1.UpdateBucketResponse
+	21, // 104: storage.v1.StorageService.ReadObject:output_type -> storage.v1.ReadObjectResponse
+	23, // 105: storage.v1.StorageService.DeleteObject:output_type -> storage.v1.DeleteObjectResponse
+	25, // 106: storage.v1.StorageService.RewriteObject:output_type -> storage.v1.RewriteObjectResponse
+	28, // 107: storage.v1.StorageService.ListEarlyDeletions:output_type -> storage.v1.ListEarlyDeletionsResponse
+	30, // 108: storage.v1.StorageService.UpdateObject:output_type -> storage.v1.UpdateObjectResponse
+	33, // 109: storage.v1.StorageService.CreateNotificationConfig:output_type -> storage.v1.CreateNotificationConfigResponse
+	35, // 110: storage.v1.StorageService.GetNotificationConfig:output_type -> storage.v1.GetNotificationConfigResponse
+	37, // 111: storage.v1.StorageService.ListNotificationConfigs:output_type -> storage.v1.ListNotificationConfigsResponse
+	39, // 112: storage.v1.StorageService.DeleteNotificationConfig:output_type -> storage.v1.DeleteNotificationConfigResponse
+	43, // 113: storage.v1.StorageService.GetIamPolicy:output_type -> storage.v1.GetIamPolicyResponse
+	45, // 114: storage.v1.StorageService.SetIamPolicy:output_type -> storage.v1.SetIamPolicyResponse
+	47, // 115: storage.v1.StorageService.TestIamPermissions:output_type -> storage.v1.TestIamPermissionsResponse
+	50, // 116: storage.v1.StorageService.ListAcl:output_type -> storage.v1.ListAclResponse
+	52, // 117: storage.v1.StorageService.InsertAcl:output_type -> storage.v1.InsertAclResponse
+	54, // 118: storage.v1.StorageService.PatchAcl:output_type -> storage.v1.PatchAclResponse
+	56, // 119: storage.v1.StorageService.DeleteAcl:output_type -> storage.v1.DeleteAclResponse
+	58, // 120: storage.v1.StorageService.RotateKmsKey:output_type -> storage.v1.RotateKmsKeyResponse
+	60, // 121: storage.v1.StorageService.ComposeObject:output_type -> storage.v1.ComposeObjectResponse
+	63, // 122: storage.v1.StorageService.GetStorageUsage:output_type -> storage.v1.GetStorageUsageResponse
+	65, // 123: storage.v1.StorageService.WriteObject:output_type -> storage.v1.WriteObjectResponse
+	67, // 124: storage.v1.StorageService.ExportState:output_type -> storage.v1.ExportStateResponse
+	69, // 125: storage.v1.StorageService.ImportState:output_type -> storage.v1.ImportStateResponse
+	71, // 126: storage.v1.StorageService.SeedStorage:output_type -> storage.v1.SeedStorageResponse
+	74, // 127: storage.v1.StorageService.QueryAuditLog:output_type -> storage.v1.QueryAuditLogResponse
+	77, // 128: storage.v1.StorageService.SetFaultRules:output_type -> storage.v1.SetFaultRulesResponse
+	79, // 129: storage.v1.StorageService.ListFaultRules:output_type -> storage.v1.ListFaultRulesResponse
+	96, // [96:130] is the sub-list for method output_type
+	62, // [62:96] is the sub-list for method input_type
+	62, // [62:62] is the sub-list for extension type_name
+	62, // [62:62] is the sub-list for extension extendee
+	0,  // [0:62] is the sub-list for field type_name
+}
+
+func init() { file_v1_storage_storage_proto_init() }
+func file_v1_storage_storage_proto_init() {
+	if File_v1_storage_storage_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_storage_storage_proto_rawDesc), len(file_v1_storage_storage_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   92,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v1_storage_storage_proto_goTypes,
+		DependencyIndexes: file_v1_storage_storage_proto_depIdxs,
+		MessageInfos:      file_v1_storage_storage_proto_msgTypes,
+	}.Build()
+	File_v1_storage_storage_proto = out.File
+	file_v1_storage_storage_proto_goTypes = nil
+	file_v1_storage_storage_proto_depIdxs = nil
+}
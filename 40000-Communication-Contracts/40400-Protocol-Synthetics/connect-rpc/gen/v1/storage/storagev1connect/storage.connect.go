@@ -1,225 +1,1064 @@
-// Code generated by protoc-gen-connect-go. DO NOT EDIT.
-//
-// Source: v1/storage/storage.proto
-
-package storagev1connect
-
-import (
-	storage "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
-	connect "connectrpc.com/connect"
-	context "context"
-	errors "errors"
-	http "net/http"
-	strings "strings"
-)
-
-// This is a compile-time assertion to ensure that this generated file and the connect package are
-// compatible. If you get a compiler error that this constant is not defined, this code was
-// generated with a version of connect newer than the one compiled into your binary. You can fix the
-// problem by either regenerating this code with an older version of connect or updating the connect
-// version compiled into your binary.
-const _ = connect.IsAtLeastVersion1_13_0
-
-const (
-	// StorageServiceName is the fully-qualified name of the StorageService service.
-	StorageServiceName = "storage.v1.StorageService"
-)
-
-// These constants are the fully-qualified names of the RPCs defined in this package. They're
-// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
-//
-// Note that these are different from the fully-qualified method names used by
-// google.protobuf/reflect/protoreflect. To convert from these constants to
-// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
-// period.
-const (
-	// StorageServiceCreateBucketProcedure is the fully-qualified name of the StorageService's
-	// CreateBucket RPC.
-	StorageServiceCreateBucketProcedure = "/storage.v1.StorageService/CreateBucket"
-	// StorageServiceUploadObjectProcedure is the fully-qualified name of the StorageService's
-	// UploadObject RPC.
-	StorageServiceUploadObjectProcedure = "/storage.v1.StorageService/UploadObject"
-	// StorageServiceGetObjectMetadataProcedure is the fully-qualified name of the StorageService's
-	// GetObjectMetadata RPC.
-	StorageServiceGetObjectMetadataProcedure = "/storage.v1.StorageService/GetObjectMetadata"
-	// StorageServiceListObjectsProcedure is the fully-qualified name of the StorageService's
-	// ListObjects RPC.
-	StorageServiceListObjectsProcedure = "/storage.v1.StorageService/ListObjects"
-	// StorageServiceGetDownloadURLProcedure is the fully-qualified name of the StorageService's
-	// GetDownloadURL RPC.
-	StorageServiceGetDownloadURLProcedure = "/storage.v1.StorageService/GetDownloadURL"
-)
-
-// StorageServiceClient is a client for the storage.v1.StorageService service.
-type StorageServiceClient interface {
-	CreateBucket(context.Context, *connect.Request[storage.CreateBucketRequest]) (*connect.Response[storage.CreateBucketResponse], error)
-	UploadObject(context.Context, *connect.Request[storage.UploadObjectRequest]) (*connect.Response[storage.UploadObjectResponse], error)
-	GetObjectMetadata(context.Context, *connect.Request[storage.GetObjectMetadataRequest]) (*connect.Response[storage.GetObjectMetadataResponse], error)
-	ListObjects(context.Context, *connect.Request[storage.ListObjectsRequest]) (*connect.Response[storage.ListObjectsResponse], error)
-	GetDownloadURL(context.Context, *connect.Request[storage.GetDownloadURLRequest]) (*connect.Response[storage.GetDownloadURLResponse], error)
-}
-
-// NewStorageServiceClient constructs a client for the storage.v1.StorageService service. By
-// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
-// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
-// connect.WithGRPC() or connect.WithGRPCWeb() options.
-//
-// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
-// http://api.acme.com or https://acme.com/grpc).
-func NewStorageServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) StorageServiceClient {
-	baseURL = strings.TrimRight(baseURL, "/")
-	storageServiceMethods := storage.File_v1_storage_storage_proto.Services().ByName("StorageService").Methods()
-	return &storageServiceClient{
-		createBucket: connect.NewClient[storage.CreateBucketRequest, storage.CreateBucketResponse](
-			httpClient,
-			baseURL+StorageServiceCreateBucketProcedure,
-			connect.WithSchema(storageServiceMethods.ByName("CreateBucket")),
-			connect.WithClientOptions(opts...),
-		),
-		uploadObject: connect.NewClient[storage.UploadObjectRequest, storage.UploadObjectResponse](
-			httpClient,
-			baseURL+StorageServiceUploadObjectProcedure,
-			connect.WithSchema(storageServiceMethods.ByName("UploadObject")),
-			connect.WithClientOptions(opts...),
-		),
-		getObjectMetadata: connect.NewClient[storage.GetObjectMetadataRequest, storage.GetObjectMetadataResponse](
-			httpClient,
-			baseURL+StorageServiceGetObjectMetadataProcedure,
-			connect.WithSchema(storageServiceMethods.ByName("GetObjectMetadata")),
-			connect.WithClientOptions(opts...),
-		),
-		listObjects: connect.NewClient[storage.ListObjectsRequest, storage.ListObjectsResponse](
-			httpClient,
-			baseURL+StorageServiceListObjectsProcedure,
-			connect.WithSchema(storageServiceMethods.ByName("ListObjects")),
-			connect.WithClientOptions(opts...),
-		),
-		getDownloadURL: connect.NewClient[storage.GetDownloadURLRequest, storage.GetDownloadURLResponse](
-			httpClient,
-			baseURL+StorageServiceGetDownloadURLProcedure,
-			connect.WithSchema(storageServiceMethods.ByName("GetDownloadURL")),
-			connect.WithClientOptions(opts...),
-		),
-	}
-}
-
-// storageServiceClient implements StorageServiceClient.
-type storageServiceClient struct {
-	createBucket      *connect.Client[storage.CreateBucketRequest, storage.CreateBucketResponse]
-	uploadObject      *connect.Client[storage.UploadObjectRequest, storage.UploadObjectResponse]
-	getObjectMetadata *connect.Client[storage.GetObjectMetadataRequest, storage.GetObjectMetadataResponse]
-	listObjects       *connect.Client[storage.ListObjectsRequest, storage.ListObjectsResponse]
-	getDownloadURL    *connect.Client[storage.GetDownloadURLRequest, storage.GetDownloadURLResponse]
-}
-
-// CreateBucket calls storage.v1.StorageService.CreateBucket.
-func (c *storageServiceClient) CreateBucket(ctx context.Context, req *connect.Request[storage.CreateBucketRequest]) (*connect.Response[storage.CreateBucketResponse], error) {
-	return c.createBucket.CallUnary(ctx, req)
-}
-
-// UploadObject calls storage.v1.StorageService.UploadObject.
-func (c *storageServiceClient) UploadObject(ctx context.Context, req *connect.Request[storage.UploadObjectRequest]) (*connect.Response[storage.UploadObjectResponse], error) {
-	return c.uploadObject.CallUnary(ctx, req)
-}
-
-// GetObjectMetadata calls storage.v1.StorageService.GetObjectMetadata.
-func (c *storageServiceClient) GetObjectMetadata(ctx context.Context, req *connect.Request[storage.GetObjectMetadataRequest]) (*connect.Response[storage.GetObjectMetadataResponse], error) {
-	return c.getObjectMetadata.CallUnary(ctx, req)
-}
-
-// ListObjects calls storage.v1.StorageService.ListObjects.
-func (c *storageServiceClient) ListObjects(ctx context.Context, req *connect.Request[storage.ListObjectsRequest]) (*connect.Response[storage.ListObjectsResponse], error) {
-	return c.listObjects.CallUnary(ctx, req)
-}
-
-// GetDownloadURL calls storage.v1.StorageService.GetDownloadURL.
-func (c *storageServiceClient) GetDownloadURL(ctx context.Context, req *connect.Request[storage.GetDownloadURLRequest]) (*connect.Response[storage.GetDownloadURLResponse], error) {
-	return c.getDownloadURL.CallUnary(ctx, req)
-}
-
-// StorageServiceHandler is an implementation of the storage.v1.StorageService service.
-type StorageServiceHandler interface {
-	CreateBucket(context.Context, *connect.Request[storage.CreateBucketRequest]) (*connect.Response[storage.CreateBucketResponse], error)
-	UploadObject(context.Context, *connect.Request[storage.UploadObjectRequest]) (*connect.Response[storage.UploadObjectResponse], error)
-	GetObjectMetadata(context.Context, *connect.Request[storage.GetObjectMetadataRequest]) (*connect.Response[storage.GetObjectMetadataResponse], error)
-	ListObjects(context.Context, *connect.Request[storage.ListObjectsRequest]) (*connect.Response[storage.ListObjectsResponse], error)
-	GetDownloadURL(context.Context, *connect.Request[storage.GetDownloadURLRequest]) (*connect.Response[storage.GetDownloadURLResponse], error)
-}
-
-// NewStorageServiceHandler builds an HTTP handler from the service implementation. It returns the
-// path on which to mount the handler and the handler itself.
-//
-// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
-// and JSON codecs. They also support gzip compression.
-func NewStorageServiceHandler(svc StorageServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
-	storageServiceMethods := storage.File_v1_storage_storage_proto.Services().ByName("StorageService").Methods()
-	storageServiceCreateBucketHandler := connect.NewUnaryHandler(
-		StorageServiceCreateBucketProcedure,
-		svc.CreateBucket,
-		connect.WithSchema(storageServiceMethods.ByName("CreateBucket")),
-		connect.WithHandlerOptions(opts...),
-	)
-	storageServiceUploadObjectHandler := connect.NewUnaryHandler(
-		StorageServiceUploadObjectProcedure,
-		svc.UploadObject,
-		connect.WithSchema(storageServiceMethods.ByName("UploadObject")),
-		connect.WithHandlerOptions(opts...),
-	)
-	storageServiceGetObjectMetadataHandler := connect.NewUnaryHandler(
-		StorageServiceGetObjectMetadataProcedure,
-		svc.GetObjectMetadata,
-		connect.WithSchema(storageServiceMethods.ByName("GetObjectMetadata")),
-		connect.WithHandlerOptions(opts...),
-	)
-	storageServiceListObjectsHandler := connect.NewUnaryHandler(
-		StorageServiceListObjectsProcedure,
-		svc.ListObjects,
-		connect.WithSchema(storageServiceMethods.ByName("ListObjects")),
-		connect.WithHandlerOptions(opts...),
-	)
-	storageServiceGetDownloadURLHandler := connect.NewUnaryHandler(
-		StorageServiceGetDownloadURLProcedure,
-		svc.GetDownloadURL,
-		connect.WithSchema(storageServiceMethods.ByName("GetDownloadURL")),
-		connect.WithHandlerOptions(opts...),
-	)
-	return "/storage.v1.StorageService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch r.URL.Path {
-		case StorageServiceCreateBucketProcedure:
-			storageServiceCreateBucketHandler.ServeHTTP(w, r)
-		case StorageServiceUploadObjectProcedure:
-			storageServiceUploadObjectHandler.ServeHTTP(w, r)
-		case StorageServiceGetObjectMetadataProcedure:
-			storageServiceGetObjectMetadataHandler.ServeHTTP(w, r)
-		case StorageServiceListObjectsProcedure:
-			storageServiceListObjectsHandler.ServeHTTP(w, r)
-		case StorageServiceGetDownloadURLProcedure:
-			storageServiceGetDownloadURLHandler.ServeHTTP(w, r)
-		default:
-			http.NotFound(w, r)
-		}
-	})
-}
-
-// UnimplementedStorageServiceHandler returns CodeUnimplemented from all methods.
-type UnimplementedStorageServiceHandler struct{}
-
-func (UnimplementedStorageServiceHandler) CreateBucket(context.Context, *connect.Request[storage.CreateBucketRequest]) (*connect.Response[storage.CreateBucketResponse], error) {
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.CreateBucket is not implemented"))
-}
-
-func (UnimplementedStorageServiceHandler) UploadObject(context.Context, *connect.Request[storage.UploadObjectRequest]) (*connect.Response[storage.UploadObjectResponse], error) {
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.UploadObject is not implemented"))
-}
-
-func (UnimplementedStorageServiceHandler) GetObjectMetadata(context.Context, *connect.Request[storage.GetObjectMetadataRequest]) (*connect.Response[storage.GetObjectMetadataResponse], error) {
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.GetObjectMetadata is not implemented"))
-}
-
-func (UnimplementedStorageServiceHandler) ListObjects(context.Context, *connect.Request[storage.ListObjectsRequest]) (*connect.Response[storage.ListObjectsResponse], error) {
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ListObjects is not implemented"))
-}
-
-func (UnimplementedStorageServiceHandler) GetDownloadURL(context.Context, *connect.Request[storage.GetDownloadURLRequest]) (*connect.Response[storage.GetDownloadURLResponse], error) {
-	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.GetDownloadURL is not implemented"))
-}
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: v1/storage/storage.proto
+
+package storagev1connect
+
+import (
+	storage "olympus.fleet/00SDLC/OlympusGCP-Storage/40000-Communication-Contracts/40400-Protocol-Synthetics/connect-rpc/gen/v1/storage"
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// StorageServiceName is the fully-qualified name of the StorageService service.
+	StorageServiceName = "storage.v1.StorageService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// StorageServiceCreateBucketProcedure is the fully-qualified name of the StorageService's
+	// CreateBucket RPC.
+	StorageServiceCreateBucketProcedure = "/storage.v1.StorageService/CreateBucket"
+	// StorageServiceUploadObjectProcedure is the fully-qualified name of the StorageService's
+	// UploadObject RPC.
+	StorageServiceUploadObjectProcedure = "/storage.v1.StorageService/UploadObject"
+	// StorageServiceGetObjectMetadataProcedure is the fully-qualified name of the StorageService's
+	// GetObjectMetadata RPC.
+	StorageServiceGetObjectMetadataProcedure = "/storage.v1.StorageService/GetObjectMetadata"
+	// StorageServiceListObjectsProcedure is the fully-qualified name of the StorageService's
+	// ListObjects RPC.
+	StorageServiceListObjectsProcedure = "/storage.v1.StorageService/ListObjects"
+	// StorageServiceGetDownloadURLProcedure is the fully-qualified name of the StorageService's
+	// GetDownloadURL RPC.
+	StorageServiceGetDownloadURLProcedure = "/storage.v1.StorageService/GetDownloadURL"
+	// StorageServiceGetBucketProcedure is the fully-qualified name of the StorageService's GetBucket
+	// RPC.
+	StorageServiceGetBucketProcedure = "/storage.v1.StorageService/GetBucket"
+	// StorageServiceListBucketsProcedure is the fully-qualified name of the StorageService's
+	// ListBuckets RPC.
+	StorageServiceListBucketsProcedure = "/storage.v1.StorageService/ListBuckets"
+	// StorageServiceUpdateBucketProcedure is the fully-qualified name of the StorageService's
+	// UpdateBucket RPC.
+	StorageServiceUpdateBucketProcedure = "/storage.v1.StorageService/UpdateBucket"
+	// StorageServiceReadObjectProcedure is the fully-qualified name of the StorageService's ReadObject
+	// RPC.
+	StorageServiceReadObjectProcedure = "/storage.v1.StorageService/ReadObject"
+	// StorageServiceDeleteObjectProcedure is the fully-qualified name of the StorageService's
+	// DeleteObject RPC.
+	StorageServiceDeleteObjectProcedure = "/storage.v1.StorageService/DeleteObject"
+	// StorageServiceRewriteObjectProcedure is the fully-qualified name of the StorageService's
+	// RewriteObject RPC.
+	StorageServiceRewriteObjectProcedure = "/storage.v1.StorageService/RewriteObject"
+	// StorageServiceListEarlyDeletionsProcedure is the fully-qualified name of the StorageService's
+	// ListEarlyDeletions RPC.
+	StorageServiceListEarlyDeletionsProcedure = "/storage.v1.StorageService/ListEarlyDeletions"
+	// StorageServiceUpdateObjectProcedure is the fully-qualified name of the StorageService's
+	// UpdateObject RPC.
+	StorageServiceUpdateObjectProcedure = "/storage.v1.StorageService/UpdateObject"
+	// StorageServiceCreateNotificationConfigProcedure is the fully-qualified name of the
+	// StorageService's CreateNotificationConfig RPC.
+	StorageServiceCreateNotificationConfigProcedure = "/storage.v1.StorageService/CreateNotificationConfig"
+	// StorageServiceGetNotificationConfigProcedure is the fully-qualified name of the StorageService's
+	// GetNotificationConfig RPC.
+	StorageServiceGetNotificationConfigProcedure = "/storage.v1.StorageService/GetNotificationConfig"
+	// StorageServiceListNotificationConfigsProcedure is the fully-qualified name of the
+	// StorageService's ListNotificationConfigs RPC.
+	StorageServiceListNotificationConfigsProcedure = "/storage.v1.StorageService/ListNotificationConfigs"
+	// StorageServiceDeleteNotificationConfigProcedure is the fully-qualified name of the
+	// StorageService's DeleteNotificationConfig RPC.
+	StorageServiceDeleteNotificationConfigProcedure = "/storage.v1.StorageService/DeleteNotificationConfig"
+	// StorageServiceGetIamPolicyProcedure is the fully-qualified name of the StorageService's
+	// GetIamPolicy RPC.
+	StorageServiceGetIamPolicyProcedure = "/storage.v1.StorageService/GetIamPolicy"
+	// StorageServiceSetIamPolicyProcedure is the fully-qualified name of the StorageService's
+	// SetIamPolicy RPC.
+	StorageServiceSetIamPolicyProcedure = "/storage.v1.StorageService/SetIamPolicy"
+	// StorageServiceTestIamPermissionsProcedure is the fully-qualified name of the StorageService's
+	// TestIamPermissions RPC.
+	StorageServiceTestIamPermissionsProcedure = "/storage.v1.StorageService/TestIamPermissions"
+	// StorageServiceListAclProcedure is the fully-qualified name of the StorageService's ListAcl RPC.
+	StorageServiceListAclProcedure = "/storage.v1.StorageService/ListAcl"
+	// StorageServiceInsertAclProcedure is the fully-qualified name of the StorageService's InsertAcl
+	// RPC.
+	StorageServiceInsertAclProcedure = "/storage.v1.StorageService/InsertAcl"
+	// StorageServicePatchAclProcedure is the fully-qualified name of the StorageService's PatchAcl RPC.
+	StorageServicePatchAclProcedure = "/storage.v1.StorageService/PatchAcl"
+	// StorageServiceDeleteAclProcedure is the fully-qualified name of the StorageService's DeleteAcl
+	// RPC.
+	StorageServiceDeleteAclProcedure = "/storage.v1.StorageService/DeleteAcl"
+	// StorageServiceRotateKmsKeyProcedure is the fully-qualified name of the StorageService's
+	// RotateKmsKey RPC.
+	StorageServiceRotateKmsKeyProcedure = "/storage.v1.StorageService/RotateKmsKey"
+	// StorageServiceComposeObjectProcedure is the fully-qualified name of the StorageService's
+	// ComposeObject RPC.
+	StorageServiceComposeObjectProcedure = "/storage.v1.StorageService/ComposeObject"
+	// StorageServiceGetStorageUsageProcedure is the fully-qualified name of the StorageService's
+	// GetStorageUsage RPC.
+	StorageServiceGetStorageUsageProcedure = "/storage.v1.StorageService/GetStorageUsage"
+	// StorageServiceWriteObjectProcedure is the fully-qualified name of the StorageService's
+	// WriteObject RPC.
+	StorageServiceWriteObjectProcedure = "/storage.v1.StorageService/WriteObject"
+	// StorageServiceExportStateProcedure is the fully-qualified name of the StorageService's
+	// ExportState RPC.
+	StorageServiceExportStateProcedure = "/storage.v1.StorageService/ExportState"
+	// StorageServiceImportStateProcedure is the fully-qualified name of the StorageService's
+	// ImportState RPC.
+	StorageServiceImportStateProcedure = "/storage.v1.StorageService/ImportState"
+	// StorageServiceSeedStorageProcedure is the fully-qualified name of the StorageService's
+	// SeedStorage RPC.
+	StorageServiceSeedStorageProcedure = "/storage.v1.StorageService/SeedStorage"
+	// StorageServiceQueryAuditLogProcedure is the fully-qualified name of the StorageService's
+	// QueryAuditLog RPC.
+	StorageServiceQueryAuditLogProcedure = "/storage.v1.StorageService/QueryAuditLog"
+	// StorageServiceSetFaultRulesProcedure is the fully-qualified name of the StorageService's
+	// SetFaultRules RPC.
+	StorageServiceSetFaultRulesProcedure = "/storage.v1.StorageService/SetFaultRules"
+	// StorageServiceListFaultRulesProcedure is the fully-qualified name of the StorageService's
+	// ListFaultRules RPC.
+	StorageServiceListFaultRulesProcedure = "/storage.v1.StorageService/ListFaultRules"
+)
+
+// StorageServiceClient is a client for the storage.v1.StorageService service.
+type StorageServiceClient interface {
+	CreateBucket(context.Context, *connect.Request[storage.CreateBucketRequest]) (*connect.Response[storage.CreateBucketResponse], error)
+	UploadObject(context.Context, *connect.Request[storage.UploadObjectRequest]) (*connect.Response[storage.UploadObjectResponse], error)
+	GetObjectMetadata(context.Context, *connect.Request[storage.GetObjectMetadataRequest]) (*connect.Response[storage.GetObjectMetadataResponse], error)
+	ListObjects(context.Context, *connect.Request[storage.ListObjectsRequest]) (*connect.Response[storage.ListObjectsResponse], error)
+	GetDownloadURL(context.Context, *connect.Request[storage.GetDownloadURLRequest]) (*connect.Response[storage.GetDownloadURLResponse], error)
+	GetBucket(context.Context, *connect.Request[storage.GetBucketRequest]) (*connect.Response[storage.GetBucketResponse], error)
+	ListBuckets(context.Context, *connect.Request[storage.ListBucketsRequest]) (*connect.Response[storage.ListBucketsResponse], error)
+	UpdateBucket(context.Context, *connect.Request[storage.UpdateBucketRequest]) (*connect.Response[storage.UpdateBucketResponse], error)
+	ReadObject(context.Context, *connect.Request[storage.ReadObjectRequest]) (*connect.ServerStreamForClient[storage.ReadObjectResponse], error)
+	DeleteObject(context.Context, *connect.Request[storage.DeleteObjectRequest]) (*connect.Response[storage.DeleteObjectResponse], error)
+	RewriteObject(context.Context, *connect.Request[storage.RewriteObjectRequest]) (*connect.Response[storage.RewriteObjectResponse], error)
+	ListEarlyDeletions(context.Context, *connect.Request[storage.ListEarlyDeletionsRequest]) (*connect.Response[storage.ListEarlyDeletionsResponse], error)
+	UpdateObject(context.Context, *connect.Request[storage.UpdateObjectRequest]) (*connect.Response[storage.UpdateObjectResponse], error)
+	CreateNotificationConfig(context.Context, *connect.Request[storage.CreateNotificationConfigRequest]) (*connect.Response[storage.CreateNotificationConfigResponse], error)
+	GetNotificationConfig(context.Context, *connect.Request[storage.GetNotificationConfigRequest]) (*connect.Response[storage.GetNotificationConfigResponse], error)
+	ListNotificationConfigs(context.Context, *connect.Request[storage.ListNotificationConfigsRequest]) (*connect.Response[storage.ListNotificationConfigsResponse], error)
+	DeleteNotificationConfig(context.Context, *connect.Request[storage.DeleteNotificationConfigRequest]) (*connect.Response[storage.DeleteNotificationConfigResponse], error)
+	GetIamPolicy(context.Context, *connect.Request[storage.GetIamPolicyRequest]) (*connect.Response[storage.GetIamPolicyResponse], error)
+	SetIamPolicy(context.Context, *connect.Request[storage.SetIamPolicyRequest]) (*connect.Response[storage.SetIamPolicyResponse], error)
+	TestIamPermissions(context.Context, *connect.Request[storage.TestIamPermissionsRequest]) (*connect.Response[storage.TestIamPermissionsResponse], error)
+	ListAcl(context.Context, *connect.Request[storage.ListAclRequest]) (*connect.Response[storage.ListAclResponse], error)
+	InsertAcl(context.Context, *connect.Request[storage.InsertAclRequest]) (*connect.Response[storage.InsertAclResponse], error)
+	PatchAcl(context.Context, *connect.Request[storage.PatchAclRequest]) (*connect.Response[storage.PatchAclResponse], error)
+	DeleteAcl(context.Context, *connect.Request[storage.DeleteAclRequest]) (*connect.Response[storage.DeleteAclResponse], error)
+	RotateKmsKey(context.Context, *connect.Request[storage.RotateKmsKeyRequest]) (*connect.Response[storage.RotateKmsKeyResponse], error)
+	ComposeObject(context.Context, *connect.Request[storage.ComposeObjectRequest]) (*connect.Response[storage.ComposeObjectResponse], error)
+	GetStorageUsage(context.Context, *connect.Request[storage.GetStorageUsageRequest]) (*connect.Response[storage.GetStorageUsageResponse], error)
+	WriteObject(context.Context) *connect.ClientStreamForClient[storage.WriteObjectRequest, storage.WriteObjectResponse]
+	ExportState(context.Context, *connect.Request[storage.ExportStateRequest]) (*connect.ServerStreamForClient[storage.ExportStateResponse], error)
+	ImportState(context.Context) *connect.ClientStreamForClient[storage.ImportStateRequest, storage.ImportStateResponse]
+	SeedStorage(context.Context, *connect.Request[storage.SeedStorageRequest]) (*connect.Response[storage.SeedStorageResponse], error)
+	QueryAuditLog(context.Context, *connect.Request[storage.QueryAuditLogRequest]) (*connect.Response[storage.QueryAuditLogResponse], error)
+	SetFaultRules(context.Context, *connect.Request[storage.SetFaultRulesRequest]) (*connect.Response[storage.SetFaultRulesResponse], error)
+	ListFaultRules(context.Context, *connect.Request[storage.ListFaultRulesRequest]) (*connect.Response[storage.ListFaultRulesResponse], error)
+}
+
+// NewStorageServiceClient constructs a client for the storage.v1.StorageService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewStorageServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) StorageServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	storageServiceMethods := storage.File_v1_storage_storage_proto.Services().ByName("StorageService").Methods()
+	return &storageServiceClient{
+		createBucket: connect.NewClient[storage.CreateBucketRequest, storage.CreateBucketResponse](
+			httpClient,
+			baseURL+StorageServiceCreateBucketProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("CreateBucket")),
+			connect.WithClientOptions(opts...),
+		),
+		uploadObject: connect.NewClient[storage.UploadObjectRequest, storage.UploadObjectResponse](
+			httpClient,
+			baseURL+StorageServiceUploadObjectProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("UploadObject")),
+			connect.WithClientOptions(opts...),
+		),
+		getObjectMetadata: connect.NewClient[storage.GetObjectMetadataRequest, storage.GetObjectMetadataResponse](
+			httpClient,
+			baseURL+StorageServiceGetObjectMetadataProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("GetObjectMetadata")),
+			connect.WithClientOptions(opts...),
+		),
+		listObjects: connect.NewClient[storage.ListObjectsRequest, storage.ListObjectsResponse](
+			httpClient,
+			baseURL+StorageServiceListObjectsProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ListObjects")),
+			connect.WithClientOptions(opts...),
+		),
+		getDownloadURL: connect.NewClient[storage.GetDownloadURLRequest, storage.GetDownloadURLResponse](
+			httpClient,
+			baseURL+StorageServiceGetDownloadURLProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("GetDownloadURL")),
+			connect.WithClientOptions(opts...),
+		),
+		getBucket: connect.NewClient[storage.GetBucketRequest, storage.GetBucketResponse](
+			httpClient,
+			baseURL+StorageServiceGetBucketProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("GetBucket")),
+			connect.WithClientOptions(opts...),
+		),
+		listBuckets: connect.NewClient[storage.ListBucketsRequest, storage.ListBucketsResponse](
+			httpClient,
+			baseURL+StorageServiceListBucketsProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ListBuckets")),
+			connect.WithClientOptions(opts...),
+		),
+		updateBucket: connect.NewClient[storage.UpdateBucketRequest, storage.UpdateBucketResponse](
+			httpClient,
+			baseURL+StorageServiceUpdateBucketProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("UpdateBucket")),
+			connect.WithClientOptions(opts...),
+		),
+		readObject: connect.NewClient[storage.ReadObjectRequest, storage.ReadObjectResponse](
+			httpClient,
+			baseURL+StorageServiceReadObjectProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ReadObject")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteObject: connect.NewClient[storage.DeleteObjectRequest, storage.DeleteObjectResponse](
+			httpClient,
+			baseURL+StorageServiceDeleteObjectProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("DeleteObject")),
+			connect.WithClientOptions(opts...),
+		),
+		rewriteObject: connect.NewClient[storage.RewriteObjectRequest, storage.RewriteObjectResponse](
+			httpClient,
+			baseURL+StorageServiceRewriteObjectProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("RewriteObject")),
+			connect.WithClientOptions(opts...),
+		),
+		listEarlyDeletions: connect.NewClient[storage.ListEarlyDeletionsRequest, storage.ListEarlyDeletionsResponse](
+			httpClient,
+			baseURL+StorageServiceListEarlyDeletionsProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ListEarlyDeletions")),
+			connect.WithClientOptions(opts...),
+		),
+		updateObject: connect.NewClient[storage.UpdateObjectRequest, storage.UpdateObjectResponse](
+			httpClient,
+			baseURL+StorageServiceUpdateObjectProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("UpdateObject")),
+			connect.WithClientOptions(opts...),
+		),
+		createNotificationConfig: connect.NewClient[storage.CreateNotificationConfigRequest, storage.CreateNotificationConfigResponse](
+			httpClient,
+			baseURL+StorageServiceCreateNotificationConfigProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("CreateNotificationConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		getNotificationConfig: connect.NewClient[storage.GetNotificationConfigRequest, storage.GetNotificationConfigResponse](
+			httpClient,
+			baseURL+StorageServiceGetNotificationConfigProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("GetNotificationConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		listNotificationConfigs: connect.NewClient[storage.ListNotificationConfigsRequest, storage.ListNotificationConfigsResponse](
+			httpClient,
+			baseURL+StorageServiceListNotificationConfigsProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ListNotificationConfigs")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteNotificationConfig: connect.NewClient[storage.DeleteNotificationConfigRequest, storage.DeleteNotificationConfigResponse](
+			httpClient,
+			baseURL+StorageServiceDeleteNotificationConfigProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("DeleteNotificationConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		getIamPolicy: connect.NewClient[storage.GetIamPolicyRequest, storage.GetIamPolicyResponse](
+			httpClient,
+			baseURL+StorageServiceGetIamPolicyProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("GetIamPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		setIamPolicy: connect.NewClient[storage.SetIamPolicyRequest, storage.SetIamPolicyResponse](
+			httpClient,
+			baseURL+StorageServiceSetIamPolicyProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("SetIamPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		testIamPermissions: connect.NewClient[storage.TestIamPermissionsRequest, storage.TestIamPermissionsResponse](
+			httpClient,
+			baseURL+StorageServiceTestIamPermissionsProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("TestIamPermissions")),
+			connect.WithClientOptions(opts...),
+		),
+		listAcl: connect.NewClient[storage.ListAclRequest, storage.ListAclResponse](
+			httpClient,
+			baseURL+StorageServiceListAclProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ListAcl")),
+			connect.WithClientOptions(opts...),
+		),
+		insertAcl: connect.NewClient[storage.InsertAclRequest, storage.InsertAclResponse](
+			httpClient,
+			baseURL+StorageServiceInsertAclProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("InsertAcl")),
+			connect.WithClientOptions(opts...),
+		),
+		patchAcl: connect.NewClient[storage.PatchAclRequest, storage.PatchAclResponse](
+			httpClient,
+			baseURL+StorageServicePatchAclProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("PatchAcl")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAcl: connect.NewClient[storage.DeleteAclRequest, storage.DeleteAclResponse](
+			httpClient,
+			baseURL+StorageServiceDeleteAclProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("DeleteAcl")),
+			connect.WithClientOptions(opts...),
+		),
+		rotateKmsKey: connect.NewClient[storage.RotateKmsKeyRequest, storage.RotateKmsKeyResponse](
+			httpClient,
+			baseURL+StorageServiceRotateKmsKeyProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("RotateKmsKey")),
+			connect.WithClientOptions(opts...),
+		),
+		composeObject: connect.NewClient[storage.ComposeObjectRequest, storage.ComposeObjectResponse](
+			httpClient,
+			baseURL+StorageServiceComposeObjectProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ComposeObject")),
+			connect.WithClientOptions(opts...),
+		),
+		getStorageUsage: connect.NewClient[storage.GetStorageUsageRequest, storage.GetStorageUsageResponse](
+			httpClient,
+			baseURL+StorageServiceGetStorageUsageProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("GetStorageUsage")),
+			connect.WithClientOptions(opts...),
+		),
+		writeObject: connect.NewClient[storage.WriteObjectRequest, storage.WriteObjectResponse](
+			httpClient,
+			baseURL+StorageServiceWriteObjectProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("WriteObject")),
+			connect.WithClientOptions(opts...),
+		),
+		exportState: connect.NewClient[storage.ExportStateRequest, storage.ExportStateResponse](
+			httpClient,
+			baseURL+StorageServiceExportStateProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ExportState")),
+			connect.WithClientOptions(opts...),
+		),
+		importState: connect.NewClient[storage.ImportStateRequest, storage.ImportStateResponse](
+			httpClient,
+			baseURL+StorageServiceImportStateProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ImportState")),
+			connect.WithClientOptions(opts...),
+		),
+		seedStorage: connect.NewClient[storage.SeedStorageRequest, storage.SeedStorageResponse](
+			httpClient,
+			baseURL+StorageServiceSeedStorageProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("SeedStorage")),
+			connect.WithClientOptions(opts...),
+		),
+		queryAuditLog: connect.NewClient[storage.QueryAuditLogRequest, storage.QueryAuditLogResponse](
+			httpClient,
+			baseURL+StorageServiceQueryAuditLogProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("QueryAuditLog")),
+			connect.WithClientOptions(opts...),
+		),
+		setFaultRules: connect.NewClient[storage.SetFaultRulesRequest, storage.SetFaultRulesResponse](
+			httpClient,
+			baseURL+StorageServiceSetFaultRulesProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("SetFaultRules")),
+			connect.WithClientOptions(opts...),
+		),
+		listFaultRules: connect.NewClient[storage.ListFaultRulesRequest, storage.ListFaultRulesResponse](
+			httpClient,
+			baseURL+StorageServiceListFaultRulesProcedure,
+			connect.WithSchema(storageServiceMethods.ByName("ListFaultRules")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// storageServiceClient implements StorageServiceClient.
+type storageServiceClient struct {
+	createBucket             *connect.Client[storage.CreateBucketRequest, storage.CreateBucketResponse]
+	uploadObject             *connect.Client[storage.UploadObjectRequest, storage.UploadObjectResponse]
+	getObjectMetadata        *connect.Client[storage.GetObjectMetadataRequest, storage.GetObjectMetadataResponse]
+	listObjects              *connect.Client[storage.ListObjectsRequest, storage.ListObjectsResponse]
+	getDownloadURL           *connect.Client[storage.GetDownloadURLRequest, storage.GetDownloadURLResponse]
+	getBucket                *connect.Client[storage.GetBucketRequest, storage.GetBucketResponse]
+	listBuckets              *connect.Client[storage.ListBucketsRequest, storage.ListBucketsResponse]
+	updateBucket             *connect.Client[storage.UpdateBucketRequest, storage.UpdateBucketResponse]
+	readObject               *connect.Client[storage.ReadObjectRequest, storage.ReadObjectResponse]
+	deleteObject             *connect.Client[storage.DeleteObjectRequest, storage.DeleteObjectResponse]
+	rewriteObject            *connect.Client[storage.RewriteObjectRequest, storage.RewriteObjectResponse]
+	listEarlyDeletions       *connect.Client[storage.ListEarlyDeletionsRequest, storage.ListEarlyDeletionsResponse]
+	updateObject             *connect.Client[storage.UpdateObjectRequest, storage.UpdateObjectResponse]
+	createNotificationConfig *connect.Client[storage.CreateNotificationConfigRequest, storage.CreateNotificationConfigResponse]
+	getNotificationConfig    *connect.Client[storage.GetNotificationConfigRequest, storage.GetNotificationConfigResponse]
+	listNotificationConfigs  *connect.Client[storage.ListNotificationConfigsRequest, storage.ListNotificationConfigsResponse]
+	deleteNotificationConfig *connect.Client[storage.DeleteNotificationConfigRequest, storage.DeleteNotificationConfigResponse]
+	getIamPolicy             *connect.Client[storage.GetIamPolicyRequest, storage.GetIamPolicyResponse]
+	setIamPolicy             *connect.Client[storage.SetIamPolicyRequest, storage.SetIamPolicyResponse]
+	testIamPermissions       *connect.Client[storage.TestIamPermissionsRequest, storage.TestIamPermissionsResponse]
+	listAcl                  *connect.Client[storage.ListAclRequest, storage.ListAclResponse]
+	insertAcl                *connect.Client[storage.InsertAclRequest, storage.InsertAclResponse]
+	patchAcl                 *connect.Client[storage.PatchAclRequest, storage.PatchAclResponse]
+	deleteAcl                *connect.Client[storage.DeleteAclRequest, storage.DeleteAclResponse]
+	rotateKmsKey             *connect.Client[storage.RotateKmsKeyRequest, storage.RotateKmsKeyResponse]
+	composeObject            *connect.Client[storage.ComposeObjectRequest, storage.ComposeObjectResponse]
+	getStorageUsage          *connect.Client[storage.GetStorageUsageRequest, storage.GetStorageUsageResponse]
+	writeObject              *connect.Client[storage.WriteObjectRequest, storage.WriteObjectResponse]
+	exportState              *connect.Client[storage.ExportStateRequest, storage.ExportStateResponse]
+	importState              *connect.Client[storage.ImportStateRequest, storage.ImportStateResponse]
+	seedStorage              *connect.Client[storage.SeedStorageRequest, storage.SeedStorageResponse]
+	queryAuditLog            *connect.Client[storage.QueryAuditLogRequest, storage.QueryAuditLogResponse]
+	setFaultRules            *connect.Client[storage.SetFaultRulesRequest, storage.SetFaultRulesResponse]
+	listFaultRules           *connect.Client[storage.ListFaultRulesRequest, storage.ListFaultRulesResponse]
+}
+
+// CreateBucket calls storage.v1.StorageService.CreateBucket.
+func (c *storageServiceClient) CreateBucket(ctx context.Context, req *connect.Request[storage.CreateBucketRequest]) (*connect.Response[storage.CreateBucketResponse], error) {
+	return c.createBucket.CallUnary(ctx, req)
+}
+
+// UploadObject calls storage.v1.StorageService.UploadObject.
+func (c *storageServiceClient) UploadObject(ctx context.Context, req *connect.Request[storage.UploadObjectRequest]) (*connect.Response[storage.UploadObjectResponse], error) {
+	return c.uploadObject.CallUnary(ctx, req)
+}
+
+// GetObjectMetadata calls storage.v1.StorageService.GetObjectMetadata.
+func (c *storageServiceClient) GetObjectMetadata(ctx context.Context, req *connect.Request[storage.GetObjectMetadataRequest]) (*connect.Response[storage.GetObjectMetadataResponse], error) {
+	return c.getObjectMetadata.CallUnary(ctx, req)
+}
+
+// ListObjects calls storage.v1.StorageService.ListObjects.
+func (c *storageServiceClient) ListObjects(ctx context.Context, req *connect.Request[storage.ListObjectsRequest]) (*connect.Response[storage.ListObjectsResponse], error) {
+	return c.listObjects.CallUnary(ctx, req)
+}
+
+// GetDownloadURL calls storage.v1.StorageService.GetDownloadURL.
+func (c *storageServiceClient) GetDownloadURL(ctx context.Context, req *connect.Request[storage.GetDownloadURLRequest]) (*connect.Response[storage.GetDownloadURLResponse], error) {
+	return c.getDownloadURL.CallUnary(ctx, req)
+}
+
+// GetBucket calls storage.v1.StorageService.GetBucket.
+func (c *storageServiceClient) GetBucket(ctx context.Context, req *connect.Request[storage.GetBucketRequest]) (*connect.Response[storage.GetBucketResponse], error) {
+	return c.getBucket.CallUnary(ctx, req)
+}
+
+// ListBuckets calls storage.v1.StorageService.ListBuckets.
+func (c *storageServiceClient) ListBuckets(ctx context.Context, req *connect.Request[storage.ListBucketsRequest]) (*connect.Response[storage.ListBucketsResponse], error) {
+	return c.listBuckets.CallUnary(ctx, req)
+}
+
+// UpdateBucket calls storage.v1.StorageService.UpdateBucket.
+func (c *storageServiceClient) UpdateBucket(ctx context.Context, req *connect.Request[storage.UpdateBucketRequest]) (*connect.Response[storage.UpdateBucketResponse], error) {
+	return c.updateBucket.CallUnary(ctx, req)
+}
+
+// ReadObject calls storage.v1.StorageService.ReadObject.
+func (c *storageServiceClient) ReadObject(ctx context.Context, req *connect.Request[storage.ReadObjectRequest]) (*connect.ServerStreamForClient[storage.ReadObjectResponse], error) {
+	return c.readObject.CallServerStream(ctx, req)
+}
+
+// DeleteObject calls storage.v1.StorageService.DeleteObject.
+func (c *storageServiceClient) DeleteObject(ctx context.Context, req *connect.Request[storage.DeleteObjectRequest]) (*connect.Response[storage.DeleteObjectResponse], error) {
+	return c.deleteObject.CallUnary(ctx, req)
+}
+
+// RewriteObject calls storage.v1.StorageService.RewriteObject.
+func (c *storageServiceClient) RewriteObject(ctx context.Context, req *connect.Request[storage.RewriteObjectRequest]) (*connect.Response[storage.RewriteObjectResponse], error) {
+	return c.rewriteObject.CallUnary(ctx, req)
+}
+
+// ListEarlyDeletions calls storage.v1.StorageService.ListEarlyDeletions.
+func (c *storageServiceClient) ListEarlyDeletions(ctx context.Context, req *connect.Request[storage.ListEarlyDeletionsRequest]) (*connect.Response[storage.ListEarlyDeletionsResponse], error) {
+	return c.listEarlyDeletions.CallUnary(ctx, req)
+}
+
+// UpdateObject calls storage.v1.StorageService.UpdateObject.
+func (c *storageServiceClient) UpdateObject(ctx context.Context, req *connect.Request[storage.UpdateObjectRequest]) (*connect.Response[storage.UpdateObjectResponse], error) {
+	return c.updateObject.CallUnary(ctx, req)
+}
+
+// CreateNotificationConfig calls storage.v1.StorageService.CreateNotificationConfig.
+func (c *storageServiceClient) CreateNotificationConfig(ctx context.Context, req *connect.Request[storage.CreateNotificationConfigRequest]) (*connect.Response[storage.CreateNotificationConfigResponse], error) {
+	return c.createNotificationConfig.CallUnary(ctx, req)
+}
+
+// GetNotificationConfig calls storage.v1.StorageService.GetNotificationConfig.
+func (c *storageServiceClient) GetNotificationConfig(ctx context.Context, req *connect.Request[storage.GetNotificationConfigRequest]) (*connect.Response[storage.GetNotificationConfigResponse], error) {
+	return c.getNotificationConfig.CallUnary(ctx, req)
+}
+
+// ListNotificationConfigs calls storage.v1.StorageService.ListNotificationConfigs.
+func (c *storageServiceClient) ListNotificationConfigs(ctx context.Context, req *connect.Request[storage.ListNotificationConfigsRequest]) (*connect.Response[storage.ListNotificationConfigsResponse], error) {
+	return c.listNotificationConfigs.CallUnary(ctx, req)
+}
+
+// DeleteNotificationConfig calls storage.v1.StorageService.DeleteNotificationConfig.
+func (c *storageServiceClient) DeleteNotificationConfig(ctx context.Context, req *connect.Request[storage.DeleteNotificationConfigRequest]) (*connect.Response[storage.DeleteNotificationConfigResponse], error) {
+	return c.deleteNotificationConfig.CallUnary(ctx, req)
+}
+
+// GetIamPolicy calls storage.v1.StorageService.GetIamPolicy.
+func (c *storageServiceClient) GetIamPolicy(ctx context.Context, req *connect.Request[storage.GetIamPolicyRequest]) (*connect.Response[storage.GetIamPolicyResponse], error) {
+	return c.getIamPolicy.CallUnary(ctx, req)
+}
+
+// SetIamPolicy calls storage.v1.StorageService.SetIamPolicy.
+func (c *storageServiceClient) SetIamPolicy(ctx context.Context, req *connect.Request[storage.SetIamPolicyRequest]) (*connect.Response[storage.SetIamPolicyResponse], error) {
+	return c.setIamPolicy.CallUnary(ctx, req)
+}
+
+// TestIamPermissions calls storage.v1.StorageService.TestIamPermissions.
+func (c *storageServiceClient) TestIamPermissions(ctx context.Context, req *connect.Request[storage.TestIamPermissionsRequest]) (*connect.Response[storage.TestIamPermissionsResponse], error) {
+	return c.testIamPermissions.CallUnary(ctx, req)
+}
+
+// ListAcl calls storage.v1.StorageService.ListAcl.
+func (c *storageServiceClient) ListAcl(ctx context.Context, req *connect.Request[storage.ListAclRequest]) (*connect.Response[storage.ListAclResponse], error) {
+	return c.listAcl.CallUnary(ctx, req)
+}
+
+// InsertAcl calls storage.v1.StorageService.InsertAcl.
+func (c *storageServiceClient) InsertAcl(ctx context.Context, req *connect.Request[storage.InsertAclRequest]) (*connect.Response[storage.InsertAclResponse], error) {
+	return c.insertAcl.CallUnary(ctx, req)
+}
+
+// PatchAcl calls storage.v1.StorageService.PatchAcl.
+func (c *storageServiceClient) PatchAcl(ctx context.Context, req *connect.Request[storage.PatchAclRequest]) (*connect.Response[storage.PatchAclResponse], error) {
+	return c.patchAcl.CallUnary(ctx, req)
+}
+
+// DeleteAcl calls storage.v1.StorageService.DeleteAcl.
+func (c *storageServiceClient) DeleteAcl(ctx context.Context, req *connect.Request[storage.DeleteAclRequest]) (*connect.Response[storage.DeleteAclResponse], error) {
+	return c.deleteAcl.CallUnary(ctx, req)
+}
+
+// RotateKmsKey calls storage.v1.StorageService.RotateKmsKey.
+func (c *storageServiceClient) RotateKmsKey(ctx context.Context, req *connect.Request[storage.RotateKmsKeyRequest]) (*connect.Response[storage.RotateKmsKeyResponse], error) {
+	return c.rotateKmsKey.CallUnary(ctx, req)
+}
+
+// ComposeObject calls storage.v1.StorageService.ComposeObject.
+func (c *storageServiceClient) ComposeObject(ctx context.Context, req *connect.Request[storage.ComposeObjectRequest]) (*connect.Response[storage.ComposeObjectResponse], error) {
+	return c.composeObject.CallUnary(ctx, req)
+}
+
+// GetStorageUsage calls storage.v1.StorageService.GetStorageUsage.
+func (c *storageServiceClient) GetStorageUsage(ctx context.Context, req *connect.Request[storage.GetStorageUsageRequest]) (*connect.Response[storage.GetStorageUsageResponse], error) {
+	return c.getStorageUsage.CallUnary(ctx, req)
+}
+
+// WriteObject calls storage.v1.StorageService.WriteObject.
+func (c *storageServiceClient) WriteObject(ctx context.Context) *connect.ClientStreamForClient[storage.WriteObjectRequest, storage.WriteObjectResponse] {
+	return c.writeObject.CallClientStream(ctx)
+}
+
+// ExportState calls storage.v1.StorageService.ExportState.
+func (c *storageServiceClient) ExportState(ctx context.Context, req *connect.Request[storage.ExportStateRequest]) (*connect.ServerStreamForClient[storage.ExportStateResponse], error) {
+	return c.exportState.CallServerStream(ctx, req)
+}
+
+// ImportState calls storage.v1.StorageService.ImportState.
+func (c *storageServiceClient) ImportState(ctx context.Context) *connect.ClientStreamForClient[storage.ImportStateRequest, storage.ImportStateResponse] {
+	return c.importState.CallClientStream(ctx)
+}
+
+// SeedStorage calls storage.v1.StorageService.SeedStorage.
+func (c *storageServiceClient) SeedStorage(ctx context.Context, req *connect.Request[storage.SeedStorageRequest]) (*connect.Response[storage.SeedStorageResponse], error) {
+	return c.seedStorage.CallUnary(ctx, req)
+}
+
+// QueryAuditLog calls storage.v1.StorageService.QueryAuditLog.
+func (c *storageServiceClient) QueryAuditLog(ctx context.Context, req *connect.Request[storage.QueryAuditLogRequest]) (*connect.Response[storage.QueryAuditLogResponse], error) {
+	return c.queryAuditLog.CallUnary(ctx, req)
+}
+
+// SetFaultRules calls storage.v1.StorageService.SetFaultRules.
+func (c *storageServiceClient) SetFaultRules(ctx context.Context, req *connect.Request[storage.SetFaultRulesRequest]) (*connect.Response[storage.SetFaultRulesResponse], error) {
+	return c.setFaultRules.CallUnary(ctx, req)
+}
+
+// ListFaultRules calls storage.v1.StorageService.ListFaultRules.
+func (c *storageServiceClient) ListFaultRules(ctx context.Context, req *connect.Request[storage.ListFaultRulesRequest]) (*connect.Response[storage.ListFaultRulesResponse], error) {
+	return c.listFaultRules.CallUnary(ctx, req)
+}
+
+// StorageServiceHandler is an implementation of the storage.v1.StorageService service.
+type StorageServiceHandler interface {
+	CreateBucket(context.Context, *connect.Request[storage.CreateBucketRequest]) (*connect.Response[storage.CreateBucketResponse], error)
+	UploadObject(context.Context, *connect.Request[storage.UploadObjectRequest]) (*connect.Response[storage.UploadObjectResponse], error)
+	GetObjectMetadata(context.Context, *connect.Request[storage.GetObjectMetadataRequest]) (*connect.Response[storage.GetObjectMetadataResponse], error)
+	ListObjects(context.Context, *connect.Request[storage.ListObjectsRequest]) (*connect.Response[storage.ListObjectsResponse], error)
+	GetDownloadURL(context.Context, *connect.Request[storage.GetDownloadURLRequest]) (*connect.Response[storage.GetDownloadURLResponse], error)
+	GetBucket(context.Context, *connect.Request[storage.GetBucketRequest]) (*connect.Response[storage.GetBucketResponse], error)
+	ListBuckets(context.Context, *connect.Request[storage.ListBucketsRequest]) (*connect.Response[storage.ListBucketsResponse], error)
+	UpdateBucket(context.Context, *connect.Request[storage.UpdateBucketRequest]) (*connect.Response[storage.UpdateBucketResponse], error)
+	ReadObject(context.Context, *connect.Request[storage.ReadObjectRequest], *connect.ServerStream[storage.ReadObjectResponse]) error
+	DeleteObject(context.Context, *connect.Request[storage.DeleteObjectRequest]) (*connect.Response[storage.DeleteObjectResponse], error)
+	RewriteObject(context.Context, *connect.Request[storage.RewriteObjectRequest]) (*connect.Response[storage.RewriteObjectResponse], error)
+	ListEarlyDeletions(context.Context, *connect.Request[storage.ListEarlyDeletionsRequest]) (*connect.Response[storage.ListEarlyDeletionsResponse], error)
+	UpdateObject(context.Context, *connect.Request[storage.UpdateObjectRequest]) (*connect.Response[storage.UpdateObjectResponse], error)
+	CreateNotificationConfig(context.Context, *connect.Request[storage.CreateNotificationConfigRequest]) (*connect.Response[storage.CreateNotificationConfigResponse], error)
+	GetNotificationConfig(context.Context, *connect.Request[storage.GetNotificationConfigRequest]) (*connect.Response[storage.GetNotificationConfigResponse], error)
+	ListNotificationConfigs(context.Context, *connect.Request[storage.ListNotificationConfigsRequest]) (*connect.Response[storage.ListNotificationConfigsResponse], error)
+	DeleteNotificationConfig(context.Context, *connect.Request[storage.DeleteNotificationConfigRequest]) (*connect.Response[storage.DeleteNotificationConfigResponse], error)
+	GetIamPolicy(context.Context, *connect.Request[storage.GetIamPolicyRequest]) (*connect.Response[storage.GetIamPolicyResponse], error)
+	SetIamPolicy(context.Context, *connect.Request[storage.SetIamPolicyRequest]) (*connect.Response[storage.SetIamPolicyResponse], error)
+	TestIamPermissions(context.Context, *connect.Request[storage.TestIamPermissionsRequest]) (*connect.Response[storage.TestIamPermissionsResponse], error)
+	ListAcl(context.Context, *connect.Request[storage.ListAclRequest]) (*connect.Response[storage.ListAclResponse], error)
+	InsertAcl(context.Context, *connect.Request[storage.InsertAclRequest]) (*connect.Response[storage.InsertAclResponse], error)
+	PatchAcl(context.Context, *connect.Request[storage.PatchAclRequest]) (*connect.Response[storage.PatchAclResponse], error)
+	DeleteAcl(context.Context, *connect.Request[storage.DeleteAclRequest]) (*connect.Response[storage.DeleteAclResponse], error)
+	RotateKmsKey(context.Context, *connect.Request[storage.RotateKmsKeyRequest]) (*connect.Response[storage.RotateKmsKeyResponse], error)
+	ComposeObject(context.Context, *connect.Request[storage.ComposeObjectRequest]) (*connect.Response[storage.ComposeObjectResponse], error)
+	GetStorageUsage(context.Context, *connect.Request[storage.GetStorageUsageRequest]) (*connect.Response[storage.GetStorageUsageResponse], error)
+	WriteObject(context.Context, *connect.ClientStream[storage.WriteObjectRequest]) (*connect.Response[storage.WriteObjectResponse], error)
+	ExportState(context.Context, *connect.Request[storage.ExportStateRequest], *connect.ServerStream[storage.ExportStateResponse]) error
+	ImportState(context.Context, *connect.ClientStream[storage.ImportStateRequest]) (*connect.Response[storage.ImportStateResponse], error)
+	SeedStorage(context.Context, *connect.Request[storage.SeedStorageRequest]) (*connect.Response[storage.SeedStorageResponse], error)
+	QueryAuditLog(context.Context, *connect.Request[storage.QueryAuditLogRequest]) (*connect.Response[storage.QueryAuditLogResponse], error)
+	SetFaultRules(context.Context, *connect.Request[storage.SetFaultRulesRequest]) (*connect.Response[storage.SetFaultRulesResponse], error)
+	ListFaultRules(context.Context, *connect.Request[storage.ListFaultRulesRequest]) (*connect.Response[storage.ListFaultRulesResponse], error)
+}
+
+// NewStorageServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewStorageServiceHandler(svc StorageServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	storageServiceMethods := storage.File_v1_storage_storage_proto.Services().ByName("StorageService").Methods()
+	storageServiceCreateBucketHandler := connect.NewUnaryHandler(
+		StorageServiceCreateBucketProcedure,
+		svc.CreateBucket,
+		connect.WithSchema(storageServiceMethods.ByName("CreateBucket")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceUploadObjectHandler := connect.NewUnaryHandler(
+		StorageServiceUploadObjectProcedure,
+		svc.UploadObject,
+		connect.WithSchema(storageServiceMethods.ByName("UploadObject")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceGetObjectMetadataHandler := connect.NewUnaryHandler(
+		StorageServiceGetObjectMetadataProcedure,
+		svc.GetObjectMetadata,
+		connect.WithSchema(storageServiceMethods.ByName("GetObjectMetadata")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceListObjectsHandler := connect.NewUnaryHandler(
+		StorageServiceListObjectsProcedure,
+		svc.ListObjects,
+		connect.WithSchema(storageServiceMethods.ByName("ListObjects")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceGetDownloadURLHandler := connect.NewUnaryHandler(
+		StorageServiceGetDownloadURLProcedure,
+		svc.GetDownloadURL,
+		connect.WithSchema(storageServiceMethods.ByName("GetDownloadURL")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceGetBucketHandler := connect.NewUnaryHandler(
+		StorageServiceGetBucketProcedure,
+		svc.GetBucket,
+		connect.WithSchema(storageServiceMethods.ByName("GetBucket")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceListBucketsHandler := connect.NewUnaryHandler(
+		StorageServiceListBucketsProcedure,
+		svc.ListBuckets,
+		connect.WithSchema(storageServiceMethods.ByName("ListBuckets")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceUpdateBucketHandler := connect.NewUnaryHandler(
+		StorageServiceUpdateBucketProcedure,
+		svc.UpdateBucket,
+		connect.WithSchema(storageServiceMethods.ByName("UpdateBucket")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceReadObjectHandler := connect.NewServerStreamHandler(
+		StorageServiceReadObjectProcedure,
+		svc.ReadObject,
+		connect.WithSchema(storageServiceMethods.ByName("ReadObject")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceDeleteObjectHandler := connect.NewUnaryHandler(
+		StorageServiceDeleteObjectProcedure,
+		svc.DeleteObject,
+		connect.WithSchema(storageServiceMethods.ByName("DeleteObject")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceRewriteObjectHandler := connect.NewUnaryHandler(
+		StorageServiceRewriteObjectProcedure,
+		svc.RewriteObject,
+		connect.WithSchema(storageServiceMethods.ByName("RewriteObject")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceListEarlyDeletionsHandler := connect.NewUnaryHandler(
+		StorageServiceListEarlyDeletionsProcedure,
+		svc.ListEarlyDeletions,
+		connect.WithSchema(storageServiceMethods.ByName("ListEarlyDeletions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceUpdateObjectHandler := connect.NewUnaryHandler(
+		StorageServiceUpdateObjectProcedure,
+		svc.UpdateObject,
+		connect.WithSchema(storageServiceMethods.ByName("UpdateObject")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceCreateNotificationConfigHandler := connect.NewUnaryHandler(
+		StorageServiceCreateNotificationConfigProcedure,
+		svc.CreateNotificationConfig,
+		connect.WithSchema(storageServiceMethods.ByName("CreateNotificationConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceGetNotificationConfigHandler := connect.NewUnaryHandler(
+		StorageServiceGetNotificationConfigProcedure,
+		svc.GetNotificationConfig,
+		connect.WithSchema(storageServiceMethods.ByName("GetNotificationConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceListNotificationConfigsHandler := connect.NewUnaryHandler(
+		StorageServiceListNotificationConfigsProcedure,
+		svc.ListNotificationConfigs,
+		connect.WithSchema(storageServiceMethods.ByName("ListNotificationConfigs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceDeleteNotificationConfigHandler := connect.NewUnaryHandler(
+		StorageServiceDeleteNotificationConfigProcedure,
+		svc.DeleteNotificationConfig,
+		connect.WithSchema(storageServiceMethods.ByName("DeleteNotificationConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceGetIamPolicyHandler := connect.NewUnaryHandler(
+		StorageServiceGetIamPolicyProcedure,
+		svc.GetIamPolicy,
+		connect.WithSchema(storageServiceMethods.ByName("GetIamPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceSetIamPolicyHandler := connect.NewUnaryHandler(
+		StorageServiceSetIamPolicyProcedure,
+		svc.SetIamPolicy,
+		connect.WithSchema(storageServiceMethods.ByName("SetIamPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceTestIamPermissionsHandler := connect.NewUnaryHandler(
+		StorageServiceTestIamPermissionsProcedure,
+		svc.TestIamPermissions,
+		connect.WithSchema(storageServiceMethods.ByName("TestIamPermissions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceListAclHandler := connect.NewUnaryHandler(
+		StorageServiceListAclProcedure,
+		svc.ListAcl,
+		connect.WithSchema(storageServiceMethods.ByName("ListAcl")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceInsertAclHandler := connect.NewUnaryHandler(
+		StorageServiceInsertAclProcedure,
+		svc.InsertAcl,
+		connect.WithSchema(storageServiceMethods.ByName("InsertAcl")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServicePatchAclHandler := connect.NewUnaryHandler(
+		StorageServicePatchAclProcedure,
+		svc.PatchAcl,
+		connect.WithSchema(storageServiceMethods.ByName("PatchAcl")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceDeleteAclHandler := connect.NewUnaryHandler(
+		StorageServiceDeleteAclProcedure,
+		svc.DeleteAcl,
+		connect.WithSchema(storageServiceMethods.ByName("DeleteAcl")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceRotateKmsKeyHandler := connect.NewUnaryHandler(
+		StorageServiceRotateKmsKeyProcedure,
+		svc.RotateKmsKey,
+		connect.WithSchema(storageServiceMethods.ByName("RotateKmsKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceComposeObjectHandler := connect.NewUnaryHandler(
+		StorageServiceComposeObjectProcedure,
+		svc.ComposeObject,
+		connect.WithSchema(storageServiceMethods.ByName("ComposeObject")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceGetStorageUsageHandler := connect.NewUnaryHandler(
+		StorageServiceGetStorageUsageProcedure,
+		svc.GetStorageUsage,
+		connect.WithSchema(storageServiceMethods.ByName("GetStorageUsage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceWriteObjectHandler := connect.NewClientStreamHandler(
+		StorageServiceWriteObjectProcedure,
+		svc.WriteObject,
+		connect.WithSchema(storageServiceMethods.ByName("WriteObject")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceExportStateHandler := connect.NewServerStreamHandler(
+		StorageServiceExportStateProcedure,
+		svc.ExportState,
+		connect.WithSchema(storageServiceMethods.ByName("ExportState")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceImportStateHandler := connect.NewClientStreamHandler(
+		StorageServiceImportStateProcedure,
+		svc.ImportState,
+		connect.WithSchema(storageServiceMethods.ByName("ImportState")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceSeedStorageHandler := connect.NewUnaryHandler(
+		StorageServiceSeedStorageProcedure,
+		svc.SeedStorage,
+		connect.WithSchema(storageServiceMethods.ByName("SeedStorage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceQueryAuditLogHandler := connect.NewUnaryHandler(
+		StorageServiceQueryAuditLogProcedure,
+		svc.QueryAuditLog,
+		connect.WithSchema(storageServiceMethods.ByName("QueryAuditLog")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceSetFaultRulesHandler := connect.NewUnaryHandler(
+		StorageServiceSetFaultRulesProcedure,
+		svc.SetFaultRules,
+		connect.WithSchema(storageServiceMethods.ByName("SetFaultRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storageServiceListFaultRulesHandler := connect.NewUnaryHandler(
+		StorageServiceListFaultRulesProcedure,
+		svc.ListFaultRules,
+		connect.WithSchema(storageServiceMethods.ByName("ListFaultRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/storage.v1.StorageService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case StorageServiceCreateBucketProcedure:
+			storageServiceCreateBucketHandler.ServeHTTP(w, r)
+		case StorageServiceUploadObjectProcedure:
+			storageServiceUploadObjectHandler.ServeHTTP(w, r)
+		case StorageServiceGetObjectMetadataProcedure:
+			storageServiceGetObjectMetadataHandler.ServeHTTP(w, r)
+		case StorageServiceListObjectsProcedure:
+			storageServiceListObjectsHandler.ServeHTTP(w, r)
+		case StorageServiceGetDownloadURLProcedure:
+			storageServiceGetDownloadURLHandler.ServeHTTP(w, r)
+		case StorageServiceGetBucketProcedure:
+			storageServiceGetBucketHandler.ServeHTTP(w, r)
+		case StorageServiceListBucketsProcedure:
+			storageServiceListBucketsHandler.ServeHTTP(w, r)
+		case StorageServiceUpdateBucketProcedure:
+			storageServiceUpdateBucketHandler.ServeHTTP(w, r)
+		case StorageServiceReadObjectProcedure:
+			storageServiceReadObjectHandler.ServeHTTP(w, r)
+		case StorageServiceDeleteObjectProcedure:
+			storageServiceDeleteObjectHandler.ServeHTTP(w, r)
+		case StorageServiceRewriteObjectProcedure:
+			storageServiceRewriteObjectHandler.ServeHTTP(w, r)
+		case StorageServiceListEarlyDeletionsProcedure:
+			storageServiceListEarlyDeletionsHandler.ServeHTTP(w, r)
+		case StorageServiceUpdateObjectProcedure:
+			storageServiceUpdateObjectHandler.ServeHTTP(w, r)
+		case StorageServiceCreateNotificationConfigProcedure:
+			storageServiceCreateNotificationConfigHandler.ServeHTTP(w, r)
+		case StorageServiceGetNotificationConfigProcedure:
+			storageServiceGetNotificationConfigHandler.ServeHTTP(w, r)
+		case StorageServiceListNotificationConfigsProcedure:
+			storageServiceListNotificationConfigsHandler.ServeHTTP(w, r)
+		case StorageServiceDeleteNotificationConfigProcedure:
+			storageServiceDeleteNotificationConfigHandler.ServeHTTP(w, r)
+		case StorageServiceGetIamPolicyProcedure:
+			storageServiceGetIamPolicyHandler.ServeHTTP(w, r)
+		case StorageServiceSetIamPolicyProcedure:
+			storageServiceSetIamPolicyHandler.ServeHTTP(w, r)
+		case StorageServiceTestIamPermissionsProcedure:
+			storageServiceTestIamPermissionsHandler.ServeHTTP(w, r)
+		case StorageServiceListAclProcedure:
+			storageServiceListAclHandler.ServeHTTP(w, r)
+		case StorageServiceInsertAclProcedure:
+			storageServiceInsertAclHandler.ServeHTTP(w, r)
+		case StorageServicePatchAclProcedure:
+			storageServicePatchAclHandler.ServeHTTP(w, r)
+		case StorageServiceDeleteAclProcedure:
+			storageServiceDeleteAclHandler.ServeHTTP(w, r)
+		case StorageServiceRotateKmsKeyProcedure:
+			storageServiceRotateKmsKeyHandler.ServeHTTP(w, r)
+		case StorageServiceComposeObjectProcedure:
+			storageServiceComposeObjectHandler.ServeHTTP(w, r)
+		case StorageServiceGetStorageUsageProcedure:
+			storageServiceGetStorageUsageHandler.ServeHTTP(w, r)
+		case StorageServiceWriteObjectProcedure:
+			storageServiceWriteObjectHandler.ServeHTTP(w, r)
+		case StorageServiceExportStateProcedure:
+			storageServiceExportStateHandler.ServeHTTP(w, r)
+		case StorageServiceImportStateProcedure:
+			storageServiceImportStateHandler.ServeHTTP(w, r)
+		case StorageServiceSeedStorageProcedure:
+			storageServiceSeedStorageHandler.ServeHTTP(w, r)
+		case StorageServiceQueryAuditLogProcedure:
+			storageServiceQueryAuditLogHandler.ServeHTTP(w, r)
+		case StorageServiceSetFaultRulesProcedure:
+			storageServiceSetFaultRulesHandler.ServeHTTP(w, r)
+		case StorageServiceListFaultRulesProcedure:
+			storageServiceListFaultRulesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedStorageServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedStorageServiceHandler struct{}
+
+func (UnimplementedStorageServiceHandler) CreateBucket(context.Context, *connect.Request[storage.CreateBucketRequest]) (*connect.Response[storage.CreateBucketResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.CreateBucket is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) UploadObject(context.Context, *connect.Request[storage.UploadObjectRequest]) (*connect.Response[storage.UploadObjectResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.UploadObject is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) GetObjectMetadata(context.Context, *connect.Request[storage.GetObjectMetadataRequest]) (*connect.Response[storage.GetObjectMetadataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.GetObjectMetadata is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ListObjects(context.Context, *connect.Request[storage.ListObjectsRequest]) (*connect.Response[storage.ListObjectsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ListObjects is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) GetDownloadURL(context.Context, *connect.Request[storage.GetDownloadURLRequest]) (*connect.Response[storage.GetDownloadURLResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.GetDownloadURL is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) GetBucket(context.Context, *connect.Request[storage.GetBucketRequest]) (*connect.Response[storage.GetBucketResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.GetBucket is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ListBuckets(context.Context, *connect.Request[storage.ListBucketsRequest]) (*connect.Response[storage.ListBucketsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ListBuckets is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) UpdateBucket(context.Context, *connect.Request[storage.UpdateBucketRequest]) (*connect.Response[storage.UpdateBucketResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.UpdateBucket is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ReadObject(context.Context, *connect.Request[storage.ReadObjectRequest], *connect.ServerStream[storage.ReadObjectResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ReadObject is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) DeleteObject(context.Context, *connect.Request[storage.DeleteObjectRequest]) (*connect.Response[storage.DeleteObjectResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.DeleteObject is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) RewriteObject(context.Context, *connect.Request[storage.RewriteObjectRequest]) (*connect.Response[storage.RewriteObjectResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.RewriteObject is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ListEarlyDeletions(context.Context, *connect.Request[storage.ListEarlyDeletionsRequest]) (*connect.Response[storage.ListEarlyDeletionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ListEarlyDeletions is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) UpdateObject(context.Context, *connect.Request[storage.UpdateObjectRequest]) (*connect.Response[storage.UpdateObjectResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.UpdateObject is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) CreateNotificationConfig(context.Context, *connect.Request[storage.CreateNotificationConfigRequest]) (*connect.Response[storage.CreateNotificationConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.CreateNotificationConfig is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) GetNotificationConfig(context.Context, *connect.Request[storage.GetNotificationConfigRequest]) (*connect.Response[storage.GetNotificationConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.GetNotificationConfig is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ListNotificationConfigs(context.Context, *connect.Request[storage.ListNotificationConfigsRequest]) (*connect.Response[storage.ListNotificationConfigsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ListNotificationConfigs is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) DeleteNotificationConfig(context.Context, *connect.Request[storage.DeleteNotificationConfigRequest]) (*connect.Response[storage.DeleteNotificationConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.DeleteNotificationConfig is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) GetIamPolicy(context.Context, *connect.Request[storage.GetIamPolicyRequest]) (*connect.Response[storage.GetIamPolicyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.GetIamPolicy is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) SetIamPolicy(context.Context, *connect.Request[storage.SetIamPolicyRequest]) (*connect.Response[storage.SetIamPolicyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.SetIamPolicy is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) TestIamPermissions(context.Context, *connect.Request[storage.TestIamPermissionsRequest]) (*connect.Response[storage.TestIamPermissionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.TestIamPermissions is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ListAcl(context.Context, *connect.Request[storage.ListAclRequest]) (*connect.Response[storage.ListAclResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ListAcl is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) InsertAcl(context.Context, *connect.Request[storage.InsertAclRequest]) (*connect.Response[storage.InsertAclResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.InsertAcl is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) PatchAcl(context.Context, *connect.Request[storage.PatchAclRequest]) (*connect.Response[storage.PatchAclResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.PatchAcl is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) DeleteAcl(context.Context, *connect.Request[storage.DeleteAclRequest]) (*connect.Response[storage.DeleteAclResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.DeleteAcl is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) RotateKmsKey(context.Context, *connect.Request[storage.RotateKmsKeyRequest]) (*connect.Response[storage.RotateKmsKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.RotateKmsKey is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ComposeObject(context.Context, *connect.Request[storage.ComposeObjectRequest]) (*connect.Response[storage.ComposeObjectResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ComposeObject is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) GetStorageUsage(context.Context, *connect.Request[storage.GetStorageUsageRequest]) (*connect.Response[storage.GetStorageUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.GetStorageUsage is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) WriteObject(context.Context, *connect.ClientStream[storage.WriteObjectRequest]) (*connect.Response[storage.WriteObjectResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.WriteObject is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ExportState(context.Context, *connect.Request[storage.ExportStateRequest], *connect.ServerStream[storage.ExportStateResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ExportState is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ImportState(context.Context, *connect.ClientStream[storage.ImportStateRequest]) (*connect.Response[storage.ImportStateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ImportState is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) SeedStorage(context.Context, *connect.Request[storage.SeedStorageRequest]) (*connect.Response[storage.SeedStorageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.SeedStorage is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) QueryAuditLog(context.Context, *connect.Request[storage.QueryAuditLogRequest]) (*connect.Response[storage.QueryAuditLogResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.QueryAuditLog is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) SetFaultRules(context.Context, *connect.Request[storage.SetFaultRulesRequest]) (*connect.Response[storage.SetFaultRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.SetFaultRules is not implemented"))
+}
+
+func (UnimplementedStorageServiceHandler) ListFaultRules(context.Context, *connect.Request[storage.ListFaultRulesRequest]) (*connect.Response[storage.ListFaultRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("storage.v1.StorageService.ListFaultRules is not implemented"))
+}